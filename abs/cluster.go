@@ -1,6 +1,9 @@
 package abs
 
-import "github.com/pokerdroid/poker/card"
+import (
+	"github.com/google/uuid"
+	"github.com/pokerdroid/poker/card"
+)
 
 type Cluster uint32
 
@@ -9,3 +12,16 @@ type Clusters []Cluster
 type Mapper interface {
 	Map(cds card.Cards) Cluster
 }
+
+// Identified is implemented by abstractions solutions are trained with,
+// solution trees record ID of their abstraction. Lossless abstraction
+// has nil ID.
+type Identified interface {
+	ID() uuid.UUID
+}
+
+// Counted is implemented by abstractions which know number of clusters
+// of preflop, flop, turn and river. Zero count is unknown.
+type Counted interface {
+	Buckets() [4]uint32
+}
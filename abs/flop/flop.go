@@ -69,6 +69,9 @@ type PartitionOpts struct {
 	LogIteration   int
 	Rng            frand.Rand
 	Bins           int
+	// BatchSize runs mini-batch k-means with batches of the size.
+	BatchSize int
+	Seeding   abs.Seeding
 }
 
 // Partition executes the k-means algorithm on the given set of equities and
@@ -76,7 +79,11 @@ type PartitionOpts struct {
 func Partition(hh []abs.Histogram, opts PartitionOpts) (*Abs, error) {
 	rng := frand.Clone(opts.Rng)
 
-	groups, err := abs.NewRandomGroups(rng, hh, opts.Clusters)
+	distance := func(a, b abs.Histogram) float64 {
+		return a.Distance(b)
+	}
+
+	groups, err := abs.NewSeededGroups(rng, hh, opts.Clusters, distance, opts.Seeding)
 	if err != nil {
 		return nil, err
 	}
@@ -101,9 +108,10 @@ func Partition(hh []abs.Histogram, opts PartitionOpts) (*Abs, error) {
 			return center.Div(float32(len(e)))
 		},
 
-		Distance: func(a, b abs.Histogram) float64 {
-			return a.Distance(b)
-		},
+		Distance:   distance,
+		Accelerate: true,
+		BatchSize:  opts.BatchSize,
+		Blend:      abs.Histogram.Blend,
 	})
 	if err != nil {
 		return nil, err
@@ -142,30 +150,35 @@ type ComputeOpts struct {
 	LogIteration int
 }
 
-func Compute(opts ComputeOpts) ([]abs.Histogram, error) {
-	hh := make([]abs.Histogram, Size)
+// Hist returns histogram of river equities of flop hand over all
+// runouts, equity is the mean.
+func Hist(buckets *river.Buckets, cb card.Cards, bins int) abs.Histogram {
+	h := abs.Histogram{
+		Bins:   make([]float32, bins),
+		Equity: 0,
+	}
 
-	err := abs.IndexWorkers(iso.Flop.Size(), func(c int, done uint64) error {
-		cb := iso.Flop.Unindex(uint64(c))
-		h := abs.Histogram{
-			Bins:   make([]float32, opts.Bins),
-			Equity: 0,
+	var counter float32
+	for _, cx := range card.Combinations(2) {
+		if card.IsAnyMatch(cb, cx) {
+			continue
 		}
+		cbb := append(cb, cx...)
+		c := buckets.Get(abs.Cluster(iso.River.Index(cbb)))
+		h = h.Increment(c.WinDraw())
+		counter++
+	}
 
-		var counter float32
-		for _, cx := range card.Combinations(2) {
-			if card.IsAnyMatch(cb, cx) {
-				continue
-			}
-			cbb := append(cb, cx...)
-			c := opts.Buckets.Get(abs.Cluster(iso.River.Index(cbb)))
-			x := c.WinDraw()
-			h = h.Increment(x)
-			counter++
-		}
+	h = h.Normalize()
+	h.Equity = h.Equity / counter
+	return h
+}
 
-		h = h.Normalize()
-		h.Equity = h.Equity / counter
+func Compute(opts ComputeOpts) ([]abs.Histogram, error) {
+	hh := make([]abs.Histogram, Size)
+
+	err := abs.IndexWorkers(iso.Flop.Size(), func(c int, done uint64) error {
+		h := Hist(opts.Buckets, iso.Flop.Unindex(uint64(c)), opts.Bins)
 
 		if done%uint64(opts.LogIteration) == 0 {
 			opts.Logger.Printf("computing flop histograms: %d/%d", done, Size)
@@ -0,0 +1,122 @@
+package flop
+
+import (
+	"math"
+
+	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/abs/river"
+	"github.com/pokerdroid/poker/abs/turn"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/iso"
+)
+
+// Potential returns distribution of flop hand over turn clusters.
+func Potential(t *turn.Abs, eq []float32, cb card.Cards) abs.Potential {
+	cbb := make(card.Cards, 6)
+	copy(cbb, cb)
+
+	next := make([]abs.Cluster, 0, 47)
+	for _, cx := range card.All(cb...) {
+		cbb[5] = cx
+		next = append(next, t.Map(iso.Turn.Index(cbb)))
+	}
+
+	return abs.NewPotential(next, eq)
+}
+
+type PotentialOpts struct {
+	Turn           *turn.Abs
+	River          *river.Abs
+	Clusters       int
+	MaxIterations  int
+	DeltaThreshold float64
+	// TurnSamples is number of turn hands to estimate means of turn
+	// clusters, zero uses all hands.
+	TurnSamples  int
+	Logger       poker.Logger
+	LogIteration int
+	Rng          frand.Rand
+}
+
+// PartitionPotential builds potential-aware flop abstraction: flop
+// hands are clustered by their distribution over turn clusters with
+// EMD over distance of turn cluster means, which are distributions
+// over river clusters.
+func PartitionPotential(opts PotentialOpts) (*Abs, error) {
+	if opts.Rng == nil {
+		opts.Rng = frand.NewHash()
+	}
+
+	if opts.Logger == nil {
+		opts.Logger = poker.VoidLogger{}
+	}
+
+	rng := frand.Clone(opts.Rng)
+
+	opts.Logger.Printf("computing means of %d turn clusters", len(opts.Turn.Equity))
+
+	means, err := turn.Means(opts.Turn, opts.River, opts.TurnSamples, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	rground, err := turn.RiverGround(opts.River)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Logger.Printf("computing distances of turn clusters")
+
+	ground, err := abs.NewGround(len(means), func(i, j int) float64 {
+		// Empty means have no mass to move.
+		if len(means[i].Clusters) == 0 || len(means[j].Clusters) == 0 {
+			return math.Abs(float64(means[i].Equity - means[j].Equity))
+		}
+		return rground.EMD(means[i], means[j])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	eq := make([]float32, len(means))
+	for i, m := range means {
+		eq[i] = m.Equity
+	}
+
+	opts.Logger.Printf("computing flop potentials")
+
+	pp := make([]abs.Potential, Size)
+	err = abs.IndexWorkers(Size, func(c int, done uint64) error {
+		pp[c] = Potential(opts.Turn, eq, iso.Flop.Unindex(uint64(c)))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	centers, err := ground.Partition(pp, abs.PotentialOpts{
+		Clusters:      opts.Clusters,
+		MaxIterations: opts.MaxIterations,
+		MaxDelta:      opts.DeltaThreshold,
+		Logger:        opts.Logger,
+		LogIteration:  opts.LogIteration,
+		Rng:           rng,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	absx := new(Abs)
+	for _, c := range centers {
+		absx.Equity = append(absx.Equity, c.Equity)
+	}
+
+	err = abs.IndexWorkers(Size, func(c int, done uint64) error {
+		absx.Clusters[c] = abs.Cluster(ground.Nearest(centers, pp[c]))
+		return nil
+	})
+
+	return absx, err
+}
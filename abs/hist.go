@@ -47,6 +47,18 @@ func (h Histogram) Div(num float32) Histogram {
 	return h
 }
 
+// Blend returns (1-w)*h + w*other as new histogram.
+func (h Histogram) Blend(other Histogram, w float64) Histogram {
+	x := Histogram{
+		Bins:   make([]float32, len(h.Bins)),
+		Equity: float32(1-w)*h.Equity + float32(w)*other.Equity,
+	}
+	for i := range x.Bins {
+		x.Bins[i] = float32(1-w)*h.Bins[i] + float32(w)*other.Bins[i]
+	}
+	return x
+}
+
 func (h Histogram) EMD(other Histogram) float64 {
 	// If the number of bins differ, treat as maximum distance
 	if len(h.Bins) != len(other.Bins) {
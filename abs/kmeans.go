@@ -66,6 +66,89 @@ func (c Groups[H]) Nearest(point H, distance Distance[H]) int {
 	return ci
 }
 
+// NewPlusPlusGroups seeds k groups by k-means++: the first center is
+// random point, every next one is point drawn with probability
+// proportional to squared distance to the nearest chosen center.
+func NewPlusPlusGroups[H any](rng frand.Rand, data []H, k int, distance Distance[H]) (Groups[H], error) {
+	if k == 0 {
+		return nil, fmt.Errorf("k must be greater than 0")
+	}
+
+	if k > len(data) {
+		return nil, fmt.Errorf("the size of the data set must at least equal k")
+	}
+
+	c := Groups[H]{{Center: data[rng.Intn(len(data))]}}
+	d2 := make([]float64, len(data))
+	for i := range d2 {
+		d2[i] = math.Inf(1)
+	}
+
+	for len(c) < k {
+		center := c[len(c)-1].Center
+		err := IndexWorkers(len(data), func(i int, done uint64) error {
+			d := distance(data[i], center)
+			if d*d < d2[i] {
+				d2[i] = d * d
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var sum float64
+		for _, d := range d2 {
+			sum += d
+		}
+
+		// All points coincide with centers, pick any.
+		next := rng.Intn(len(data))
+		if sum > 0 {
+			x := rng.Float64() * sum
+			for i, d := range d2 {
+				x -= d
+				if x < 0 {
+					next = i
+					break
+				}
+			}
+		}
+
+		c = append(c, Group[H]{Center: data[next]})
+	}
+
+	return c, nil
+}
+
+// Seeding chooses initial centers of k-means.
+type Seeding struct {
+	// Random picks random points as NewRandomGroups, it is fast but
+	// results vary run to run.
+	Random bool
+	// Sample runs k-means++ on random sample of that many points
+	// instead of all of them, zero uses every point.
+	Sample int
+}
+
+// NewSeededGroups seeds k groups as configured by s, k-means++ over
+// all points by default.
+func NewSeededGroups[H any](rng frand.Rand, data []H, k int, distance Distance[H], s Seeding) (Groups[H], error) {
+	if s.Random {
+		return NewRandomGroups(rng, data, k)
+	}
+
+	if s.Sample > 0 && s.Sample < len(data) {
+		sample := make([]H, max(s.Sample, k))
+		for i := range sample {
+			sample[i] = data[rng.Intn(len(data))]
+		}
+		data = sample
+	}
+
+	return NewPlusPlusGroups(rng, data, k, distance)
+}
+
 type KMeansOpts[H any] struct {
 	Clusters      int
 	MaxIterations int
@@ -77,6 +160,17 @@ type KMeansOpts[H any] struct {
 
 	Recenter Recenter[H]
 	Distance Distance[H]
+
+	// Accelerate skips distances to centers which can't be nearer by
+	// triangle inequality (Hamerly), Distance must be a metric.
+	Accelerate bool
+
+	// BatchSize runs mini-batch k-means when positive. Every iteration
+	// assigns random batch of points and moves centers towards means
+	// of their batch points by Blend, which is required.
+	BatchSize int
+	// Blend returns (1-w)*a + w*b.
+	Blend func(a, b H, w float64) H
 }
 
 func KMeans[H any](data []H, opts KMeansOpts[H]) (Groups[H], float64, error) {
@@ -92,56 +186,40 @@ func KMeans[H any](data []H, opts KMeansOpts[H]) (Groups[H], float64, error) {
 		opts.Logger = poker.VoidLogger{}
 	}
 
-	cpus := runtime.NumCPU()
+	if len(opts.Groups) == 0 {
+		return nil, 0, fmt.Errorf("groups must be seeded")
+	}
+
+	if opts.BatchSize > 0 {
+		return miniBatch(data, opts)
+	}
 
 	chln := len(data)
-	points := make([]int, chln)
-	changes := 1
 	cc := opts.Groups
+	k := len(cc)
 
-	for i := 0; changes > 0; i++ {
-		opts.Logger.Printf("kmeans cycle: %d", i)
-
-		changes = 0
-		cc.Reset()
+	points := make([]int, chln)
+	upper := make([]float64, chln)
+	lower := make([]float64, chln)
+	for i := range points {
+		points[i] = -1
+	}
 
-		var wg sync.WaitGroup
-		var mux sync.Mutex
+	half := make([]float64, k)
+	old := make([]H, k)
 
-		type eq struct {
-			p H
-			i int
+	for i := 0; ; i++ {
+		if opts.Accelerate {
+			centersHalf(cc, half, opts.Distance)
 		}
-		ch := make(chan eq)
-
-		for i := 0; i < cpus; i++ {
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
 
-				for p := range ch {
-					ci := cc.Nearest(p.p, opts.Distance)
-					mux.Lock()
-					cc[ci].Append(p.p)
-					if points[p.i] != ci {
-						points[p.i] = ci
-						changes++
-					}
-					mux.Unlock()
-				}
-			}()
-		}
+		changes, inertia := assign(data, cc, points, upper, lower, half, opts)
 
-		for p, point := range data {
-			if opts.LogIteration > 0 && p%opts.LogIteration == 0 {
-				opts.Logger.Printf("kmeans iteration: %d", p)
-			}
-			ch <- eq{point, p}
+		cc.Reset()
+		for p, ci := range points {
+			cc[ci].Append(data[p])
 		}
 
-		close(ch)
-		wg.Wait()
-
 		for ci := 0; ci < len(cc); ci++ {
 			if len(cc[ci].Observations) == 0 {
 				// During the iterations, if any of the cluster centers has no
@@ -159,60 +237,233 @@ func KMeans[H any](data []H, opts KMeansOpts[H]) (Groups[H], float64, error) {
 				}
 				cc[ci].Append(data[ri])
 				points[ri] = ci
+				lower[ri] = 0
 				// Ensure that we always see at least one more iteration after
 				// randomly assigning a data point to a cluster
 				changes = chln
 			}
 		}
 
+		opts.Logger.Printf("kmeans iteration %d: inertia %f, changes %d", i, inertia, changes)
+
 		if changes > 0 {
-			opts.Logger.Printf("kmeans recentering, changes %d", changes)
+			for ci := range cc {
+				old[ci] = cc[ci].Center
+			}
 
-			perw := len(cc) / cpus
-			var madech uint32
-			for i := 0; i < cpus; i++ {
-				wg.Add(1)
-				go func(rng frand.Rand) {
-					defer wg.Done()
+			recenter(cc, opts)
 
-					start := i * perw
-					end := start + perw
+			// Other centers moved at most by the largest move.
+			if opts.Accelerate {
+				var moved float64
+				for ci := range cc {
+					moved = math.Max(moved, opts.Distance(old[ci], cc[ci].Center))
+				}
+				for p := range lower {
+					lower[p] = math.Max(0, lower[p]-moved)
+				}
+			}
+		}
 
-					if i == cpus-1 {
-						end = len(cc)
-					}
+		if changes == 0 || i == opts.MaxIterations || changes < int(float64(chln)*opts.MaxDelta) {
+			break
+		}
+	}
 
-					for start < end {
-						cc[start].Center = opts.Recenter(rng, cc[start].Observations)
-						start++
+	score := inertiaOf(cc, opts.Distance)
+	opts.Logger.Printf("kmeans score: %f", score)
 
-						atomic.AddUint32(&madech, 1)
+	return cc, score, nil
+}
 
-						if madech%100 == 0 {
-							opts.Logger.Printf("kmeans recentering: %d/%d", madech, len(cc))
-						}
-					}
-				}(frand.Clone(opts.Rng))
+// centersHalf sets half of distance from every center to the nearest
+// other center.
+func centersHalf[H any](cc Groups[H], half []float64, distance Distance[H]) {
+	for i := range half {
+		half[i] = math.Inf(1)
+	}
+
+	IndexWorkers(len(cc), func(i int, done uint64) error {
+		for j := range cc {
+			if i != j {
+				half[i] = math.Min(half[i], distance(cc[i].Center, cc[j].Center)/2)
 			}
-			wg.Wait()
 		}
+		return nil
+	})
+}
+
+// assign moves points to the nearest center and returns number of
+// changed points and inertia. Points with bounds proving the current
+// center is the nearest are not scanned when accelerated.
+func assign[H any](data []H, cc Groups[H], points []int, upper, lower, half []float64, opts KMeansOpts[H]) (int, float64) {
+	var changes int64
+	var mux sync.Mutex
+	var inertia float64
+
+	cpus := runtime.NumCPU()
+	per := (len(data) + cpus - 1) / cpus
 
-		if i == opts.MaxIterations || changes < int(float64(chln)*opts.MaxDelta) {
+	var wg sync.WaitGroup
+	for w := 0; w < cpus; w++ {
+		start := w * per
+		end := min(start+per, len(data))
+		if start >= end {
 			break
 		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var sum float64
+			for p := start; p < end; p++ {
+				if opts.LogIteration > 0 && p%opts.LogIteration == 0 {
+					opts.Logger.Printf("kmeans iteration: %d", p)
+				}
+
+				if a := points[p]; opts.Accelerate && a >= 0 {
+					upper[p] = opts.Distance(data[p], cc[a].Center)
+					if upper[p] <= math.Max(half[a], lower[p]) {
+						sum += upper[p] * upper[p]
+						continue
+					}
+				}
+
+				best, second := -1, math.Inf(1)
+				dist := math.Inf(1)
+				for ci := range cc {
+					d := opts.Distance(data[p], cc[ci].Center)
+					if d < dist {
+						best, second, dist = ci, dist, d
+					} else if d < second {
+						second = d
+					}
+				}
+
+				if points[p] != best {
+					points[p] = best
+					atomic.AddInt64(&changes, 1)
+				}
+				upper[p], lower[p] = dist, second
+				sum += dist * dist
+			}
+
+			mux.Lock()
+			inertia += sum
+			mux.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return int(changes), inertia
+}
+
+func recenter[H any](cc Groups[H], opts KMeansOpts[H]) {
+	var wg sync.WaitGroup
+
+	cpus := runtime.NumCPU()
+	perw := len(cc) / cpus
+	var madech uint32
+
+	for i := 0; i < cpus; i++ {
+		wg.Add(1)
+		go func(rng frand.Rand) {
+			defer wg.Done()
+
+			start := i * perw
+			end := start + perw
+
+			if i == cpus-1 {
+				end = len(cc)
+			}
+
+			for start < end {
+				cc[start].Center = opts.Recenter(rng, cc[start].Observations)
+				start++
+
+				atomic.AddUint32(&madech, 1)
+
+				if madech%100 == 0 {
+					opts.Logger.Printf("kmeans recentering: %d/%d", madech, len(cc))
+				}
+			}
+		}(frand.Clone(opts.Rng))
 	}
+	wg.Wait()
+}
 
+func inertiaOf[H any](cc Groups[H], distance Distance[H]) float64 {
 	score := 0.0
 	for _, c := range cc {
 		for _, o := range c.Observations {
-			distance := opts.Distance(c.Center, o)
-			score += distance * distance
+			d := distance(c.Center, o)
+			score += d * d
 		}
 	}
+	return score
+}
+
+// miniBatch runs mini-batch k-means. Center moves towards mean of its
+// batch points with rate of their share of all points it got so far.
+// Observations hold full assignment to the final centers.
+func miniBatch[H any](data []H, opts KMeansOpts[H]) (Groups[H], float64, error) {
+	if opts.Blend == nil {
+		return nil, 0, fmt.Errorf("mini-batch k-means requires blend")
+	}
+
+	cc := opts.Groups
+	counts := make([]float64, len(cc))
+	batch := make([]H, min(opts.BatchSize, len(data)))
+
+	iterations := opts.MaxIterations
+	if iterations == math.MaxInt64 {
+		iterations = 100
+	}
+
+	for i := 0; i < iterations; i++ {
+		cc.Reset()
+		for b := range batch {
+			batch[b] = data[opts.Rng.Intn(len(data))]
+		}
+
+		var inertia float64
+		for _, x := range batch {
+			ci := cc.Nearest(x, opts.Distance)
+			d := opts.Distance(x, cc[ci].Center)
+			inertia += d * d
+			cc[ci].Append(x)
+		}
+
+		for ci := range cc {
+			n := float64(len(cc[ci].Observations))
+			if n == 0 {
+				continue
+			}
+			counts[ci] += n
+			mean := opts.Recenter(opts.Rng, cc[ci].Observations)
+			cc[ci].Center = opts.Blend(cc[ci].Center, mean, n/counts[ci])
+		}
+
+		opts.Logger.Printf("kmeans batch %d: inertia %f", i, inertia/float64(len(batch))*float64(len(data)))
+	}
+
+	cc.Reset()
+	points := make([]int, len(data))
+	err := IndexWorkers(len(data), func(p int, done uint64) error {
+		points[p] = cc.Nearest(data[p], opts.Distance)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	for p, ci := range points {
+		cc[ci].Append(data[p])
+	}
 
+	score := inertiaOf(cc, opts.Distance)
 	opts.Logger.Printf("kmeans score: %f", score)
 
-	// here we need to add best score
 	return cc, score, nil
 }
 
@@ -2,10 +2,12 @@ package abs
 
 import (
 	"math"
+	"sort"
 	"testing"
 
 	"github.com/pokerdroid/poker"
 	"github.com/pokerdroid/poker/frand"
+	"github.com/stretchr/testify/require"
 )
 
 // TestKmeansGroups verifies that KMeans groups effectively cluster the data.
@@ -87,3 +89,137 @@ func TestKmeansGroups(t *testing.T) {
 		t.Fatalf("cluster centers not as expected: got %f and %f", center1, center2)
 	}
 }
+
+func blobs(rng frand.Rand, centers []float64, n int) []float64 {
+	var data []float64
+	for _, c := range centers {
+		for i := 0; i < n; i++ {
+			data = append(data, c+rng.Float64())
+		}
+	}
+	return data
+}
+
+func meanOf(rng frand.Rand, pts []float64) float64 {
+	sum := 0.0
+	for _, v := range pts {
+		sum += v
+	}
+	return sum / float64(len(pts))
+}
+
+func absDistance(x, y float64) float64 {
+	return math.Abs(x - y)
+}
+
+func TestPlusPlusGroups(t *testing.T) {
+	rng := frand.NewUnsafeInt(7)
+	data := blobs(rng, []float64{0, 100, 200, 300}, 50)
+
+	groups, err := NewPlusPlusGroups(rng, data, 4, absDistance)
+	require.NoError(t, err)
+	require.Len(t, groups, 4)
+
+	// Far apart blobs get one seed each.
+	seen := map[int]bool{}
+	for _, g := range groups {
+		seen[int(g.Center)/100] = true
+	}
+	require.Len(t, seen, 4)
+
+	_, err = NewPlusPlusGroups(rng, data[:3], 4, absDistance)
+	require.Error(t, err)
+}
+
+func TestSeededGroups(t *testing.T) {
+	rng := frand.NewUnsafeInt(7)
+	data := blobs(rng, []float64{0, 100, 200, 300}, 500)
+
+	// Sample of far apart blobs still gets one seed each.
+	groups, err := NewSeededGroups(rng, data, 4, absDistance, Seeding{Sample: 100})
+	require.NoError(t, err)
+	require.Len(t, groups, 4)
+
+	seen := map[int]bool{}
+	for _, g := range groups {
+		seen[int(g.Center)/100] = true
+	}
+	require.Len(t, seen, 4)
+
+	// Sample smaller than k is raised to k.
+	groups, err = NewSeededGroups(rng, data, 4, absDistance, Seeding{Sample: 2})
+	require.NoError(t, err)
+	require.Len(t, groups, 4)
+
+	groups, err = NewSeededGroups(rng, data, 4, absDistance, Seeding{Random: true})
+	require.NoError(t, err)
+	require.Len(t, groups, 4)
+
+	_, err = NewSeededGroups(rng, data[:3], 4, absDistance, Seeding{Sample: 100})
+	require.Error(t, err)
+}
+
+func TestKMeansAccelerate(t *testing.T) {
+	data := blobs(frand.NewUnsafeInt(1), []float64{0, 3, 6, 20, 21, 40}, 200)
+
+	run := func(accelerate bool) (Groups[float64], float64) {
+		rng := frand.NewUnsafeInt(5)
+		groups, err := NewPlusPlusGroups(rng, data, 6, absDistance)
+		require.NoError(t, err)
+
+		gg, score, err := KMeans(data, KMeansOpts[float64]{
+			Clusters:   6,
+			MaxDelta:   0.0000001,
+			Rng:        rng,
+			Groups:     groups,
+			Recenter:   meanOf,
+			Distance:   absDistance,
+			Accelerate: accelerate,
+		})
+		require.NoError(t, err)
+		return gg, score
+	}
+
+	naive, s1 := run(false)
+	fast, s2 := run(true)
+
+	require.InDelta(t, s1, s2, 1e-9)
+	for i := range naive {
+		require.InDelta(t, naive[i].Center, fast[i].Center, 1e-9)
+		require.Len(t, fast[i].Observations, len(naive[i].Observations))
+	}
+}
+
+func TestKMeansMiniBatch(t *testing.T) {
+	rng := frand.NewUnsafeInt(3)
+	data := blobs(rng, []float64{0, 100}, 500)
+
+	groups, err := NewPlusPlusGroups(rng, data, 2, absDistance)
+	require.NoError(t, err)
+
+	opts := KMeansOpts[float64]{
+		Clusters:      2,
+		MaxIterations: 50,
+		Rng:           rng,
+		Groups:        groups,
+		Recenter:      meanOf,
+		Distance:      absDistance,
+		BatchSize:     32,
+	}
+
+	_, _, err = KMeans(data, opts)
+	require.Error(t, err)
+
+	opts.Blend = func(a, b float64, w float64) float64 {
+		return (1-w)*a + w*b
+	}
+
+	gg, _, err := KMeans(data, opts)
+	require.NoError(t, err)
+
+	centers := []float64{gg[0].Center, gg[1].Center}
+	sort.Float64s(centers)
+	require.InDelta(t, 0.5, centers[0], 0.2)
+	require.InDelta(t, 100.5, centers[1], 0.2)
+	require.Len(t, gg[0].Observations, 500)
+}
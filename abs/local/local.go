@@ -0,0 +1,457 @@
+package local
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/encbin"
+	"github.com/pokerdroid/poker/eval"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/iso"
+)
+
+// rangeIndex is range index of hole cards in any order.
+var rangeIndex [53][53]int16
+
+func init() {
+	for i := 0; i < 1326; i++ {
+		cc := card.RangeCards(i)
+		rangeIndex[cc[0]][cc[1]] = int16(i)
+		rangeIndex[cc[1]][cc[0]] = int16(i)
+	}
+}
+
+// Abs is abstraction of a single flop used to solve subgame rooted at
+// the flop. Flop and turn hands are bucketed by histograms of their
+// river equity over runouts of the board, river hands are lossless
+// isomorphic hands. Only hands dealt on the board can be mapped.
+type Abs struct {
+	UID   uuid.UUID
+	Board card.Cards
+	// Flop clusters by range index of hole cards.
+	Flop []abs.Cluster
+	// Turn clusters by turn card and range index of hole cards, board
+	// cards have none.
+	Turn [53][]abs.Cluster
+}
+
+func (a *Abs) ID() uuid.UUID {
+	return a.UID
+}
+
+// Map returns cluster of hole cards followed by board cards.
+func (a *Abs) Map(cds card.Cards) abs.Cluster {
+	switch len(cds) {
+	case 2:
+		return abs.Cluster(iso.Preflop.Index(cds))
+	case 5:
+		return a.Flop[rangeIndex[cds[0]][cds[1]]]
+	case 6:
+		return a.Turn[cds[5]][rangeIndex[cds[0]][cds[1]]]
+	case 7:
+		return abs.Cluster(iso.River.Index(cds))
+	default:
+		panic("invalid number of cards")
+	}
+}
+
+type Opts struct {
+	FlopClusters  int
+	TurnClusters  int
+	Bins          int
+	MaxIterations int
+	Rng           frand.Rand
+	Logger        poker.Logger
+}
+
+func (o Opts) Validate() error {
+	if o.FlopClusters <= 0 || o.TurnClusters <= 0 {
+		return errors.New("clusters must be positive")
+	}
+	if o.Bins <= 0 {
+		return errors.New("bins must be positive")
+	}
+	return nil
+}
+
+// Build builds abstraction of the flop. Equity of every river hand is
+// computed against random hand, suit isomorphic flop and turn hands
+// share histogram and are clustered once. Streets with fewer hands
+// than clusters are lossless.
+func Build(board card.Cards, opts Opts) (*Abs, error) {
+	if len(board) != 3 {
+		return nil, errors.New("board must have 3 cards")
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.Logger == nil {
+		opts.Logger = poker.VoidLogger{}
+	}
+
+	if opts.Rng == nil {
+		opts.Rng = frand.NewHash()
+	}
+
+	rest := card.All(board...)
+	runouts := card.CombinationsFrom(rest, 2)
+
+	opts.Logger.Printf("computing river equities of %d runouts", len(runouts))
+
+	// Equities by river board, stored under both orders of runout.
+	eqs := make([][]float32, 53*53)
+	err := abs.IndexWorkers(len(runouts), func(i int, done uint64) error {
+		t, r := runouts[i][0], runouts[i][1]
+		e := RiverEquity(append(board.Clone(), t, r))
+		eqs[int(t)*53+int(r)] = e
+		eqs[int(r)*53+int(t)] = e
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Abs{
+		UID:   uuid.New(),
+		Board: board.Clone(),
+		Flop:  make([]abs.Cluster, 1326),
+	}
+
+	// Turn hands of all turn cards are clustered together.
+	turns := newStreet()
+	for _, t := range rest {
+		a.Turn[t] = make([]abs.Cluster, 1326)
+		cb := append(board.Clone(), t)
+		for i := 0; i < 1326; i++ {
+			hole := card.RangeCards(i)
+			if card.IsAnyMatch(hole, cb) {
+				continue
+			}
+			turns.add(iso.Turn.Index(append(hole.Clone(), cb...)), t, i)
+		}
+	}
+
+	opts.Logger.Printf("computing %d turn histograms", len(turns.hands))
+
+	turns.hists(opts.Bins, func(t card.Card, i int, h abs.Histogram) abs.Histogram {
+		hole := card.RangeCards(i)
+		for _, r := range rest {
+			if r == t || card.IsAnyMatch(hole, card.Cards{r}) {
+				continue
+			}
+			h = h.Increment(eqs[int(t)*53+int(r)][i])
+		}
+		return h
+	})
+
+	flops := newStreet()
+	for i := 0; i < 1326; i++ {
+		hole := card.RangeCards(i)
+		if card.IsAnyMatch(hole, board) {
+			continue
+		}
+		flops.add(iso.Flop.Index(append(hole.Clone(), board...)), 0, i)
+	}
+
+	opts.Logger.Printf("computing %d flop histograms", len(flops.hands))
+
+	flops.hists(opts.Bins, func(_ card.Card, i int, h abs.Histogram) abs.Histogram {
+		hole := card.RangeCards(i)
+		for _, ro := range runouts {
+			if card.IsAnyMatch(hole, ro) {
+				continue
+			}
+			h = h.Increment(eqs[int(ro[0])*53+int(ro[1])][i])
+		}
+		return h
+	})
+
+	opts.Logger.Printf("clustering flop")
+	if err := flops.partition(opts.FlopClusters, opts); err != nil {
+		return nil, err
+	}
+
+	opts.Logger.Printf("clustering turn")
+	if err := turns.partition(opts.TurnClusters, opts); err != nil {
+		return nil, err
+	}
+
+	for _, hh := range flops.hands {
+		for _, h := range hh {
+			a.Flop[h.idx] = flops.clusters[h.key]
+		}
+	}
+
+	for _, hh := range turns.hands {
+		for _, h := range hh {
+			a.Turn[h.turn][h.idx] = turns.clusters[h.key]
+		}
+	}
+
+	return a, nil
+}
+
+type streetHand struct {
+	key  int
+	turn card.Card
+	idx  int
+}
+
+// street groups hands of a street by isomorphic index.
+type street struct {
+	keys     map[uint32]int
+	hands    [][]streetHand
+	hh       []abs.Histogram
+	clusters []abs.Cluster
+}
+
+func newStreet() *street {
+	return &street{keys: make(map[uint32]int)}
+}
+
+func (s *street) add(index uint32, turn card.Card, idx int) {
+	k, ok := s.keys[index]
+	if !ok {
+		k = len(s.hands)
+		s.keys[index] = k
+		s.hands = append(s.hands, nil)
+	}
+	s.hands[k] = append(s.hands[k], streetHand{key: k, turn: turn, idx: idx})
+}
+
+// hists computes histogram of the first hand of every isomorphic group.
+func (s *street) hists(bins int, fill func(t card.Card, i int, h abs.Histogram) abs.Histogram) {
+	s.hh = make([]abs.Histogram, len(s.hands))
+
+	abs.IndexWorkers(len(s.hands), func(k int, done uint64) error {
+		h := s.hands[k][0]
+		x := fill(h.turn, h.idx, abs.Histogram{Bins: make([]float32, bins)})
+
+		var n float32
+		for _, b := range x.Bins {
+			n += b
+		}
+		x = x.Normalize()
+		x.Equity /= n
+		s.hh[k] = x
+		return nil
+	})
+}
+
+// partition clusters histograms into k clusters sorted by equity.
+func (s *street) partition(k int, opts Opts) error {
+	s.clusters = make([]abs.Cluster, len(s.hh))
+
+	if k >= len(s.hh) {
+		order := make([]int, len(s.hh))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return s.hh[order[i]].Equity < s.hh[order[j]].Equity
+		})
+		for c, i := range order {
+			s.clusters[i] = abs.Cluster(c)
+		}
+		return nil
+	}
+
+	rng := frand.Clone(opts.Rng)
+
+	distance := func(a, b abs.Histogram) float64 {
+		return a.Distance(b)
+	}
+
+	groups, err := abs.NewPlusPlusGroups(rng, s.hh, k, distance)
+	if err != nil {
+		return err
+	}
+
+	data, _, err := abs.KMeans(s.hh, abs.KMeansOpts[abs.Histogram]{
+		Clusters:      k,
+		MaxIterations: opts.MaxIterations,
+		MaxDelta:      0.0000001,
+		Logger:        opts.Logger,
+		LogIteration:  100_000,
+		Rng:           rng,
+		Groups:        groups,
+
+		Recenter: func(rng frand.Rand, e []abs.Histogram) abs.Histogram {
+			center := abs.Histogram{Bins: make([]float32, opts.Bins)}
+			for _, h := range e {
+				center = center.Add(h)
+			}
+			return center.Div(float32(len(e)))
+		},
+
+		Distance:   distance,
+		Accelerate: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].Center.Equity < data[j].Center.Equity
+	})
+
+	for i, h := range s.hh {
+		s.clusters[i] = abs.Cluster(data.Nearest(h, distance))
+	}
+
+	return nil
+}
+
+// RiverEquity returns equity of every hole cards against random hand
+// on 5 card board by range index, hands blocked by board are zero.
+// Hands are sorted by strength once, wins and ties are counted by
+// sweep with hands sharing a card removed.
+func RiverEquity(board card.Cards) []float32 {
+	type hand struct {
+		idx  int
+		a, b card.Card
+		rank card.HandRank
+	}
+
+	hands := make([]hand, 0, 1081)
+	cds := make(card.Cards, 7)
+	copy(cds[2:], board)
+
+	for i := 0; i < 1326; i++ {
+		hole := card.RangeCards(i)
+		if card.IsAnyMatch(hole, board) {
+			continue
+		}
+		cds[0], cds[1] = hole[0], hole[1]
+		rank, err := eval.Eval(cds...)
+		if err != nil {
+			panic(err)
+		}
+		hands = append(hands, hand{idx: i, a: hole[0], b: hole[1], rank: rank})
+	}
+
+	sort.Slice(hands, func(i, j int) bool {
+		return hands[i].rank.Compare(hands[j].rank) == 1
+	})
+
+	eq := make([]float32, 1326)
+	// Opponents of every hand, hands sharing either card are removed.
+	total := float32(len(hands) - 2*(52-len(board)-1) + 1)
+
+	var below int
+	var belowCard, tieCard [53]int
+
+	for lo := 0; lo < len(hands); {
+		hi := lo
+		for hi < len(hands) && hands[hi].rank.Compare(hands[lo].rank) == 2 {
+			tieCard[hands[hi].a]++
+			tieCard[hands[hi].b]++
+			hi++
+		}
+
+		for _, h := range hands[lo:hi] {
+			won := below - belowCard[h.a] - belowCard[h.b]
+			tied := (hi - lo) - tieCard[h.a] - tieCard[h.b] + 1
+			eq[h.idx] = (float32(won) + float32(tied)/2) / total
+		}
+
+		for _, h := range hands[lo:hi] {
+			tieCard[h.a]--
+			tieCard[h.b]--
+			belowCard[h.a]++
+			belowCard[h.b]++
+		}
+		below += hi - lo
+		lo = hi
+	}
+
+	return eq
+}
+
+func (a *Abs) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+
+	err := encbin.MarshalWithLen[uint16](buf, a.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encbin.MarshalSliceLen[card.Card, uint8](buf, a.Board)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encbin.MarshalSliceLen[abs.Cluster, uint16](buf, a.Flop)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range a.Turn {
+		err = encbin.MarshalSliceLen[abs.Cluster, uint16](buf, t)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (a *Abs) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewReader(data)
+
+	err := encbin.UnmarshalWithLen[uint16](buf, &a.UID)
+	if err != nil {
+		return err
+	}
+
+	a.Board, err = encbin.UnmarhsalSliceLen[card.Card, uint8](buf)
+	if err != nil {
+		return err
+	}
+
+	a.Flop, err = encbin.UnmarhsalSliceLen[abs.Cluster, uint16](buf)
+	if err != nil {
+		return err
+	}
+
+	if len(a.Board) != 3 || len(a.Flop) != 1326 {
+		return errors.New("invalid flop abstraction")
+	}
+
+	for i := range a.Turn {
+		a.Turn[i], err = encbin.UnmarhsalSliceLen[abs.Cluster, uint16](buf)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func NewFromFile(path string) (*Abs, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	a := &Abs{}
+	if err := a.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *Abs) WriteFile(path string) error {
+	data, err := a.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,82 @@
+package local
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/eval"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/iso"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRiverEquity(t *testing.T) {
+	board := card.NewCardsFromString("As Ks 2s 7d 7h")
+	eq := RiverEquity(board)
+
+	for _, hand := range []string{"Qs Js", "7c 2d", "3c 4d", "Ah Kd", "Kh Kc"} {
+		hole := card.NewCardsFromString(hand)
+		r1, err := eval.Eval(append(hole.Clone(), board...)...)
+		require.NoError(t, err)
+
+		var won, n float64
+		for _, opp := range card.Combinations(2) {
+			if card.IsAnyMatch(opp, board) || card.IsAnyMatch(opp, hole) {
+				continue
+			}
+			r2, err := eval.Eval(append(opp, board...)...)
+			require.NoError(t, err)
+			switch r1.Compare(r2) {
+			case 0:
+				won++
+			case 2:
+				won += 0.5
+			}
+			n++
+		}
+
+		require.InDelta(t, won/n, eq[rangeIndex[hole[0]][hole[1]]], 1e-5, hand)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	board := card.NewCardsFromString("As Ks 2s")
+
+	a, err := Build(board, Opts{
+		FlopClusters:  20,
+		TurnClusters:  30,
+		Bins:          10,
+		MaxIterations: 20,
+		Rng:           frand.NewUnsafeInt(1),
+	})
+	require.NoError(t, err)
+
+	mapf := func(s string) abs.Cluster {
+		return a.Map(card.NewCardsFromString(s))
+	}
+
+	// Suits not on board are isomorphic.
+	require.Equal(t, mapf("7h 6h As Ks 2s"), mapf("7d 6d As Ks 2s"))
+	require.Equal(t, mapf("6h 7h As Ks 2s"), mapf("7d 6d As Ks 2s"))
+	require.Equal(t, mapf("7h 6h As Ks 2s 9c"), mapf("7d 6d As Ks 2s 9c"))
+
+	// Clusters are sorted by equity.
+	require.Greater(t, mapf("Qs Js As Ks 2s"), mapf("7h 3d As Ks 2s"))
+	require.Greater(t, mapf("Qs Js As Ks 2s 9c"), mapf("7h 3d As Ks 2s 9c"))
+	require.Less(t, int(mapf("Qs Js As Ks 2s")), 20)
+
+	// River is lossless.
+	river := card.NewCardsFromString("Qs Js As Ks 2s 9c 3d")
+	require.Equal(t, abs.Cluster(iso.River.Index(river)), a.Map(river))
+
+	path := filepath.Join(t.TempDir(), "flop.bin")
+	require.NoError(t, a.WriteFile(path))
+
+	b, err := NewFromFile(path)
+	require.NoError(t, err)
+	require.Equal(t, a.UID, b.ID())
+	require.Equal(t, a.Flop, b.Flop)
+	require.Equal(t, a.Turn, b.Turn)
+}
@@ -0,0 +1,452 @@
+package omaha
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"sort"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/encbin"
+	"github.com/pokerdroid/poker/eval"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/iso"
+)
+
+// PreflopSize is number of isomorphic 4 card starting hands.
+const PreflopSize = 16_432
+
+// Indexers of omaha streets. Postflop indexes of turn and river don't
+// fit uint32, hands can't be mapped by table and are clustered on the
+// fly by nearest center.
+var (
+	preflop = iso.MustNew(1, []uint8{4})
+	flop    = iso.MustNew(2, []uint8{4, 3})
+	turn    = iso.MustNew(2, []uint8{4, 4})
+	river   = iso.MustNew(2, []uint8{4, 5})
+)
+
+// HistParams controls equity histogram of a hand.
+type HistParams struct {
+	Bins int
+	// Runouts is number of sampled boards completed to the river.
+	Runouts int
+	// Opponents is number of random opponent hands per runout.
+	Opponents int
+}
+
+func (p HistParams) Validate() error {
+	if p.Bins <= 0 || p.Runouts <= 0 || p.Opponents <= 0 {
+		return errors.New("bins, runouts and opponents must be positive")
+	}
+	return nil
+}
+
+// Hist returns equity histogram of 4 hole cards followed by 0, 3, 4
+// or 5 board cards. Every bin counts runouts by equity against
+// random hands, equity is the mean.
+func Hist(rng frand.Rand, cds card.Cards, p HistParams) abs.Histogram {
+	h := abs.Histogram{Bins: make([]float32, p.Bins)}
+
+	var base uint64
+	for _, c := range cds {
+		base |= 1 << c
+	}
+
+	hole := card.OmahaHand(cds[:4])
+	board := make(card.Cards, 5)
+	n := copy(board, cds[4:])
+
+	var opp card.OmahaHand
+
+	for r := 0; r < p.Runouts; r++ {
+		used := base
+		deal(rng, &used, board[n:])
+		hr := evalHand(hole, board)
+
+		var won float32
+		for o := 0; o < p.Opponents; o++ {
+			dealt := used
+			deal(rng, &dealt, opp[:])
+			switch hr.Compare(evalHand(opp, board)) {
+			case 0:
+				won++
+			case 2:
+				won += 0.5
+			}
+		}
+
+		h = h.Increment(won / float32(p.Opponents))
+	}
+
+	h = h.Normalize()
+	h.Equity /= float32(p.Runouts)
+	return h
+}
+
+func evalHand(hole card.OmahaHand, board card.Cards) card.HandRank {
+	r, _ := eval.EvalOmaha(hole, board)
+	return r
+}
+
+// deal fills cds with cards not in used.
+func deal(rng frand.Rand, used *uint64, cds []card.Card) {
+	for k := 0; k < len(cds); {
+		c := card.Card(rng.Intn(52) + 1)
+		if *used&(1<<c) != 0 {
+			continue
+		}
+		*used |= 1 << c
+		cds[k] = c
+		k++
+	}
+}
+
+// Abs clusters omaha hands by equity histograms. Preflop hands are
+// mapped by table of isomorphic hands, postflop hands by the nearest
+// center of histogram computed for canonical hand. Histograms of
+// canonical hands are sampled with rng seeded by their index, so
+// every hand maps to the same cluster.
+type Abs struct {
+	UID    uuid.UUID
+	Params HistParams
+	// Preflop clusters by isomorphic index.
+	Preflop []abs.Cluster
+	// Centers of flop, turn and river clusters sorted by equity.
+	Centers [3][]abs.Histogram
+
+	cache  [3][]uint64
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func (a *Abs) ID() uuid.UUID {
+	return a.UID
+}
+
+// DefaultCacheSize is number of slots of postflop street cache enabled
+// by NewFromFile.
+const DefaultCacheSize = 1 << 20
+
+// Map returns cluster of 4 hole cards followed by board cards.
+//
+// Postflop hands missing the cache are clustered on the fly with the
+// same histogram and distance the centers were built with, which is
+// costly, cache keeps clusters of hands seen before.
+func (a *Abs) Map(cds card.Cards) abs.Cluster {
+	var ix *iso.Indexer
+	switch len(cds) {
+	case 4:
+		return a.Preflop[preflop.Index(cds)]
+	case 7:
+		ix = flop
+	case 8:
+		ix = turn
+	case 9:
+		ix = river
+	default:
+		panic("invalid number of cards")
+	}
+
+	st := len(cds) - 7
+	idx := ix.Index(cds)
+
+	if a.cache[st] == nil {
+		return a.nearest(st, ix, idx, len(cds))
+	}
+
+	// Entries hold index+1 above cluster, zero is empty slot.
+	slot := &a.cache[st][idx%uint64(len(a.cache[st]))]
+	if e := atomic.LoadUint64(slot); e>>16 == idx+1 {
+		a.hits.Add(1)
+		return abs.Cluster(e & 0xffff)
+	}
+
+	a.misses.Add(1)
+	c := a.nearest(st, ix, idx, len(cds))
+	atomic.StoreUint64(slot, (idx+1)<<16|uint64(c))
+	return c
+}
+
+// EnableCache turns on lock-free cache of postflop clusters with slots
+// entries per street, zero slots turn it off. Hands colliding in a slot
+// evict each other.
+func (a *Abs) EnableCache(slots int) {
+	for i := range a.cache {
+		a.cache[i] = nil
+		if slots > 0 {
+			a.cache[i] = make([]uint64, slots)
+		}
+	}
+	a.hits.Store(0)
+	a.misses.Store(0)
+}
+
+// CacheStats returns number of postflop cache hits and misses since
+// cache was enabled.
+func (a *Abs) CacheStats() (hits, misses uint64) {
+	return a.hits.Load(), a.misses.Load()
+}
+
+func (a *Abs) nearest(st int, ix *iso.Indexer, idx uint64, size int) abs.Cluster {
+	cds := ix.Unindex(1, idx, size)
+	rng := frand.NewUnsafeInt(int64(idx)*3 + int64(st))
+	h := Hist(rng, cds, a.Params)
+	return abs.Cluster(nearest(a.Centers[st], h))
+}
+
+func nearest(centers []abs.Histogram, h abs.Histogram) int {
+	var ci int
+	dist := -1.0
+	for i, c := range centers {
+		d := h.Distance(c)
+		if dist < 0 || d < dist {
+			dist = d
+			ci = i
+		}
+	}
+	return ci
+}
+
+type BuildParams struct {
+	HistParams
+	// Clusters of preflop, flop, turn and river.
+	Clusters [4]int
+	// Samples is number of random hands clustered for every postflop street.
+	Samples       int
+	MaxIterations int
+	Rng           frand.Rand
+	Logger        poker.Logger
+}
+
+// Build computes histograms of all preflop hands and sampled postflop
+// hands and clusters every street by k-means.
+func Build(p BuildParams) (*Abs, error) {
+	if err := p.HistParams.Validate(); err != nil {
+		return nil, err
+	}
+
+	for _, k := range p.Clusters {
+		if k <= 0 || k > 0xffff {
+			return nil, errors.New("clusters must be between 1 and 65535")
+		}
+	}
+
+	if p.Rng == nil {
+		p.Rng = frand.NewHash()
+	}
+
+	if p.Logger == nil {
+		p.Logger = poker.VoidLogger{}
+	}
+
+	a := &Abs{
+		UID:     uuid.New(),
+		Params:  p.HistParams,
+		Preflop: make([]abs.Cluster, PreflopSize),
+	}
+
+	p.Logger.Printf("computing preflop histograms")
+
+	hh := make([]abs.Histogram, PreflopSize)
+	err := abs.IndexWorkers(PreflopSize, func(i int, done uint64) error {
+		cds := preflop.Unindex(0, uint64(i), 4)
+		hh[i] = Hist(frand.NewUnsafeInt(int64(i)), cds, p.HistParams)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	centers, err := partition(hh, p.Clusters[0], p)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, h := range hh {
+		a.Preflop[i] = abs.Cluster(nearest(centers, h))
+	}
+
+	for st, size := range []int{7, 8, 9} {
+		p.Logger.Printf("computing histograms of %d cards", size)
+
+		ix := [...]*iso.Indexer{flop, turn, river}[st]
+		idx := make([]uint64, p.Samples)
+		for i := range idx {
+			var used uint64
+			cds := make(card.Cards, size)
+			deal(p.Rng, &used, cds)
+			idx[i] = ix.Index(cds)
+		}
+
+		hh := make([]abs.Histogram, p.Samples)
+		err := abs.IndexWorkers(p.Samples, func(i int, done uint64) error {
+			cds := ix.Unindex(1, idx[i], size)
+			rng := frand.NewUnsafeInt(int64(idx[i])*3 + int64(st))
+			hh[i] = Hist(rng, cds, p.HistParams)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		a.Centers[st], err = partition(hh, p.Clusters[st+1], p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
+}
+
+// partition clusters histograms and returns centers sorted by equity.
+func partition(hh []abs.Histogram, k int, p BuildParams) ([]abs.Histogram, error) {
+	distance := func(a, b abs.Histogram) float64 {
+		return a.Distance(b)
+	}
+
+	groups, err := abs.NewPlusPlusGroups(p.Rng, hh, k, distance)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := abs.KMeans(hh, abs.KMeansOpts[abs.Histogram]{
+		Clusters:      k,
+		MaxIterations: p.MaxIterations,
+		MaxDelta:      0.0000001,
+		Logger:        p.Logger,
+		Rng:           p.Rng,
+		Groups:        groups,
+
+		Recenter: func(rng frand.Rand, e []abs.Histogram) abs.Histogram {
+			center := abs.Histogram{Bins: make([]float32, p.Bins)}
+			for _, h := range e {
+				center = center.Add(h)
+			}
+			return center.Div(float32(len(e)))
+		},
+
+		Distance:   distance,
+		Accelerate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].Center.Equity < data[j].Center.Equity
+	})
+
+	centers := make([]abs.Histogram, len(data))
+	for i, g := range data {
+		centers[i] = g.Center
+	}
+
+	return centers, nil
+}
+
+func (a *Abs) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+
+	err := encbin.MarshalWithLen[uint16](buf, a.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encbin.MarshalValues(buf,
+		uint16(a.Params.Bins), uint32(a.Params.Runouts), uint32(a.Params.Opponents))
+	if err != nil {
+		return nil, err
+	}
+
+	err = encbin.MarshalSliceLen[abs.Cluster, uint32](buf, a.Preflop)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cc := range a.Centers {
+		err = encbin.MarshalValues(buf, uint32(len(cc)))
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range cc {
+			err = encbin.MarshalValues(buf, c.Equity, c.Bins)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (a *Abs) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewReader(data)
+
+	err := encbin.UnmarshalWithLen[uint16](buf, &a.UID)
+	if err != nil {
+		return err
+	}
+
+	var bins uint16
+	var runouts, opponents uint32
+	err = encbin.UnmarshalValues(buf, &bins, &runouts, &opponents)
+	if err != nil {
+		return err
+	}
+	a.Params = HistParams{Bins: int(bins), Runouts: int(runouts), Opponents: int(opponents)}
+
+	a.Preflop, err = encbin.UnmarhsalSliceLen[abs.Cluster, uint32](buf)
+	if err != nil {
+		return err
+	}
+
+	if len(a.Preflop) != PreflopSize {
+		return errors.New("invalid preflop table")
+	}
+
+	for i := range a.Centers {
+		var n uint32
+		err = encbin.UnmarshalValues(buf, &n)
+		if err != nil {
+			return err
+		}
+		a.Centers[i] = make([]abs.Histogram, n)
+		for k := range a.Centers[i] {
+			c := abs.Histogram{Bins: make([]float32, bins)}
+			err = encbin.UnmarshalValues(buf, &c.Equity, c.Bins)
+			if err != nil {
+				return err
+			}
+			a.Centers[i][k] = c
+		}
+	}
+
+	return nil
+}
+
+// NewFromFile loads abstraction stored by WriteFile, cache is enabled.
+func NewFromFile(path string) (*Abs, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	a := &Abs{}
+	if err := a.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	a.EnableCache(DefaultCacheSize)
+	return a, nil
+}
+
+func (a *Abs) WriteFile(path string) error {
+	data, err := a.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
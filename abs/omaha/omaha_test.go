@@ -0,0 +1,78 @@
+package omaha
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHist(t *testing.T) {
+	p := HistParams{Bins: 10, Runouts: 200, Opponents: 4}
+
+	aces := Hist(frand.NewUnsafeInt(1), card.NewCardsFromString("As Ah Ks Kh"), p)
+	trash := Hist(frand.NewUnsafeInt(1), card.NewCardsFromString("7c 2d 3h 8s"), p)
+	require.Greater(t, aces.Equity, trash.Equity)
+
+	var sum float32
+	for _, b := range aces.Bins {
+		sum += b
+	}
+	require.InDelta(t, 1, sum, 0.0001)
+
+	// Made nuts on the river always wins.
+	nuts := Hist(frand.NewUnsafeInt(1), card.NewCardsFromString("As Ks 2d 3c Qs Js Ts 4h 5h"), p)
+	require.Equal(t, float32(1), nuts.Equity)
+}
+
+func TestBuild(t *testing.T) {
+	a, err := Build(BuildParams{
+		HistParams:    HistParams{Bins: 5, Runouts: 4, Opponents: 2},
+		Clusters:      [4]int{4, 4, 4, 4},
+		Samples:       200,
+		MaxIterations: 10,
+		Rng:           frand.NewUnsafeInt(42),
+	})
+	require.NoError(t, err)
+	require.Len(t, a.Preflop, PreflopSize)
+
+	for _, cc := range a.Centers {
+		require.Len(t, cc, 4)
+		for i := 1; i < len(cc); i++ {
+			require.LessOrEqual(t, cc[i-1].Equity, cc[i].Equity)
+		}
+	}
+
+	// Suit isomorphic hands map to the same cluster.
+	h1 := card.NewCardsFromString("As Ah Ks Kh 7d 8d 9c")
+	h2 := card.NewCardsFromString("Ah As Kh Ks 7c 8c 9d")
+	require.Equal(t, a.Map(h1), a.Map(h2))
+	require.Equal(t, a.Map(h1[:4]), a.Map(h2[:4]))
+
+	// Postflop hands map by the distance centers were built with.
+	idx := flop.Index(h1)
+	h := Hist(frand.NewUnsafeInt(int64(idx)*3), flop.Unindex(1, idx, 7), a.Params)
+	require.Equal(t, abs.Cluster(nearest(a.Centers[0], h)), a.Map(h1))
+
+	a.EnableCache(1 << 10)
+	require.Equal(t, a.Map(h1), a.Map(h2))
+	require.Less(t, int(a.Map(h1[:4])), 4)
+
+	hits, misses := a.CacheStats()
+	require.Equal(t, uint64(1), hits)
+	require.Equal(t, uint64(1), misses)
+
+	path := filepath.Join(t.TempDir(), "omaha.bin")
+	require.NoError(t, a.WriteFile(path))
+
+	b, err := NewFromFile(path)
+	require.NoError(t, err)
+	require.Equal(t, a.UID, b.UID)
+	require.Equal(t, a.Params, b.Params)
+	require.Equal(t, a.Preflop, b.Preflop)
+	require.Equal(t, a.Centers, b.Centers)
+	require.Equal(t, a.Map(h1), b.Map(h1))
+}
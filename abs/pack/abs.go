@@ -18,34 +18,40 @@ import (
 	"github.com/pokerdroid/poker/table"
 )
 
-type Iso struct{}
+// Iso is lossless abstraction, cluster is isomorphic index of hand.
+type Iso struct {
+	Variant card.Variant
+}
 
 func NewIso() Iso {
 	return Iso{}
 }
 
-func (Iso) Map(cds card.Cards) abs.Cluster {
+// NewIsoVariant creates lossless abstraction of variant deck.
+func NewIsoVariant(v card.Variant) Iso {
+	return Iso{Variant: v}
+}
 
-	// pretty.Println(cds)
-	switch len(cds) {
-	case 2:
-		return abs.Cluster(iso.Preflop.Index(cds))
-	case 5:
-		return abs.Cluster(iso.Flop.Index(cds))
-	case 6:
-		return abs.Cluster(iso.Turn.Index(cds))
-	case 7:
-		return abs.Cluster(iso.River.Index(cds))
-	default:
-		panic("invalid number of cards")
-	}
+func (i Iso) Map(cds card.Cards) abs.Cluster {
+	return abs.Cluster(iso.Variant(i.Variant).Street(len(cds)).Index(cds))
+}
+
+// ID of lossless abstraction is nil.
+func (i Iso) ID() uuid.UUID {
+	return uuid.Nil
+}
+
+func (i Iso) String() string {
+	return "lossless " + i.Variant.String()
 }
 
 type Abs struct {
-	UID   uuid.UUID
-	Flop  *flop.Abs
-	Turn  *turn.Abs
-	River *river.Abs
+	UID uuid.UUID
+	// Method describes how streets were clustered.
+	Method string
+	Flop   *flop.Abs
+	Turn   *turn.Abs
+	River  *river.Abs
 }
 
 func NewFromFile(filename string) (*Abs, error) {
@@ -138,6 +144,11 @@ func (a *Abs) MarshalBinary() ([]byte, error) {
 		return nil, err
 	}
 
+	err = encbin.MarshalWithLen[uint16](buf, a.Header())
+	if err != nil {
+		return nil, err
+	}
+
 	return buf.Bytes(), nil
 }
 
@@ -164,6 +175,18 @@ func (a *Abs) UnmarshalBinary(data []byte) error {
 		return err
 	}
 
+	// Abstractions packed before header have nothing left.
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	var h Header
+	err = encbin.UnmarshalWithLen[uint16](buf, &h)
+	if err != nil {
+		return err
+	}
+	a.Method = h.Method
+
 	return nil
 }
 
@@ -0,0 +1,200 @@
+package absp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pokerdroid/poker/encbin"
+	"github.com/pokerdroid/poker/iso"
+)
+
+// Header identifies packed abstraction. It's stored after the streets,
+// abstractions packed before it have empty method.
+type Header struct {
+	UID uuid.UUID
+	// Buckets is number of clusters of preflop, flop, turn and river.
+	Buckets [4]uint32
+	// Method describes how streets were clustered.
+	Method string
+}
+
+func (h Header) String() string {
+	method := h.Method
+	if method == "" {
+		method = "unknown method"
+	}
+	return fmt.Sprintf("%s (%s, buckets %d/%d/%d/%d)",
+		h.UID, method, h.Buckets[0], h.Buckets[1], h.Buckets[2], h.Buckets[3])
+}
+
+func (h Header) MarshalBinary() ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+
+	err := encbin.MarshalValues(buf, h.Buckets)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encbin.MarshalSliceLen[byte, uint16](buf, []byte(h.Method))
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (h *Header) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewBuffer(data)
+
+	err := encbin.UnmarshalValues(buf, &h.Buckets)
+	if err != nil {
+		return err
+	}
+
+	method, err := encbin.UnmarhsalSliceLen[byte, uint16](buf)
+	if err != nil {
+		return err
+	}
+	h.Method = string(method)
+
+	return nil
+}
+
+// Header returns header of abstraction with bucket counts of its
+// streets.
+func (a *Abs) Header() Header {
+	return Header{
+		UID: a.UID,
+		Buckets: [4]uint32{
+			uint32(iso.Preflop.Size()),
+			uint32(len(a.Flop.Equity)),
+			uint32(len(a.Turn.Equity)),
+			uint32(len(a.River.Equities)),
+		},
+		Method: a.Method,
+	}
+}
+
+func (a *Abs) ID() uuid.UUID {
+	return a.UID
+}
+
+func (a *Abs) Buckets() [4]uint32 {
+	return a.Header().Buckets
+}
+
+func (a *Abs) String() string {
+	return a.Header().String()
+}
+
+// ReadHeader reads header of packed abstraction without loading its
+// streets. Bucket counts of abstractions packed without header are
+// zero.
+func ReadHeader(filename string) (Header, error) {
+	var h Header
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return h, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return h, err
+	}
+
+	err = encbin.UnmarshalWithLen[uint16](f, &h.UID)
+	if err != nil {
+		return h, err
+	}
+
+	// Skip flop, turn and river.
+	for i := 0; i < 3; i++ {
+		var n uint64
+		err = binary.Read(f, binary.LittleEndian, &n)
+		if err != nil {
+			return h, err
+		}
+		pos, err := f.Seek(int64(n), io.SeekCurrent)
+		if err != nil {
+			return h, err
+		}
+		if pos > info.Size() {
+			return h, errors.New("not a packed abstraction")
+		}
+	}
+
+	ok, err := encbin.UnmarshalWithLenNil[uint16](f, &h)
+	if errors.Is(err, io.EOF) || (err == nil && !ok) {
+		return h, nil
+	}
+	return h, err
+}
+
+// FindFile returns path to packed abstraction with the uid in dirs.
+// Solution trees in dirs are skipped. Directories are searched
+// recursively, each of them once even if dirs are nested.
+func FindFile(uid uuid.UUID, dirs ...string) (string, error) {
+	var found string
+	var walked []string
+
+	for _, dir := range dirs {
+		dir = filepath.Clean(dir)
+		if within(dir, walked) {
+			continue
+		}
+
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || found != "" {
+				return err
+			}
+
+			if info.IsDir() {
+				if path != dir && within(path, walked) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			name := filepath.Base(path)
+			if !strings.HasSuffix(name, ".bin") || strings.HasPrefix(name, "tree") {
+				return nil
+			}
+
+			// Files which aren't packed abstractions fail to read.
+			h, err := ReadHeader(path)
+			if err == nil && h.UID == uid {
+				found = path
+			}
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+		if found != "" {
+			return found, nil
+		}
+		walked = append(walked, dir)
+	}
+
+	return "", fmt.Errorf("abstraction %s not found in %s", uid, strings.Join(dirs, ", "))
+}
+
+// within returns true if path is one of dirs or inside of it.
+func within(path string, dirs []string) bool {
+	for _, d := range dirs {
+		rel, err := filepath.Rel(d, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package absp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pokerdroid/poker/encbin"
+	"github.com/stretchr/testify/require"
+)
+
+// writePacked writes file laid out as packed abstraction with streets
+// replaced by placeholder bytes.
+func writePacked(t *testing.T, path string, h *Header) {
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, encbin.MarshalWithLen[uint16](buf, h.UID))
+
+	for i := 0; i < 3; i++ {
+		street := bytes.Repeat([]byte{byte(i)}, 10+i)
+		require.NoError(t, binary.Write(buf, binary.LittleEndian, uint64(len(street))))
+		buf.Write(street)
+	}
+
+	if h.Method != "" {
+		require.NoError(t, encbin.MarshalWithLen[uint16](buf, h))
+	}
+
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+}
+
+func TestReadHeader(t *testing.T) {
+	dir := t.TempDir()
+
+	h := Header{
+		UID:     uuid.New(),
+		Buckets: [4]uint32{169, 200, 300, 400},
+		Method:  "flop:hist turn:potential river:ochs",
+	}
+	writePacked(t, filepath.Join(dir, "abs.bin"), &h)
+
+	got, err := ReadHeader(filepath.Join(dir, "abs.bin"))
+	require.NoError(t, err)
+	require.Equal(t, h, got)
+	require.Contains(t, got.String(), "buckets 169/200/300/400")
+
+	// Abstraction packed without header.
+	legacy := Header{UID: uuid.New()}
+	writePacked(t, filepath.Join(dir, "legacy.bin"), &legacy)
+
+	got, err = ReadHeader(filepath.Join(dir, "legacy.bin"))
+	require.NoError(t, err)
+	require.Equal(t, legacy, got)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.bin"), []byte{16, 0, 1, 2}, 0644))
+	_, err = ReadHeader(filepath.Join(dir, "other.bin"))
+	require.Error(t, err)
+}
+
+func TestFindFile(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "clustering")
+	require.NoError(t, os.MkdirAll(sub, 0755))
+
+	a := Header{UID: uuid.New(), Method: "a"}
+	b := Header{UID: uuid.New(), Method: "b"}
+	writePacked(t, filepath.Join(dir, "a.bin"), &a)
+	writePacked(t, filepath.Join(sub, "b.bin"), &b)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tree_1.bin"), []byte{1, 2, 3}, 0644))
+
+	path, err := FindFile(b.UID, dir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(sub, "b.bin"), path)
+
+	_, err = FindFile(uuid.New(), dir, filepath.Join(dir, "missing"))
+	require.Error(t, err)
+
+	// Nested directories are searched once in any order.
+	path, err = FindFile(a.UID, sub, dir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "a.bin"), path)
+
+	path, err = FindFile(b.UID, dir, sub, dir+string(filepath.Separator))
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(sub, "b.bin"), path)
+
+	require.True(t, within(sub, []string{dir}))
+	require.False(t, within(dir, []string{sub}))
+	require.False(t, within(dir+"2", []string{dir}))
+}
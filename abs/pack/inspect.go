@@ -0,0 +1,317 @@
+package absp
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/abs/flop"
+	"github.com/pokerdroid/poker/abs/river"
+	"github.com/pokerdroid/poker/abs/turn"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/iso"
+	"github.com/pokerdroid/poker/table"
+)
+
+type InspectOpts struct {
+	// Buckets of river equities, equity and EMD statistics are skipped
+	// without them.
+	Buckets *river.Buckets
+	Bins    int
+	// Samples is number of random hands evaluated per street, zero
+	// evaluates all hands.
+	Samples int
+	// Examples is number of hands listed per cluster.
+	Examples int
+	// Tiny is share of average cluster size below which cluster is tiny.
+	Tiny   float64
+	Rng    frand.Rand
+	Logger poker.Logger
+}
+
+// StreetReport describes quality of street abstraction. Sizes count
+// isomorphic hands of all street, equity statistics are computed on
+// evaluated hands.
+type StreetReport struct {
+	Street   table.Street
+	Clusters int
+	Hands    int
+
+	MinSize    int
+	MedianSize int
+	MaxSize    int
+	Empty      int
+	Tiny       int
+
+	// Evaluated is number of hands equity statistics are computed on.
+	Evaluated int
+	// EquityStd is square root of mean within cluster variance of equity.
+	EquityStd float64
+	// EquityError is mean absolute difference of hand equity and
+	// equity of its cluster center.
+	EquityError float64
+	// EMDMean and EMDMax are distances of hand histograms to mean
+	// histogram of their cluster, river has no histograms.
+	EMDMean float64
+	EMDMax  float64
+
+	Examples [][]card.Cards
+}
+
+func (r StreetReport) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("%s: %d clusters, %d hands\n", r.Street, r.Clusters, r.Hands))
+	sb.WriteString(fmt.Sprintf("  size min/median/max: %d/%d/%d, empty: %d, tiny: %d\n",
+		r.MinSize, r.MedianSize, r.MaxSize, r.Empty, r.Tiny))
+
+	if r.Evaluated > 0 {
+		sb.WriteString(fmt.Sprintf("  evaluated: %d, equity std: %.4f, equity error: %.4f\n",
+			r.Evaluated, r.EquityStd, r.EquityError))
+		if r.Street != table.River {
+			sb.WriteString(fmt.Sprintf("  emd mean: %.4f, emd max: %.4f\n", r.EMDMean, r.EMDMax))
+		}
+	}
+
+	for c, ex := range r.Examples {
+		if len(ex) == 0 {
+			continue
+		}
+		hands := make([]string, len(ex))
+		for i, h := range ex {
+			hands[i] = h.String()
+		}
+		sb.WriteString(fmt.Sprintf("  %5d: %s\n", c, strings.Join(hands, ", ")))
+	}
+
+	return sb.String()
+}
+
+// Inspect reports quality of flop, turn and river abstraction.
+// Preflop is lossless.
+func Inspect(a *Abs, opts InspectOpts) ([]StreetReport, error) {
+	if opts.Logger == nil {
+		opts.Logger = poker.VoidLogger{}
+	}
+
+	if opts.Rng == nil {
+		opts.Rng = frand.NewHash()
+	}
+
+	if opts.Bins == 0 {
+		opts.Bins = 20
+	}
+
+	streets := []struct {
+		street   table.Street
+		iso      *iso.Street
+		clusters func(i int) abs.Cluster
+		equity   []float32
+	}{
+		{table.Flop, iso.Flop, func(i int) abs.Cluster { return a.Flop.Clusters[i] }, a.Flop.Equity},
+		{table.Turn, iso.Turn, func(i int) abs.Cluster { return a.Turn.Clusters[i] }, a.Turn.Equity},
+		{table.River, iso.River, func(i int) abs.Cluster { return a.River.Clusters[i] }, riverEquity(a.River)},
+	}
+
+	var reports []StreetReport
+
+	for _, st := range streets {
+		opts.Logger.Printf("inspecting %s", st.street)
+
+		ins := newInspector(st.street, len(st.equity))
+		size := st.iso.Size()
+
+		for i := 0; i < size; i++ {
+			ins.sizes[st.clusters(i)]++
+		}
+
+		idx := sampleIndexes(opts.Rng, size, opts.Samples)
+
+		hands := make([]card.Cards, len(idx))
+		hists := make([]abs.Histogram, len(idx))
+
+		err := abs.IndexWorkers(len(idx), func(i int, done uint64) error {
+			hands[i] = st.iso.Unindex(uint64(idx[i]))
+			if opts.Buckets == nil {
+				return nil
+			}
+			switch st.street {
+			case table.Flop:
+				hists[i] = flop.Hist(opts.Buckets, hands[i], opts.Bins)
+			case table.Turn:
+				hists[i] = turn.Hist(opts.Buckets, hands[i], opts.Bins)
+			default:
+				eq := opts.Buckets.Get(abs.Cluster(idx[i])).WinDraw()
+				hists[i] = abs.Histogram{Equity: eq}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i, x := range idx {
+			c := st.clusters(x)
+			ins.example(c, hands[i], opts.Examples)
+			if opts.Buckets != nil {
+				ins.add(c, hists[i], st.equity[c])
+			}
+		}
+
+		reports = append(reports, ins.report(opts.Tiny))
+	}
+
+	return reports, nil
+}
+
+func riverEquity(r *river.Abs) []float32 {
+	eq := make([]float32, len(r.Equities))
+	for i, e := range r.Equities {
+		eq[i] = e.WinDraw()
+	}
+	return eq
+}
+
+func sampleIndexes(rng frand.Rand, size, n int) []int {
+	if n <= 0 || n >= size {
+		idx := make([]int, size)
+		for i := range idx {
+			idx[i] = i
+		}
+		return idx
+	}
+
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = rng.Intn(size)
+	}
+	return idx
+}
+
+// inspector accumulates statistics of street abstraction. Histograms
+// of hands are added twice, first for cluster means and then for
+// distances to them.
+type inspector struct {
+	street table.Street
+	sizes  []int
+
+	n      []float64
+	sum    []float64
+	sumsq  []float64
+	errsum float64
+	hists  []abs.Histogram
+	points []struct {
+		c abs.Cluster
+		h abs.Histogram
+	}
+
+	examples [][]card.Cards
+}
+
+func newInspector(street table.Street, k int) *inspector {
+	return &inspector{
+		street:   street,
+		sizes:    make([]int, k),
+		n:        make([]float64, k),
+		sum:      make([]float64, k),
+		sumsq:    make([]float64, k),
+		hists:    make([]abs.Histogram, k),
+		examples: make([][]card.Cards, k),
+	}
+}
+
+func (s *inspector) example(c abs.Cluster, hand card.Cards, max int) {
+	if len(s.examples[c]) < max {
+		s.examples[c] = append(s.examples[c], hand)
+	}
+}
+
+func (s *inspector) add(c abs.Cluster, h abs.Histogram, center float32) {
+	e := float64(h.Equity)
+	s.n[c]++
+	s.sum[c] += e
+	s.sumsq[c] += e * e
+	s.errsum += math.Abs(e - float64(center))
+
+	if len(h.Bins) == 0 {
+		return
+	}
+
+	if s.hists[c].Bins == nil {
+		s.hists[c] = abs.Histogram{Bins: make([]float32, len(h.Bins))}
+	}
+	s.hists[c] = s.hists[c].Add(h)
+	s.points = append(s.points, struct {
+		c abs.Cluster
+		h abs.Histogram
+	}{c, h})
+}
+
+func (s *inspector) report(tiny float64) StreetReport {
+	r := StreetReport{
+		Street:   s.street,
+		Clusters: len(s.sizes),
+		Examples: s.examples,
+	}
+
+	sizes := append([]int{}, s.sizes...)
+	sort.Ints(sizes)
+
+	for _, x := range sizes {
+		r.Hands += x
+	}
+
+	if len(sizes) > 0 {
+		r.MinSize = sizes[0]
+		r.MedianSize = sizes[len(sizes)/2]
+		r.MaxSize = sizes[len(sizes)-1]
+	}
+
+	avg := float64(r.Hands) / float64(max(1, len(sizes)))
+	for _, x := range sizes {
+		switch {
+		case x == 0:
+			r.Empty++
+		case float64(x) < tiny*avg:
+			r.Tiny++
+		}
+	}
+
+	var within float64
+	for c := range s.n {
+		if s.n[c] == 0 {
+			continue
+		}
+		r.Evaluated += int(s.n[c])
+		mean := s.sum[c] / s.n[c]
+		within += s.sumsq[c] - s.n[c]*mean*mean
+	}
+
+	if r.Evaluated == 0 {
+		return r
+	}
+
+	r.EquityStd = math.Sqrt(math.Max(0, within/float64(r.Evaluated)))
+	r.EquityError = s.errsum / float64(r.Evaluated)
+
+	for c := range s.hists {
+		if s.hists[c].Bins != nil {
+			s.hists[c] = s.hists[c].Div(float32(s.n[c]))
+		}
+	}
+
+	for _, p := range s.points {
+		d := p.h.EMD(s.hists[p.c])
+		r.EMDMean += d
+		r.EMDMax = math.Max(r.EMDMax, d)
+	}
+	if len(s.points) > 0 {
+		r.EMDMean /= float64(len(s.points))
+	}
+
+	return r
+}
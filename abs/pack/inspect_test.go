@@ -0,0 +1,48 @@
+package absp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/table"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspector(t *testing.T) {
+	ins := newInspector(table.Flop, 3)
+	ins.sizes = []int{10, 0, 1}
+
+	hist := func(eq float32, bins ...float32) abs.Histogram {
+		return abs.Histogram{Bins: bins, Equity: eq}
+	}
+
+	// Cluster 0 has two different hands, cluster 2 one exact hand.
+	ins.add(0, hist(0.2, 1, 0), 0.3)
+	ins.add(0, hist(0.4, 0, 1), 0.3)
+	ins.add(2, hist(0.9, 0, 1), 0.9)
+
+	hand := card.NewCardsFromString("As Ah Kd 7c 2s")
+	ins.example(0, hand, 1)
+	ins.example(0, hand, 1)
+
+	r := ins.report(0.5)
+	require.Equal(t, 3, r.Clusters)
+	require.Equal(t, 11, r.Hands)
+	require.Equal(t, 0, r.MinSize)
+	require.Equal(t, 1, r.MedianSize)
+	require.Equal(t, 10, r.MaxSize)
+	require.Equal(t, 1, r.Empty)
+	require.Equal(t, 1, r.Tiny)
+
+	require.Equal(t, 3, r.Evaluated)
+	// Variance of cluster 0 is 0.01 on two of three hands.
+	require.InDelta(t, 0.0816, r.EquityStd, 0.001)
+	require.InDelta(t, 0.2/3, r.EquityError, 0.0001)
+	require.InDelta(t, 0.25/3*2, r.EMDMean, 0.0001)
+	require.InDelta(t, 0.25, r.EMDMax, 0.0001)
+
+	require.Len(t, r.Examples[0], 1)
+	require.True(t, strings.Contains(r.String(), "empty: 1, tiny: 1"))
+}
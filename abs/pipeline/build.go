@@ -0,0 +1,465 @@
+package pipeline
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/abs/flop"
+	absp "github.com/pokerdroid/poker/abs/pack"
+	"github.com/pokerdroid/poker/abs/river"
+	"github.com/pokerdroid/poker/abs/turn"
+	"github.com/pokerdroid/poker/frand"
+)
+
+const deltaThreshold = 0.0000001
+
+type RiverConfig struct {
+	Clusters      int `json:"clusters"`
+	MaxIterations int `json:"max_iterations"`
+	// OCHS is number of preflop clusters of opponent hands, zero
+	// clusters river by equity.
+	OCHS    int `json:"ochs,omitempty"`
+	Samples int `json:"samples,omitempty"`
+}
+
+type StreetConfig struct {
+	Clusters      int `json:"clusters"`
+	Bins          int `json:"bins"`
+	MaxIterations int `json:"max_iterations"`
+	Batch         int `json:"batch,omitempty"`
+	// RandomSeed and SeedSample choose k-means seeding, see abs.Seeding.
+	RandomSeed bool `json:"random_seed,omitempty"`
+	SeedSample int  `json:"seed_sample,omitempty"`
+	// Potential clusters by distribution over next street clusters.
+	Potential bool `json:"potential,omitempty"`
+	// Samples is number of hands clustered by potential-aware turn and
+	// number of turn hands estimating turn means by potential-aware flop.
+	Samples int `json:"samples,omitempty"`
+}
+
+// Config describes abstraction build.
+type Config struct {
+	// Dir holds intermediate outputs and their stamps.
+	Dir    string `json:"dir"`
+	Output string `json:"output"`
+	// Seed of the first street, every street seeds its own generator
+	// from it.
+	Seed  int64        `json:"seed"`
+	River RiverConfig  `json:"river"`
+	Turn  StreetConfig `json:"turn"`
+	Flop  StreetConfig `json:"flop"`
+}
+
+// NewConfigFromFile reads JSON config, paths are relative to the file.
+func NewConfigFromFile(path string) (Config, error) {
+	cfg := DefaultConfig()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+
+	base := filepath.Dir(path)
+	if !filepath.IsAbs(cfg.Dir) {
+		cfg.Dir = filepath.Join(base, cfg.Dir)
+	}
+	if !filepath.IsAbs(cfg.Output) {
+		cfg.Output = filepath.Join(base, cfg.Output)
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// DefaultConfig matches defaults of clustering commands.
+func DefaultConfig() Config {
+	return Config{
+		Dir:    "build",
+		Output: "abs.bin",
+		Seed:   42,
+		River: RiverConfig{
+			Clusters:      10000,
+			MaxIterations: 5000,
+			Samples:       5_000_000,
+		},
+		Turn: StreetConfig{
+			Clusters:      10000,
+			Bins:          20,
+			MaxIterations: 5000,
+			Samples:       2_000_000,
+		},
+		Flop: StreetConfig{
+			Clusters:      10000,
+			Bins:          20,
+			MaxIterations: 5000,
+			Samples:       2_000_000,
+		},
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Dir == "" || c.Output == "" {
+		return errors.New("dir and output are required")
+	}
+	if c.River.Clusters <= 0 || c.Turn.Clusters <= 0 || c.Flop.Clusters <= 0 {
+		return errors.New("clusters must be positive")
+	}
+	if c.River.Clusters > 1<<16 || c.Turn.Clusters > 1<<16 || c.Flop.Clusters > 1<<16 {
+		return errors.New("clusters must fit in 16 bits")
+	}
+	if (!c.Turn.Potential && c.Turn.Bins <= 0) || (!c.Flop.Potential && c.Flop.Bins <= 0) {
+		return errors.New("bins must be positive")
+	}
+	return nil
+}
+
+// Method describes clustering of streets, it's recorded in header of
+// packed abstraction.
+func (c Config) Method() string {
+	river := fmt.Sprintf("river:equity/%d", c.River.Clusters)
+	if c.River.OCHS > 0 {
+		river = fmt.Sprintf("river:ochs%d/%d", c.River.OCHS, c.River.Clusters)
+	}
+
+	street := func(name string, s StreetConfig) string {
+		if s.Potential {
+			return fmt.Sprintf("%s:potential/%d", name, s.Clusters)
+		}
+		return fmt.Sprintf("%s:hist%d/%d", name, s.Bins, s.Clusters)
+	}
+
+	return fmt.Sprintf("%s %s %s seed:%d", street("flop", c.Flop), street("turn", c.Turn), river, c.Seed)
+}
+
+// Stages returns stages building abstraction described by config.
+// Stages depend only on outputs they read, OCHS river doesn't need
+// equities and histogram streets don't need the next street.
+func (c Config) Stages(p *Pipeline) []Stage {
+	rng := func(street int64) frand.Rand {
+		return frand.NewUnsafeInt(c.Seed + street)
+	}
+
+	equities := Stage{
+		Name:   "equities",
+		Weight: 1,
+		Run: func(ctx context.Context, path string, logger poker.Logger) error {
+			b, err := river.ComputeBuckets(logger)
+			if err != nil {
+				return err
+			}
+			return writeBinary(path, b)
+		},
+	}
+
+	rs := Stage{
+		Name: "river",
+		Params: struct {
+			RiverConfig
+			Seed int64 `json:"seed"`
+		}{c.River, c.Seed},
+		Weight: 2,
+		Run: func(ctx context.Context, path string, logger poker.Logger) error {
+			var a *river.Abs
+			var err error
+
+			if c.River.OCHS > 0 {
+				a, err = river.PartitionOCHS(river.OCHSOpts{
+					Opponents:      c.River.OCHS,
+					Clusters:       c.River.Clusters,
+					MaxIterations:  c.River.MaxIterations,
+					DeltaThreshold: deltaThreshold,
+					Samples:        c.River.Samples,
+					Logger:         logger,
+					LogIteration:   1_000_000,
+					Rng:            rng(0),
+				})
+			} else {
+				var b *river.Buckets
+				b, err = river.NewBucketsFromFile(p.Output("equities"), logger)
+				if err != nil {
+					return err
+				}
+				a, err = river.Partition(b, river.PartitionOpts{
+					Clusters:       c.River.Clusters,
+					Logger:         logger,
+					Rng:            rng(0),
+					MaxIterations:  c.River.MaxIterations,
+					LogIteration:   10_000,
+					DeltaThreshold: deltaThreshold,
+				})
+			}
+			if err != nil {
+				return err
+			}
+			return writeBinary(path, a)
+		},
+	}
+	if c.River.OCHS == 0 {
+		rs.Deps = []string{"equities"}
+	}
+
+	ts := Stage{
+		Name: "turn",
+		Params: struct {
+			StreetConfig
+			Seed int64 `json:"seed"`
+		}{c.Turn, c.Seed + 1},
+		Weight: 4,
+		Run: func(ctx context.Context, path string, logger poker.Logger) error {
+			var a *turn.Abs
+
+			if c.Turn.Potential {
+				r, err := river.NewFromFile(p.Output("river"))
+				if err != nil {
+					return err
+				}
+				a, err = turn.PartitionPotential(turn.PotentialOpts{
+					River:          r,
+					Clusters:       c.Turn.Clusters,
+					MaxIterations:  c.Turn.MaxIterations,
+					DeltaThreshold: deltaThreshold,
+					Samples:        c.Turn.Samples,
+					Logger:         logger,
+					LogIteration:   1_000_000,
+					Rng:            rng(1),
+				})
+				if err != nil {
+					return err
+				}
+				return writeBinary(path, a)
+			}
+
+			b, err := river.NewBucketsFromFile(p.Output("equities"), logger)
+			if err != nil {
+				return err
+			}
+
+			hh, err := turn.Compute(turn.ComputeOpts{
+				Buckets:      b,
+				Logger:       logger,
+				Bins:         c.Turn.Bins,
+				LogIteration: 100_000,
+			})
+			if err != nil {
+				return err
+			}
+
+			a, err = turn.Partition(hh, turn.PartitionOpts{
+				Clusters:       c.Turn.Clusters,
+				Logger:         logger,
+				Rng:            rng(1),
+				MaxIterations:  c.Turn.MaxIterations,
+				LogIteration:   1_000_000,
+				DeltaThreshold: deltaThreshold,
+				Bins:           c.Turn.Bins,
+				BatchSize:      c.Turn.Batch,
+				Seeding:        abs.Seeding{Random: c.Turn.RandomSeed, Sample: c.Turn.SeedSample},
+			})
+			if err != nil {
+				return err
+			}
+			return writeBinary(path, a)
+		},
+	}
+	if c.Turn.Potential {
+		ts.Deps = []string{"river"}
+	} else {
+		ts.Deps = []string{"equities"}
+	}
+
+	fs := Stage{
+		Name: "flop",
+		Params: struct {
+			StreetConfig
+			Seed int64 `json:"seed"`
+		}{c.Flop, c.Seed + 2},
+		Weight: 3,
+		Run: func(ctx context.Context, path string, logger poker.Logger) error {
+			var a *flop.Abs
+
+			if c.Flop.Potential {
+				t, err := turn.NewFromFile(p.Output("turn"))
+				if err != nil {
+					return err
+				}
+				r, err := river.NewFromFile(p.Output("river"))
+				if err != nil {
+					return err
+				}
+				a, err = flop.PartitionPotential(flop.PotentialOpts{
+					Turn:           t,
+					River:          r,
+					Clusters:       c.Flop.Clusters,
+					MaxIterations:  c.Flop.MaxIterations,
+					DeltaThreshold: deltaThreshold,
+					TurnSamples:    c.Flop.Samples,
+					Logger:         logger,
+					LogIteration:   100_000,
+					Rng:            rng(2),
+				})
+				if err != nil {
+					return err
+				}
+				return writeBinary(path, a)
+			}
+
+			b, err := river.NewBucketsFromFile(p.Output("equities"), logger)
+			if err != nil {
+				return err
+			}
+
+			hh, err := flop.Compute(flop.ComputeOpts{
+				Buckets:      b,
+				Logger:       logger,
+				Bins:         c.Flop.Bins,
+				LogIteration: 100_000,
+			})
+			if err != nil {
+				return err
+			}
+
+			a, err = flop.Partition(hh, flop.PartitionOpts{
+				Clusters:       c.Flop.Clusters,
+				Logger:         logger,
+				Rng:            rng(2),
+				MaxIterations:  c.Flop.MaxIterations,
+				LogIteration:   100_000,
+				DeltaThreshold: deltaThreshold,
+				Bins:           c.Flop.Bins,
+				BatchSize:      c.Flop.Batch,
+				Seeding:        abs.Seeding{Random: c.Flop.RandomSeed, Sample: c.Flop.SeedSample},
+			})
+			if err != nil {
+				return err
+			}
+			return writeBinary(path, a)
+		},
+	}
+	if c.Flop.Potential {
+		fs.Deps = []string{"turn", "river"}
+	} else {
+		fs.Deps = []string{"equities"}
+	}
+
+	stages := []Stage{rs, ts, fs}
+	if c.River.OCHS == 0 || !c.Turn.Potential || !c.Flop.Potential {
+		stages = append([]Stage{equities}, stages...)
+	}
+
+	return stages
+}
+
+// Manifest records how packed abstraction was built.
+type Manifest struct {
+	UID     uuid.UUID        `json:"uid"`
+	Config  Config           `json:"config"`
+	Stages  map[string]Stamp `json:"stages"`
+	Created time.Time        `json:"created"`
+}
+
+// Build runs stages of config and packs flop, turn and river into
+// output with manifest next to it. Packed abstraction is rewritten only
+// when any street changed, its UID stays the same otherwise.
+func Build(ctx context.Context, cfg Config, logger poker.Logger) (*Manifest, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &Pipeline{Dir: cfg.Dir, Logger: logger}
+	p.Stages = cfg.Stages(p)
+
+	stamps, err := p.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestPath := cfg.Output + ".manifest.json"
+
+	var prev Manifest
+	if data, err := os.ReadFile(manifestPath); err == nil && json.Unmarshal(data, &prev) == nil {
+		if _, err := os.Stat(cfg.Output); err == nil && sameStreets(prev.Stages, stamps) {
+			p.Logger.Printf("%s is up to date", cfg.Output)
+			return &prev, nil
+		}
+	}
+
+	p.Logger.Printf("packing abstraction")
+
+	a := &absp.Abs{
+		UID:    uuid.New(),
+		Method: cfg.Method(),
+		Flop:   &flop.Abs{},
+		Turn:   &turn.Abs{},
+		River:  &river.Abs{},
+	}
+
+	if err := readBinary(p.Output("flop"), a.Flop); err != nil {
+		return nil, err
+	}
+	if err := readBinary(p.Output("turn"), a.Turn); err != nil {
+		return nil, err
+	}
+	if err := readBinary(p.Output("river"), a.River); err != nil {
+		return nil, err
+	}
+
+	tmp := cfg.Output + ".tmp"
+	if err := writeBinary(tmp, a); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp, cfg.Output); err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{
+		UID:     a.UID,
+		Config:  cfg,
+		Stages:  stamps,
+		Created: time.Now().UTC(),
+	}
+
+	if err := writeJSON(manifestPath, m); err != nil {
+		return nil, err
+	}
+
+	p.Logger.Printf("%s written, uid %s", cfg.Output, a.UID)
+
+	return m, nil
+}
+
+func sameStreets(a, b map[string]Stamp) bool {
+	for _, s := range []string{"flop", "turn", "river"} {
+		if a[s].Checksum == "" || a[s].Checksum != b[s].Checksum {
+			return false
+		}
+	}
+	return true
+}
+
+func writeBinary(path string, v encoding.BinaryMarshaler) error {
+	bb, err := v.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bb, 0644)
+}
+
+func readBinary(path string, v encoding.BinaryUnmarshaler) error {
+	bb, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalBinary(bb)
+}
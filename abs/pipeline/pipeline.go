@@ -0,0 +1,264 @@
+package pipeline
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pokerdroid/poker"
+)
+
+// Stage produces single output file from outputs of its dependencies.
+type Stage struct {
+	Name string
+	Deps []string
+	// Params of the stage, part of the stage key.
+	Params any
+	// Weight is relative duration of the stage used for ETA.
+	Weight float64
+	// Run writes output to path.
+	Run func(ctx context.Context, path string, logger poker.Logger) error
+}
+
+// Stamp records how stage output was produced.
+type Stamp struct {
+	Stage    string          `json:"stage"`
+	Key      string          `json:"key"`
+	Checksum string          `json:"checksum"`
+	Params   json.RawMessage `json:"params"`
+	Duration string          `json:"duration"`
+	Created  time.Time       `json:"created"`
+}
+
+// Pipeline runs stages in order of dependencies. Output of every stage
+// is cached in Dir with stamp holding key of its parameters and
+// checksums of dependencies, stages with matching stamp and checksum
+// of output are skipped.
+type Pipeline struct {
+	Dir    string
+	Stages []Stage
+	Logger poker.Logger
+}
+
+// Output returns path to output of stage.
+func (p *Pipeline) Output(name string) string {
+	return filepath.Join(p.Dir, name+".bin")
+}
+
+func (p *Pipeline) stampPath(name string) string {
+	return filepath.Join(p.Dir, name+".stamp.json")
+}
+
+// order returns stages sorted so dependencies go first.
+func (p *Pipeline) order() ([]Stage, error) {
+	byName := make(map[string]Stage, len(p.Stages))
+	for _, s := range p.Stages {
+		if _, ok := byName[s.Name]; ok {
+			return nil, fmt.Errorf("duplicate stage %s", s.Name)
+		}
+		byName[s.Name] = s
+	}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+
+	state := make(map[string]int)
+	var out []Stage
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		s, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("unknown stage %s", name)
+		}
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("stage %s depends on itself", name)
+		case done:
+			return nil
+		}
+		state[name] = visiting
+		for _, d := range s.Deps {
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+		state[name] = done
+		out = append(out, s)
+		return nil
+	}
+
+	for _, s := range p.Stages {
+		if err := visit(s.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// Run runs stages which are not up to date and returns stamps of all
+// stages.
+func (p *Pipeline) Run(ctx context.Context) (map[string]Stamp, error) {
+	if p.Logger == nil {
+		p.Logger = poker.VoidLogger{}
+	}
+
+	stages, err := p.order()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(p.Dir, 0755); err != nil {
+		return nil, err
+	}
+
+	var total, finished float64
+	for _, s := range stages {
+		total += s.Weight
+	}
+
+	stamps := make(map[string]Stamp, len(stages))
+	start := time.Now()
+	var spent time.Duration
+
+	for i, s := range stages {
+		if err := ctx.Err(); err != nil {
+			return stamps, err
+		}
+
+		params, err := json.Marshal(s.Params)
+		if err != nil {
+			return stamps, err
+		}
+
+		key := stageKey(s, params, stamps)
+		path := p.Output(s.Name)
+		logger := poker.LoggerPrefix{
+			Logger: p.Logger,
+			Prefix: fmt.Sprintf("[%d/%d %s] ", i+1, len(stages), s.Name),
+		}
+
+		if st, ok := p.upToDate(s.Name, key); ok {
+			logger.Printf("up to date, skipping")
+			stamps[s.Name] = st
+			total -= s.Weight
+			continue
+		}
+
+		logger.Printf("running%s", eta(spent, finished, total))
+
+		began := time.Now()
+		tmp := path + ".tmp"
+		if err := s.Run(ctx, tmp, logger); err != nil {
+			os.Remove(tmp)
+			return stamps, fmt.Errorf("stage %s: %w", s.Name, err)
+		}
+
+		if err := os.Rename(tmp, path); err != nil {
+			return stamps, err
+		}
+
+		sum, err := checksum(path)
+		if err != nil {
+			return stamps, err
+		}
+
+		took := time.Since(began)
+		st := Stamp{
+			Stage:    s.Name,
+			Key:      key,
+			Checksum: sum,
+			Params:   params,
+			Duration: took.Round(time.Second).String(),
+			Created:  time.Now().UTC(),
+		}
+
+		if err := writeJSON(p.stampPath(s.Name), st); err != nil {
+			return stamps, err
+		}
+
+		stamps[s.Name] = st
+		spent += took
+		finished += s.Weight
+
+		logger.Printf("done in %s%s", took.Round(time.Second), eta(spent, finished, total))
+	}
+
+	p.Logger.Printf("pipeline done in %s", time.Since(start).Round(time.Second))
+
+	return stamps, nil
+}
+
+// upToDate returns stamp of stage if it has the key and output
+// matches its checksum.
+func (p *Pipeline) upToDate(name, key string) (Stamp, bool) {
+	var st Stamp
+
+	data, err := os.ReadFile(p.stampPath(name))
+	if err != nil {
+		return st, false
+	}
+
+	if err := json.Unmarshal(data, &st); err != nil || st.Key != key {
+		return st, false
+	}
+
+	sum, err := checksum(p.Output(name))
+	if err != nil || sum != st.Checksum {
+		return st, false
+	}
+
+	return st, true
+}
+
+// stageKey hashes stage name, parameters and checksums of dependencies,
+// change of any dependency output reruns the stage.
+func stageKey(s Stage, params []byte, stamps map[string]Stamp) string {
+	h := sha256.New()
+	h.Write([]byte(s.Name))
+	h.Write(params)
+	for _, d := range s.Deps {
+		h.Write([]byte(d))
+		h.Write([]byte(stamps[d].Checksum))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func eta(spent time.Duration, finished, total float64) string {
+	if finished == 0 || total <= finished {
+		return ""
+	}
+	left := time.Duration(float64(spent) / finished * (total - finished))
+	return fmt.Sprintf(", eta %s", left.Round(time.Second))
+}
+
+func checksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", " ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
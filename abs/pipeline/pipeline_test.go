@@ -0,0 +1,89 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/pokerdroid/poker"
+	"github.com/stretchr/testify/require"
+)
+
+func testPipeline(dir string, runs map[string]int, param *int) *Pipeline {
+	stage := func(name string, deps ...string) Stage {
+		return Stage{
+			Name:   name,
+			Deps:   deps,
+			Params: *param,
+			Weight: 1,
+			Run: func(ctx context.Context, path string, logger poker.Logger) error {
+				runs[name]++
+				return os.WriteFile(path, []byte(name), 0644)
+			},
+		}
+	}
+
+	a := stage("a")
+	b := stage("b", "a")
+	c := stage("c", "b")
+	c.Params = 0
+
+	// Stages are listed out of order.
+	return &Pipeline{
+		Dir:    dir,
+		Stages: []Stage{c, b, a},
+		Logger: poker.VoidLogger{},
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	dir := t.TempDir()
+	runs := map[string]int{}
+	param := 1
+
+	_, err := testPipeline(dir, runs, &param).Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"a": 1, "b": 1, "c": 1}, runs)
+
+	// Everything is up to date.
+	stamps, err := testPipeline(dir, runs, &param).Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"a": 1, "b": 1, "c": 1}, runs)
+	require.Len(t, stamps, 3)
+
+	// Corrupted output reruns the stage, dependents are kept as output
+	// is the same.
+	require.NoError(t, os.WriteFile(testPipeline(dir, runs, &param).Output("b"), []byte("x"), 0644))
+	_, err = testPipeline(dir, runs, &param).Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"a": 1, "b": 2, "c": 1}, runs)
+
+	// Changed parameter reruns a and b, c has fixed params and the same
+	// dependency output.
+	param = 2
+	_, err = testPipeline(dir, runs, &param).Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"a": 2, "b": 3, "c": 1}, runs)
+}
+
+func TestPipelineErrors(t *testing.T) {
+	p := &Pipeline{
+		Dir: t.TempDir(),
+		Stages: []Stage{
+			{Name: "a", Deps: []string{"b"}},
+			{Name: "b", Deps: []string{"a"}},
+		},
+	}
+	_, err := p.Run(context.Background())
+	require.Error(t, err)
+
+	p.Stages = []Stage{{Name: "a", Deps: []string{"x"}}}
+	_, err = p.Run(context.Background())
+	require.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	p.Stages = []Stage{{Name: "a"}}
+	_, err = p.Run(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,278 @@
+package abs
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/frand"
+)
+
+// Potential is distribution of a hand over clusters of the next street,
+// stored sparse. Clusters are sorted and distinct, weights sum to 1.
+// Equity is weighted equity of the next street clusters.
+type Potential struct {
+	Clusters []Cluster
+	Weights  []float32
+	Equity   float32
+}
+
+// NewPotential creates potential from next street cluster of every
+// card dealt next and their equity.
+func NewPotential(next []Cluster, equity []float32) Potential {
+	cc := append([]Cluster{}, next...)
+	sort.Slice(cc, func(i, j int) bool { return cc[i] < cc[j] })
+
+	var p Potential
+	w := 1 / float32(len(cc))
+
+	for i, c := range cc {
+		if i > 0 && cc[i-1] == c {
+			p.Weights[len(p.Weights)-1] += w
+		} else {
+			p.Clusters = append(p.Clusters, c)
+			p.Weights = append(p.Weights, w)
+		}
+		p.Equity += w * equity[c]
+	}
+
+	return p
+}
+
+// Ground holds distances between next street clusters used by EMD
+// of potentials.
+type Ground struct {
+	Dist [][]float32
+	// Order lists clusters by distance from every cluster.
+	Order [][]Cluster
+
+	pool sync.Pool
+}
+
+type emdScratch struct {
+	mean   []float32
+	target []float32
+	cursor []int
+}
+
+// NewGround computes distances between n clusters.
+func NewGround(n int, dist func(i, j int) float64) (*Ground, error) {
+	g := &Ground{
+		Dist:  make([][]float32, n),
+		Order: make([][]Cluster, n),
+	}
+
+	err := IndexWorkers(n, func(i int, done uint64) error {
+		row := make([]float32, n)
+		order := make([]Cluster, n)
+		for j := range row {
+			row[j] = float32(dist(i, j))
+			order[j] = Cluster(j)
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return row[order[a]] < row[order[b]]
+		})
+		g.Dist[i] = row
+		g.Order[i] = order
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g.pool.New = func() any {
+		return &emdScratch{mean: make([]float32, n)}
+	}
+
+	return g, nil
+}
+
+// EMD approximates earth mover's distance between point p and mean m.
+// Every point cluster takes mass from the nearest mean cluster with
+// mass left until the point is covered, as in the heuristic of
+// potential-aware abstraction paper. Greedy transport is feasible,
+// result is never below the exact distance.
+func (g *Ground) EMD(p, m Potential) float64 {
+	s := g.pool.Get().(*emdScratch)
+	defer g.pool.Put(s)
+
+	for i, c := range m.Clusters {
+		s.mean[c] = m.Weights[i]
+	}
+
+	s.target = append(s.target[:0], p.Weights...)
+	s.cursor = s.cursor[:0]
+	for range p.Clusters {
+		s.cursor = append(s.cursor, 0)
+	}
+
+	var cost float64
+	left := len(p.Clusters)
+
+	for left > 0 {
+		for j, pc := range p.Clusters {
+			if s.target[j] <= 0 {
+				continue
+			}
+
+			order := g.Order[pc]
+			k := s.cursor[j]
+			for k < len(order) && s.mean[order[k]] <= 0 {
+				k++
+			}
+			s.cursor[j] = k
+
+			// Rounding left some point mass uncovered.
+			if k == len(order) {
+				s.target[j] = 0
+				left--
+				continue
+			}
+
+			mc := order[k]
+			d := float64(g.Dist[pc][mc])
+
+			if amt := s.mean[mc]; amt < s.target[j] {
+				cost += float64(amt) * d
+				s.target[j] -= amt
+				s.mean[mc] = 0
+			} else {
+				cost += float64(s.target[j]) * d
+				s.mean[mc] -= s.target[j]
+				s.target[j] = 0
+				left--
+			}
+		}
+	}
+
+	for _, c := range m.Clusters {
+		s.mean[c] = 0
+	}
+
+	return cost
+}
+
+// Mean returns average of potentials.
+func (g *Ground) Mean(pp []Potential) Potential {
+	if len(pp) == 0 {
+		return Potential{}
+	}
+
+	sum := make([]float32, len(g.Dist))
+	var eq float32
+
+	for _, p := range pp {
+		for i, c := range p.Clusters {
+			sum[c] += p.Weights[i]
+		}
+		eq += p.Equity
+	}
+
+	n := float32(len(pp))
+	m := Potential{Equity: eq / n}
+	for c, w := range sum {
+		if w > 0 {
+			m.Clusters = append(m.Clusters, Cluster(c))
+			m.Weights = append(m.Weights, w/n)
+		}
+	}
+
+	return m
+}
+
+// Nearest returns index of center nearest to p. Centers must be sorted
+// by equity. Difference of equities is lower bound of EMD when ground
+// distance is not below difference of cluster equities, centers are
+// searched outwards from p equity until the bound exceeds the best.
+func (g *Ground) Nearest(centers []Potential, p Potential) int {
+	hi := sort.Search(len(centers), func(i int) bool {
+		return centers[i].Equity >= p.Equity
+	})
+	lo := hi - 1
+
+	best := -1
+	dist := math.Inf(1)
+
+	for lo >= 0 || hi < len(centers) {
+		dlo, dhi := math.Inf(1), math.Inf(1)
+		if lo >= 0 {
+			dlo = float64(p.Equity - centers[lo].Equity)
+		}
+		if hi < len(centers) {
+			dhi = float64(centers[hi].Equity - p.Equity)
+		}
+
+		if best >= 0 && math.Min(dlo, dhi) >= dist {
+			break
+		}
+
+		k := hi
+		if dlo < dhi {
+			k = lo
+			lo--
+		} else {
+			hi++
+		}
+
+		if d := g.EMD(p, centers[k]); d < dist {
+			best, dist = k, d
+		}
+	}
+
+	return best
+}
+
+type PotentialOpts struct {
+	Clusters      int
+	MaxIterations int
+	MaxDelta      float64
+	Logger        poker.Logger
+	LogIteration  int
+	Rng           frand.Rand
+}
+
+// Partition clusters potentials by k-means with EMD over ground
+// distance and returns centers sorted by equity.
+func (g *Ground) Partition(pp []Potential, opts PotentialOpts) ([]Potential, error) {
+	if opts.Clusters <= 0 {
+		return nil, errors.New("clusters must be positive")
+	}
+
+	groups, err := NewPlusPlusGroups(opts.Rng, pp, opts.Clusters, g.EMD)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := KMeans(pp, KMeansOpts[Potential]{
+		Clusters:      opts.Clusters,
+		MaxIterations: opts.MaxIterations,
+		MaxDelta:      opts.MaxDelta,
+		Logger:        opts.Logger,
+		LogIteration:  opts.LogIteration,
+		Rng:           opts.Rng,
+		Groups:        groups,
+
+		Recenter: func(rng frand.Rand, e []Potential) Potential {
+			return g.Mean(e)
+		},
+
+		// Approximated EMD is not a metric, bounds can't prune.
+		Distance: g.EMD,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].Center.Equity < data[j].Center.Equity
+	})
+
+	centers := make([]Potential, len(data))
+	for i, c := range data {
+		centers[i] = c.Center
+	}
+
+	return centers, nil
+}
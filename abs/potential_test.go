@@ -0,0 +1,105 @@
+package abs
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/stretchr/testify/require"
+)
+
+func lineGround(t *testing.T, n int) (*Ground, []float32) {
+	eq := make([]float32, n)
+	for i := range eq {
+		eq[i] = float32(i) / float32(n-1)
+	}
+	g, err := NewGround(n, func(i, j int) float64 {
+		return math.Abs(float64(eq[i] - eq[j]))
+	})
+	require.NoError(t, err)
+	return g, eq
+}
+
+func TestNewPotential(t *testing.T) {
+	_, eq := lineGround(t, 5)
+
+	p := NewPotential([]Cluster{4, 0, 4, 2}, eq)
+	require.Equal(t, []Cluster{0, 2, 4}, p.Clusters)
+	require.Equal(t, []float32{0.25, 0.25, 0.5}, p.Weights)
+	require.InDelta(t, 0.625, p.Equity, 1e-6)
+}
+
+func TestGroundEMD(t *testing.T) {
+	g, eq := lineGround(t, 5)
+
+	a := NewPotential([]Cluster{0}, eq)
+	b := NewPotential([]Cluster{4}, eq)
+	c := NewPotential([]Cluster{0, 1}, eq)
+	d := NewPotential([]Cluster{1}, eq)
+
+	require.InDelta(t, 0, g.EMD(a, a), 1e-6)
+	require.InDelta(t, 1, g.EMD(a, b), 1e-6)
+	require.InDelta(t, 0.125, g.EMD(c, d), 1e-6)
+	require.InDelta(t, 0.125, g.EMD(d, c), 1e-6)
+
+	// Equities bound distance from below.
+	require.GreaterOrEqual(t, g.EMD(c, b)+1e-6, math.Abs(float64(c.Equity-b.Equity)))
+}
+
+func TestGroundNearest(t *testing.T) {
+	g, eq := lineGround(t, 20)
+	rng := frand.NewUnsafeInt(1)
+
+	random := func() Potential {
+		next := make([]Cluster, 10)
+		for i := range next {
+			next[i] = Cluster(rng.Intn(20))
+		}
+		return NewPotential(next, eq)
+	}
+
+	centers := make([]Potential, 30)
+	for i := range centers {
+		centers[i] = random()
+	}
+	sort.Slice(centers, func(i, j int) bool {
+		return centers[i].Equity < centers[j].Equity
+	})
+
+	for i := 0; i < 200; i++ {
+		p := random()
+
+		best := 0
+		for k := range centers {
+			if g.EMD(p, centers[k]) < g.EMD(p, centers[best]) {
+				best = k
+			}
+		}
+
+		k := g.Nearest(centers, p)
+		require.InDelta(t, g.EMD(p, centers[best]), g.EMD(p, centers[k]), 1e-6)
+	}
+}
+
+func TestGroundPartition(t *testing.T) {
+	g, eq := lineGround(t, 10)
+
+	var pp []Potential
+	for i := 0; i < 20; i++ {
+		pp = append(pp, NewPotential([]Cluster{0, 1, Cluster(i % 2)}, eq))
+		pp = append(pp, NewPotential([]Cluster{8, 9, Cluster(8 + i%2)}, eq))
+	}
+
+	centers, err := g.Partition(pp, PotentialOpts{
+		Clusters:      2,
+		MaxIterations: 100,
+		Logger:        poker.VoidLogger{},
+		Rng:           frand.NewUnsafeInt(3),
+	})
+	require.NoError(t, err)
+	require.Len(t, centers, 2)
+	require.Less(t, centers[0].Equity, float32(0.2))
+	require.Greater(t, centers[1].Equity, float32(0.8))
+}
@@ -0,0 +1,342 @@
+package river
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/equity"
+	"github.com/pokerdroid/poker/eval"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/iso"
+)
+
+// preflopSamples is number of sampled deals to estimate preflop
+// equity of a hand against random hand.
+const preflopSamples = 20_000
+
+// Opponents assigns opponent hole cards to clusters of preflop
+// hands with similar equity, cluster 0 is the weakest.
+type Opponents struct {
+	N     int
+	table [53][53]uint8
+}
+
+// Cluster returns preflop cluster of hole cards.
+func (o *Opponents) Cluster(a, b card.Card) int {
+	return int(o.table[a][b])
+}
+
+// NewOpponents clusters preflop hands into n clusters by k-means on
+// their equity against random hand, combos weighted.
+func NewOpponents(n int, rng frand.Rand) (*Opponents, error) {
+	if n <= 0 || n > math.MaxUint8 {
+		return nil, errors.New("opponent clusters must be between 1 and 255")
+	}
+
+	size := iso.Preflop.Size()
+	eq := make([]float64, size)
+	for i := range eq {
+		eq[i] = preflopEquity(rng, iso.Preflop.Unindex(uint64(i)))
+	}
+
+	combos := make([]float64, 0, 1326)
+	for _, c := range card.Combinations(2) {
+		combos = append(combos, eq[iso.Preflop.Index(c)])
+	}
+
+	distance := func(a, b float64) float64 {
+		return math.Abs(a - b)
+	}
+
+	groups, err := abs.NewPlusPlusGroups(rng, combos, n, distance)
+	if err != nil {
+		return nil, err
+	}
+
+	gg, _, err := abs.KMeans(combos, abs.KMeansOpts[float64]{
+		Clusters:      n,
+		MaxIterations: 1000,
+		MaxDelta:      0.0000001,
+		Logger:        poker.VoidLogger{},
+		Rng:           rng,
+		Groups:        groups,
+
+		Recenter: func(rng frand.Rand, e []float64) float64 {
+			var sum float64
+			for _, x := range e {
+				sum += x
+			}
+			return sum / float64(len(e))
+		},
+
+		Distance:   distance,
+		Accelerate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(gg, func(i, j int) bool {
+		return gg[i].Center < gg[j].Center
+	})
+
+	o := &Opponents{N: n}
+	for _, c := range card.Combinations(2) {
+		x := uint8(gg.Nearest(eq[iso.Preflop.Index(c)], distance))
+		o.table[c[0]][c[1]] = x
+		o.table[c[1]][c[0]] = x
+	}
+
+	return o, nil
+}
+
+func preflopEquity(rng frand.Rand, hole card.Cards) float64 {
+	h1 := make(card.Cards, 7)
+	h2 := make(card.Cards, 7)
+	copy(h1, hole)
+
+	var won float64
+	for n := 0; n < preflopSamples; n++ {
+		used := uint64(1)<<hole[0] | uint64(1)<<hole[1]
+		for k := 0; k < 7; {
+			c := card.Card(rng.Intn(52) + 1)
+			if used&(1<<c) != 0 {
+				continue
+			}
+			used |= 1 << c
+			if k < 2 {
+				h2[k] = c
+			} else {
+				h1[k], h2[k] = c, c
+			}
+			k++
+		}
+
+		r1, _ := eval.Eval(h1...)
+		r2, _ := eval.Eval(h2...)
+		switch r1.Compare(r2) {
+		case 0:
+			won++
+		case 2:
+			won += 0.5
+		}
+	}
+
+	return won / preflopSamples
+}
+
+// OCHS is opponent cluster hand strength: equity of river hand against
+// opponent hands of every preflop cluster.
+type OCHS []float32
+
+func (o OCHS) Distance(other OCHS) float64 {
+	var sum float64
+	for i := range o {
+		d := float64(o[i] - other[i])
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// ComputeOCHS returns equity of river hand against opponents of every
+// cluster and equity against all opponents.
+func ComputeOCHS(cb card.Cards, o *Opponents) (OCHS, equity.Equity) {
+	rank, err := eval.Eval(cb...)
+	if err != nil {
+		panic(err)
+	}
+
+	won := make([]float32, o.N)
+	count := make([]float32, o.N)
+	var chances [3]float32
+
+	rest := card.All(cb...)
+	opp := make(card.Cards, 7)
+	copy(opp[2:], cb[2:])
+
+	for i := 0; i < len(rest); i++ {
+		for j := i + 1; j < len(rest); j++ {
+			opp[0], opp[1] = rest[i], rest[j]
+			other, err := eval.Eval(opp...)
+			if err != nil {
+				panic(err)
+			}
+
+			x := o.Cluster(rest[i], rest[j])
+			count[x]++
+
+			switch r := rank.Compare(other); r {
+			case 0:
+				won[x]++
+				chances[r]++
+			case 2:
+				won[x] += 0.5
+				chances[r]++
+			}
+		}
+	}
+
+	var total float32
+	for x := range won {
+		total += count[x]
+		if count[x] > 0 {
+			won[x] /= count[x]
+		}
+	}
+
+	return won, equity.NewEquity(chances[0]/total, chances[2]/total)
+}
+
+type OCHSOpts struct {
+	// Opponents is number of preflop clusters of opponent hands.
+	Opponents      int
+	Clusters       int
+	MaxIterations  int
+	DeltaThreshold float64
+	// Samples is number of river hands clustered by k-means, all hands
+	// are mapped to the nearest center. Zero clusters all hands.
+	Samples      int
+	Logger       poker.Logger
+	LogIteration int
+	Rng          frand.Rand
+}
+
+// PartitionOCHS clusters river hands by k-means on their OCHS vectors.
+// Equities of clusters are mean equities of their hands.
+func PartitionOCHS(opts OCHSOpts) (*Abs, error) {
+	if opts.Rng == nil {
+		opts.Rng = frand.NewHash()
+	}
+
+	if opts.Logger == nil {
+		opts.Logger = poker.VoidLogger{}
+	}
+
+	rng := frand.Clone(opts.Rng)
+
+	opps, err := NewOpponents(opts.Opponents, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	n := opts.Samples
+	if n <= 0 || n > Size {
+		n = Size
+	}
+
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+		if n < Size {
+			idx[i] = rng.Intn(Size)
+		}
+	}
+
+	opts.Logger.Printf("computing %d river ochs", n)
+
+	vv := make([]OCHS, n)
+	err = abs.IndexWorkers(n, func(i int, done uint64) error {
+		vv[i], _ = ComputeOCHS(iso.River.Unindex(uint64(idx[i])), opps)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	distance := func(a, b OCHS) float64 {
+		return a.Distance(b)
+	}
+
+	groups, err := abs.NewPlusPlusGroups(rng, vv, opts.Clusters, distance)
+	if err != nil {
+		return nil, err
+	}
+
+	gg, _, err := abs.KMeans(vv, abs.KMeansOpts[OCHS]{
+		Clusters:      opts.Clusters,
+		MaxIterations: opts.MaxIterations,
+		MaxDelta:      opts.DeltaThreshold,
+		Logger:        opts.Logger,
+		LogIteration:  opts.LogIteration,
+		Rng:           rng,
+		Groups:        groups,
+
+		Recenter: func(rng frand.Rand, e []OCHS) OCHS {
+			center := make(OCHS, opts.Opponents)
+			for _, v := range e {
+				for i := range v {
+					center[i] += v[i]
+				}
+			}
+			for i := range center {
+				center[i] /= float32(len(e))
+			}
+			return center
+		},
+
+		Distance:   distance,
+		Accelerate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Clusters are ordered by equity against the average opponent
+	// cluster, mapping below replaces it by mean equity.
+	sort.Slice(gg, func(i, j int) bool {
+		return mean(gg[i].Center) < mean(gg[j].Center)
+	})
+
+	absx := new(Abs)
+	sums := make([][2]float64, len(gg))
+	counts := make([]float64, len(gg))
+	var mux sync.Mutex
+
+	err = abs.IndexWorkers(Size, func(c int, done uint64) error {
+		v, eq := ComputeOCHS(iso.River.Unindex(uint64(c)), opps)
+		absx.Clusters[c] = abs.Cluster(gg.Nearest(v, distance))
+
+		if opts.LogIteration > 0 && done%uint64(opts.LogIteration) == 0 {
+			opts.Logger.Printf("building mapping: %d/%d", done, Size)
+		}
+
+		// Equities are accumulated on sampled hands only to avoid
+		// locking on every hand.
+		if c%64 == 0 {
+			mux.Lock()
+			x := absx.Clusters[c]
+			sums[x][0] += float64(eq.Win())
+			sums[x][1] += float64(eq.Tie())
+			counts[x]++
+			mux.Unlock()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, g := range gg {
+		if counts[i] == 0 {
+			absx.Equities = append(absx.Equities, equity.NewEquity(mean(g.Center), 0))
+			continue
+		}
+		absx.Equities = append(absx.Equities, equity.NewEquity(
+			float32(sums[i][0]/counts[i]), float32(sums[i][1]/counts[i])))
+	}
+
+	return absx, nil
+}
+
+func mean(v OCHS) float32 {
+	var sum float32
+	for _, x := range v {
+		sum += x
+	}
+	return sum / float32(len(v))
+}
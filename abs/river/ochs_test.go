@@ -0,0 +1,41 @@
+package river
+
+import (
+	"testing"
+
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpponents(t *testing.T) {
+	o, err := NewOpponents(8, frand.NewUnsafeInt(42))
+	require.NoError(t, err)
+
+	aces := card.NewCardsFromString("As Ah")
+	trash := card.NewCardsFromString("7c 2d")
+	require.Equal(t, 7, o.Cluster(aces[0], aces[1]))
+	require.Equal(t, 0, o.Cluster(trash[0], trash[1]))
+	require.Equal(t, o.Cluster(aces[0], aces[1]), o.Cluster(aces[1], aces[0]))
+
+	_, err = NewOpponents(0, frand.NewUnsafeInt(42))
+	require.Error(t, err)
+}
+
+func TestComputeOCHS(t *testing.T) {
+	o, err := NewOpponents(8, frand.NewUnsafeInt(42))
+	require.NoError(t, err)
+
+	// Royal flush wins against every opponent.
+	v, eq := ComputeOCHS(card.NewCardsFromString("As Ks Qs Js Ts 2d 3c"), o)
+	require.Len(t, v, 8)
+	for _, x := range v {
+		require.Equal(t, float32(1), x)
+	}
+	require.Equal(t, float32(1), eq.Win())
+
+	// Bottom pair beats weak holdings more often than strong ones.
+	v, eq = ComputeOCHS(card.NewCardsFromString("3h 4d Kc 9s 7d 3c Jh"), o)
+	require.Greater(t, v[0], v[7])
+	require.InDelta(t, ComputeEquity(card.NewCardsFromString("3h 4d Kc 9s 7d 3c Jh")).WinDraw(), eq.WinDraw(), 0.001)
+}
@@ -108,7 +108,11 @@ func Partition(buckets *Buckets, opts PartitionOpts) (*Abs, error) {
 		counter++
 	}
 
-	groups, err := abs.NewRandomGroups(opts.Rng, equities, opts.Clusters)
+	distance := func(a, b equity.Equity) float64 {
+		return a.Distance(b)
+	}
+
+	groups, err := abs.NewPlusPlusGroups(opts.Rng, equities, opts.Clusters, distance)
 	if err != nil {
 		return nil, err
 	}
@@ -138,9 +142,8 @@ func Partition(buckets *Buckets, opts PartitionOpts) (*Abs, error) {
 			return equity.NewEquity(v1, v2)
 		},
 
-		Distance: func(a, b equity.Equity) float64 {
-			return a.Distance(b)
-		},
+		Distance:   distance,
+		Accelerate: true,
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,174 @@
+package turn
+
+import (
+	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/abs/river"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/iso"
+)
+
+// RiverEquity returns equity of every river cluster.
+func RiverEquity(r *river.Abs) []float32 {
+	eq := make([]float32, len(r.Equities))
+	for i, e := range r.Equities {
+		eq[i] = e.WinDraw()
+	}
+	return eq
+}
+
+// RiverGround returns ground distance between river clusters, distance
+// of their equities.
+func RiverGround(r *river.Abs) (*abs.Ground, error) {
+	return abs.NewGround(len(r.Equities), func(i, j int) float64 {
+		return r.Equities[i].Distance(r.Equities[j])
+	})
+}
+
+// Potential returns distribution of turn hand over river clusters.
+func Potential(r *river.Abs, eq []float32, cb card.Cards) abs.Potential {
+	cbb := make(card.Cards, 7)
+	copy(cbb, cb)
+
+	next := make([]abs.Cluster, 0, 46)
+	for _, cx := range card.All(cb...) {
+		cbb[6] = cx
+		next = append(next, r.Map(iso.River.Index(cbb)))
+	}
+
+	return abs.NewPotential(next, eq)
+}
+
+type PotentialOpts struct {
+	River          *river.Abs
+	Clusters       int
+	MaxIterations  int
+	DeltaThreshold float64
+	// Samples is number of turn hands clustered by k-means, all hands
+	// are mapped to the nearest center. Zero clusters all hands.
+	Samples      int
+	Logger       poker.Logger
+	LogIteration int
+	Rng          frand.Rand
+}
+
+// PartitionPotential builds potential-aware turn abstraction: turn
+// hands are clustered by their distribution over river clusters with
+// EMD over distance of river clusters.
+func PartitionPotential(opts PotentialOpts) (*Abs, error) {
+	if opts.Rng == nil {
+		opts.Rng = frand.NewHash()
+	}
+
+	if opts.Logger == nil {
+		opts.Logger = poker.VoidLogger{}
+	}
+
+	rng := frand.Clone(opts.Rng)
+
+	ground, err := RiverGround(opts.River)
+	if err != nil {
+		return nil, err
+	}
+
+	eq := RiverEquity(opts.River)
+	idx := SampleIndexes(rng, opts.Samples)
+
+	opts.Logger.Printf("computing %d turn potentials", len(idx))
+
+	pp := make([]abs.Potential, len(idx))
+	err = abs.IndexWorkers(len(idx), func(i int, done uint64) error {
+		pp[i] = Potential(opts.River, eq, iso.Turn.Unindex(uint64(idx[i])))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	centers, err := ground.Partition(pp, abs.PotentialOpts{
+		Clusters:      opts.Clusters,
+		MaxIterations: opts.MaxIterations,
+		MaxDelta:      opts.DeltaThreshold,
+		Logger:        opts.Logger,
+		LogIteration:  opts.LogIteration,
+		Rng:           rng,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	absx := new(Abs)
+	for _, c := range centers {
+		absx.Equity = append(absx.Equity, c.Equity)
+	}
+
+	err = abs.IndexWorkers(Size, func(c int, done uint64) error {
+		p := Potential(opts.River, eq, iso.Turn.Unindex(uint64(c)))
+		absx.Clusters[c] = abs.Cluster(ground.Nearest(centers, p))
+
+		if opts.LogIteration > 0 && done%uint64(opts.LogIteration) == 0 {
+			opts.Logger.Printf("building turn mapping: %d/%d", done, Size)
+		}
+		return nil
+	})
+
+	return absx, err
+}
+
+// Means returns mean distribution over river clusters of turn hands in
+// every cluster of turn abstraction. Means are estimated from samples
+// of turn hands, zero samples uses all hands.
+func Means(a *Abs, r *river.Abs, samples int, rng frand.Rand) ([]abs.Potential, error) {
+	ground, err := RiverGround(r)
+	if err != nil {
+		return nil, err
+	}
+
+	eq := RiverEquity(r)
+	idx := SampleIndexes(rng, samples)
+
+	pp := make([]abs.Potential, len(idx))
+	err = abs.IndexWorkers(len(idx), func(i int, done uint64) error {
+		pp[i] = Potential(r, eq, iso.Turn.Unindex(uint64(idx[i])))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([][]abs.Potential, len(a.Equity))
+	for i, p := range pp {
+		c := a.Clusters[idx[i]]
+		groups[c] = append(groups[c], p)
+	}
+
+	means := make([]abs.Potential, len(groups))
+	for c, g := range groups {
+		means[c] = ground.Mean(g)
+		// Equity of sampled out cluster falls back to abstraction.
+		if len(g) == 0 {
+			means[c].Equity = a.Equity[c]
+		}
+	}
+
+	return means, nil
+}
+
+// SampleIndexes returns n random turn indexes, all of them when n is
+// zero or not below Size.
+func SampleIndexes(rng frand.Rand, n int) []int {
+	if n <= 0 || n >= Size {
+		idx := make([]int, Size)
+		for i := range idx {
+			idx[i] = i
+		}
+		return idx
+	}
+
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = rng.Intn(Size)
+	}
+	return idx
+}
@@ -2,6 +2,7 @@ package turn
 
 import (
 	"bytes"
+	"os"
 	"sort"
 
 	"github.com/pokerdroid/poker"
@@ -21,6 +22,20 @@ type Abs struct {
 	Clusters [Size]abs.Cluster
 }
 
+func NewFromFile(path string) (*Abs, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	a := new(Abs)
+	err = a.UnmarshalBinary(data)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
 func (a *Abs) Map(cluster uint32) abs.Cluster {
 	return a.Clusters[cluster]
 }
@@ -69,6 +84,9 @@ type PartitionOpts struct {
 	LogIteration   int
 	Rng            frand.Rand
 	Bins           int
+	// BatchSize runs mini-batch k-means with batches of the size.
+	BatchSize int
+	Seeding   abs.Seeding
 }
 
 // Partition executes the k-means algorithm on the given set of equities and
@@ -76,7 +94,11 @@ type PartitionOpts struct {
 func Partition(hh []abs.Histogram, opts PartitionOpts) (*Abs, error) {
 	rng := frand.Clone(opts.Rng)
 
-	groups, err := abs.NewRandomGroups(rng, hh, opts.Clusters)
+	distance := func(a, b abs.Histogram) float64 {
+		return a.Distance(b)
+	}
+
+	groups, err := abs.NewSeededGroups(rng, hh, opts.Clusters, distance, opts.Seeding)
 	if err != nil {
 		return nil, err
 	}
@@ -101,9 +123,10 @@ func Partition(hh []abs.Histogram, opts PartitionOpts) (*Abs, error) {
 			return center.Div(float32(len(e)))
 		},
 
-		Distance: func(a, b abs.Histogram) float64 {
-			return a.Distance(b)
-		},
+		Distance:   distance,
+		Accelerate: true,
+		BatchSize:  opts.BatchSize,
+		Blend:      abs.Histogram.Blend,
 	})
 	if err != nil {
 		return nil, err
@@ -142,27 +165,33 @@ type ComputeOpts struct {
 	LogIteration int
 }
 
+// Hist returns histogram of river equities of turn hand over all
+// river cards, equity is the mean.
+func Hist(buckets *river.Buckets, cb card.Cards, bins int) abs.Histogram {
+	h := abs.Histogram{
+		Bins:   make([]float32, bins),
+		Equity: 0,
+	}
+
+	var counter float32
+	for _, cx := range card.All(cb...) {
+		cbb := append(cb, cx)
+		clus := abs.Cluster(iso.River.Index(cbb))
+		eq := buckets.Get(clus)
+		h = h.Increment(eq.WinDraw())
+		counter++
+	}
+
+	h = h.Normalize()
+	h.Equity = h.Equity / counter
+	return h
+}
+
 func Compute(opts ComputeOpts) ([]abs.Histogram, error) {
 	hh := make([]abs.Histogram, Size)
 
 	err := abs.IndexWorkers(Size, func(c int, done uint64) error {
-		cb := iso.Turn.Unindex(uint64(c))
-		h := abs.Histogram{
-			Bins:   make([]float32, opts.Bins),
-			Equity: 0,
-		}
-
-		var counter float32
-		for _, cx := range card.All(cb...) {
-			cbb := append(cb, cx)
-			clus := abs.Cluster(iso.River.Index(cbb))
-			eq := opts.Buckets.Get(clus)
-			h = h.Increment(eq.WinDraw())
-			counter++
-		}
-
-		h = h.Normalize()
-		h.Equity = h.Equity / counter
+		h := Hist(opts.Buckets, iso.Turn.Unindex(uint64(c)), opts.Bins)
 
 		if done%uint64(opts.LogIteration) == 0 {
 			opts.Logger.Printf("computing turn histograms: %d/%d", done, Size)
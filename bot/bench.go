@@ -40,7 +40,7 @@ func Benchmark(ctx context.Context, opts BenchmarkParams) chips.List {
 
 	Loop:
 		for r := 0; r <= rounds; r++ {
-			deck := card.NewDeck(card.All())
+			deck := card.NewDeck(opts.Params.Variant.Cards())
 			deck.Shuffle(opts.Rand)
 
 			prms := opts.Params.Clone()
@@ -54,7 +54,7 @@ func Benchmark(ctx context.Context, opts BenchmarkParams) chips.List {
 
 			var v []card.Cards
 			for i := 0; i < int(prms.NumPlayers); i++ {
-				v = append(v, deck.PopMulti(2))
+				v = append(v, deck.PopMulti(prms.Variant.HoleCards()))
 			}
 
 			var c card.Cards
@@ -116,9 +116,11 @@ func Benchmark(ctx context.Context, opts BenchmarkParams) chips.List {
 
 			}
 
-			winnings := table.GetWinnings(round.Latest.Players, &table.Cards{
+			pots := table.GetPots(round.Latest.Players)
+			winnings := table.GetPotsWinnings(len(round.Latest.Players), pots, &table.Cards{
 				Community: c,
 				Players:   v,
+				Variant:   prms.Variant,
 			})
 
 			mux.Lock()
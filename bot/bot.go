@@ -24,12 +24,14 @@ type State struct {
 }
 
 func (s State) Validate() error {
-	if len(s.Hole) != 2 {
+	if len(s.Hole) != s.Params.Variant.HoleCards() {
 		return fmt.Errorf("invalid hole cards: %v", s.Hole)
 	}
 
-	if s.Hole[0] == card.Card00 || s.Hole[1] == card.Card00 {
-		return fmt.Errorf("invalid hole cards: %v", s.Hole)
+	for _, c := range s.Hole {
+		if c == card.Card00 {
+			return fmt.Errorf("invalid hole cards: %v", s.Hole)
+		}
 	}
 
 	if len(s.Community) > 5 {
@@ -99,7 +101,7 @@ func (s *State) UnmarshalBinary(data []byte) error {
 		return err
 	}
 
-	hole := make([]byte, 2)
+	hole := make([]byte, s.Params.Variant.HoleCards())
 	_, err = io.ReadFull(buf, hole)
 	if err != nil {
 		return err
@@ -300,3 +300,31 @@ func TestMarshalUnmarshal(t *testing.T) {
 
 	require.Equal(t, exampleState, got)
 }
+
+func TestBotStateOmaha(t *testing.T) {
+	params := table.NewGameParams(2, chips.NewFromInt(100))
+	params.Variant = card.Omaha
+	params.Structure = table.PotLimit
+
+	ts, err := table.MakeInitialBets(params, table.NewState(params))
+	require.NoError(t, err)
+
+	orig := bot.State{
+		Params:    params,
+		State:     ts,
+		Hole:      card.NewCardsFromString("as ks qh jh"),
+		Community: card.NewCardsFromString("ts 9s 8s"),
+	}
+
+	data, err := orig.MarshalBinary()
+	require.NoError(t, err)
+
+	var got bot.State
+	require.NoError(t, got.UnmarshalBinary(data))
+	require.Equal(t, orig.Hole, got.Hole)
+	require.Equal(t, orig.Community, got.Community)
+
+	// Holdem hand is not valid omaha hand.
+	orig.Hole = card.NewCardsFromString("as ks")
+	require.Error(t, orig.Validate())
+}
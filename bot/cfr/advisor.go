@@ -22,6 +22,13 @@ type Advisor struct {
 	Logger  poker.Logger
 	Abs     abs.Mapper
 	Advisor AdvisorFn
+	// IgnoreAbs plays solutions trained with other abstraction. Only
+	// IDs are compared per decision, bucket counts of Roots are checked
+	// with CheckAbs on load.
+	IgnoreAbs bool
+	// Ranges caches ranges of every root for search, see
+	// tree.NewRangeCaches. Ranges are computed per search when nil.
+	Ranges map[*tree.Root]*tree.RangeCache
 }
 
 func AdvisorSimple(s *Advisor, root *tree.Root) bot.Advisor {
@@ -38,6 +45,7 @@ func AdvisorWithSearch(s *Advisor, root *tree.Root) bot.Advisor {
 		Rand:        s.Rand,
 		MaxDuration: time.Second * 7,
 		Root:        root,
+		Ranges:      s.Ranges[root],
 	}
 }
 func (a Advisor) Advise(ctx context.Context, loggr poker.Logger, state bot.State) (tb table.DiscreteAction, err error) {
@@ -48,6 +56,13 @@ func (a Advisor) Advise(ctx context.Context, loggr poker.Logger, state bot.State
 		return
 	}
 
+	if !a.IgnoreAbs {
+		err = root.CheckAbsID(a.Abs)
+		if err != nil {
+			return
+		}
+	}
+
 	bbs := chips.NewListAlloc(len(state.Params.InitialStacks))
 	bbz := chips.NewListAlloc(len(state.Params.InitialStacks))
 
@@ -0,0 +1,222 @@
+package cfr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/bot"
+	"github.com/pokerdroid/poker/float/f64"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/table"
+	"github.com/pokerdroid/poker/tree"
+	"github.com/pokerdroid/poker/tree/mapping"
+)
+
+// Blend plays state with the two solutions bracketing its effective
+// stack. Their strategies are interpolated by stack distance, so 63bb
+// spot with 50bb and 80bb solutions is played 17/30 with the 50bb
+// strategy and 13/30 with the 80bb one.
+//
+// Actions of the farther solution are translated into actions of the
+// closer one using pseudo-harmonic mapping.
+type Blend struct {
+	Roots []*tree.Root
+	Abs   abs.Mapper
+	Rand  frand.Rand
+	// IgnoreAbs plays solutions trained with other abstraction. Only
+	// IDs are compared per decision, bucket counts of Roots are checked
+	// with CheckAbs on load.
+	IgnoreAbs bool
+}
+
+type blendSource struct {
+	root   *tree.Root
+	weight float64
+	acts   []table.DiscreteAction
+	strat  []float64
+}
+
+func (b Blend) Advise(ctx context.Context, loggr poker.Logger, state bot.State) (table.DiscreteAction, error) {
+	lo, hi, w := tree.FindBracketingRoots(b.Roots, state.Params, state.State.TurnPos)
+	if lo == nil {
+		return 0, errors.New("no solution found")
+	}
+
+	if !b.IgnoreAbs {
+		for _, r := range []*tree.Root{lo, hi} {
+			if err := r.CheckAbsID(b.Abs); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	srcs := []*blendSource{{root: lo, weight: 1 - w}}
+	if hi != lo {
+		srcs = append(srcs, &blendSource{root: hi, weight: w})
+	}
+
+	// Closer solution decides action space.
+	if len(srcs) == 2 && w > 0.5 {
+		srcs[0], srcs[1] = srcs[1], srcs[0]
+	}
+
+	cards := append(state.Hole.Clone(), state.Community...)
+	cluster := b.Abs.Map(cards)
+
+	var ok []*blendSource
+	for _, src := range srcs {
+		err := src.load(state, cluster)
+
+		bbs := src.root.Params.EffectiveStack(state.State.TurnPos).Div(src.root.Params.SbAmount.Mul(2))
+		if err != nil {
+			loggr.Printf("Source %sbb weight %.3f: %s\n", bbs, src.weight, err)
+			continue
+		}
+
+		loggr.Printf("Source %sbb weight %.3f: %s\n", bbs, src.weight, blendString(src.acts, src.strat))
+		ok = append(ok, src)
+	}
+
+	if len(ok) == 0 {
+		return 0, ErrNoPolicy
+	}
+
+	acts, strat := blendStrategies(ok)
+	loggr.Printf("Blended: %s\n", blendString(acts, strat))
+
+	action, err := sampleStrategy(b.Rand, acts, strat)
+	if err != nil {
+		return 0, err
+	}
+
+	loggr.Printf("Chosen action: %s\n", action.String())
+	return action, nil
+}
+
+func (s *blendSource) load(state bot.State, cluster abs.Cluster) error {
+	p, err := mapping.MapGameStateToTree(state.Params, state.State, s.root)
+	if err != nil {
+		return err
+	}
+
+	if p == nil || p.Actions == nil || p.Actions.Policies == nil {
+		return ErrNoState
+	}
+
+	pol, ok := p.Actions.Policies.Get(cluster)
+	if !ok {
+		return ErrNoPolicy
+	}
+
+	s.acts, s.strat = LegalStrategy(state, p.Actions.Actions, pol.GetAverageStrategy())
+	return nil
+}
+
+// blendStrategies interpolates strategies of sources over action
+// space of the first one. Weights are renormalized if some source
+// is missing.
+func blendStrategies(srcs []*blendSource) ([]table.DiscreteAction, []float64) {
+	var acts []table.DiscreteAction
+	idx := make(map[table.DiscreteAction]int)
+
+	for _, a := range srcs[0].acts {
+		if _, ok := idx[a]; ok {
+			continue
+		}
+		idx[a] = len(acts)
+		acts = append(acts, a)
+	}
+
+	var total float64
+	for _, src := range srcs {
+		total += src.weight
+	}
+
+	strat := make([]float64, len(acts))
+
+	for _, src := range srcs {
+		w := 1 / float64(len(srcs))
+		if total > 0 {
+			w = src.weight / total
+		}
+
+		sum := f64.Sum(src.strat)
+		if sum == 0 {
+			continue
+		}
+
+		for i, a := range src.acts {
+			j, ok := idx[translateAction(a, acts)]
+			if !ok {
+				continue
+			}
+			strat[j] += w * src.strat[i] / sum
+		}
+	}
+
+	return acts, strat
+}
+
+// translateAction maps action into given action space. Bets are
+// mapped with pseudo-harmonic mapping, all-in falls back to the
+// largest bet. Returns DNoAction if there is no counterpart.
+func translateAction(a table.DiscreteAction, acts []table.DiscreteAction) table.DiscreteAction {
+	var bets []table.DiscreteAction
+	var allin bool
+
+	for _, x := range acts {
+		if x == a {
+			return a
+		}
+		if x == table.DAllIn {
+			allin = true
+		}
+		if x > 0 {
+			bets = append(bets, x)
+		}
+	}
+
+	sort.Slice(bets, func(i, j int) bool {
+		return bets[i] < bets[j]
+	})
+
+	switch {
+	case a == table.DAllIn && len(bets) > 0:
+		return bets[len(bets)-1]
+
+	case a > 0 && len(bets) > 0:
+		switch {
+		case a <= bets[0]:
+			return bets[0]
+		case a >= bets[len(bets)-1]:
+			return bets[len(bets)-1]
+		}
+		return mapping.PseudoHarmonicMapping(a, bets)
+
+	case a > 0 && allin:
+		return table.DAllIn
+	}
+
+	return table.DNoAction
+}
+
+func blendString(acts []table.DiscreteAction, strat []float64) string {
+	sum := f64.Sum(strat)
+
+	s := ""
+	for i, a := range acts {
+		p := strat[i]
+		if sum > 0 {
+			p /= sum
+		}
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%s:%.3f", a.Short(), p)
+	}
+	return s
+}
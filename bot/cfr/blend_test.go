@@ -0,0 +1,97 @@
+package cfr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/bot"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/chips"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/policy"
+	"github.com/pokerdroid/poker/table"
+	"github.com/pokerdroid/poker/tree"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslateAction(t *testing.T) {
+	acts := []table.DiscreteAction{table.DFold, table.DCall, 1, 2, table.DAllIn}
+
+	require.Equal(t, table.DCall, translateAction(table.DCall, acts))
+	require.Equal(t, table.DiscreteAction(1), translateAction(0.5, acts))
+	require.Equal(t, table.DiscreteAction(1), translateAction(1.2, acts))
+	require.Equal(t, table.DiscreteAction(2), translateAction(1.5, acts))
+	require.Equal(t, table.DiscreteAction(2), translateAction(3, acts))
+	require.Equal(t, table.DNoAction, translateAction(table.DCheck, acts))
+
+	noallin := []table.DiscreteAction{table.DFold, table.DCall, 1, 2}
+	require.Equal(t, table.DiscreteAction(2), translateAction(table.DAllIn, noallin))
+}
+
+func TestBlendAdvisor(t *testing.T) {
+	// Both solutions bet everything, 10bb with 1x pot and 30bb with 1.5x.
+	newRoot := func(bb int64, size float32) *tree.Root {
+		prms := table.NewGameParams(2, chips.NewFromInt(bb*2))
+		prms.BetSizes = [][]float32{{1, 2, 1.5}}
+		prms.MaxActionsPerRound = 2
+		prms.TerminalStreet = table.Preflop
+
+		root, err := tree.NewRoot(prms)
+		require.NoError(t, err)
+		require.NoError(t, tree.ExpandFull(root))
+
+		p := root.Next.(*tree.Chance).Next.(*tree.Player)
+
+		pol := policy.New(len(p.Actions.Actions))
+		pol.StrategySum[p.Actions.GetIdx(table.DiscreteAction(size))] = 1
+		p.Actions.Policies.Store(abs.Cluster(0), pol)
+
+		return root
+	}
+
+	roots := []*tree.Root{newRoot(10, 1), newRoot(30, 1.5)}
+
+	// 15bb is closer to 10bb solution.
+	prms := table.NewGameParams(2, chips.NewFromInt(30))
+	s, err := table.MakeInitialBets(prms, table.NewState(prms))
+	require.NoError(t, err)
+
+	st := bot.State{
+		Params:    prms,
+		State:     s,
+		Hole:      card.NewCardsFromString("as ks"),
+		Community: card.Cards{},
+	}
+
+	srcs := []*blendSource{
+		{root: roots[0], weight: 0.75},
+		{root: roots[1], weight: 0.25},
+	}
+	for _, src := range srcs {
+		require.NoError(t, src.load(st, 0))
+	}
+
+	acts, strat := blendStrategies(srcs)
+	for i, a := range acts {
+		switch a {
+		case 1:
+			require.InDelta(t, 0.75, strat[i], 1e-9)
+		case 1.5:
+			require.InDelta(t, 0.25, strat[i], 1e-9)
+		default:
+			require.Zero(t, strat[i], a.String())
+		}
+	}
+
+	b := Blend{
+		Roots: roots,
+		Abs:   &mockGetter{},
+		Rand:  frand.NewHash(),
+	}
+
+	act, err := b.Advise(context.Background(), &poker.TestingLogger{T: t}, st)
+	require.NoError(t, err)
+	require.Contains(t, []table.DiscreteAction{1, 1.5}, act)
+}
@@ -25,20 +25,25 @@ type SampleParams struct {
 // Sample againts real policy. This is used to sample actions from
 // a policy accounting for cases where policy might be bit off.
 func SampleState(params *SampleParams) (table.DiscreteAction, error) {
-	s := params.State
 	strategy := params.Policy.GetAverageStrategy()
+	acts, strat := LegalStrategy(params.State, params.Actions, strategy)
+	return sampleStrategy(params.Rng, acts, strat)
+}
+
+// LegalStrategy keeps only actions legal in the real game state,
+// bets exceeding the stack become all-in. Returned strategy is not
+// normalized.
+func LegalStrategy(s bot.State, actions []table.DiscreteAction, strategy []float64) ([]table.DiscreteAction, []float64) {
 	legal := table.NewLegalActions(s.Params, s.State)
 
 	paid := s.State.Players[s.State.TurnPos].Paid
 	stack := s.Params.InitialStacks[s.State.TurnPos].Sub(paid)
 
-	// Create new strategy considering only legal actions
-	var sum float64
 	strat := make([]float64, 0, len(strategy))
 	acts := make([]table.DiscreteAction, 0, len(strategy))
 
 	// Copy probabilities only for legal actions
-	for i, act := range params.Actions {
+	for i, act := range actions {
 		ax, amount := act.GetAction(s.Params, s.State)
 		min, ok := legal[ax]
 		if !ok {
@@ -53,11 +58,16 @@ func SampleState(params *SampleParams) (table.DiscreteAction, error) {
 		if amount.GreaterThanOrEqual(stack) && ok {
 			act = table.DAllIn
 		}
-		sum += strategy[i]
 		strat = append(strat, strategy[i])
 		acts = append(acts, act)
 	}
 
+	return acts, strat
+}
+
+func sampleStrategy(rng frand.Rand, acts []table.DiscreteAction, strat []float64) (table.DiscreteAction, error) {
+	sum := f64.Sum(strat)
+
 	// Normalize if sum is not zero
 	if sum == 0 {
 		// panic("sum is zero")
@@ -65,6 +75,6 @@ func SampleState(params *SampleParams) (table.DiscreteAction, error) {
 	}
 
 	f64.ScalUnitary(1/sum, strat)
-	indx := frand.SampleIndex(params.Rng, strat, 0.00001)
+	indx := frand.SampleIndex(rng, strat, 0.00001)
 	return acts[indx], nil
 }
@@ -46,6 +46,8 @@ type SearchParams struct {
 	EpochSize uint64
 	// Workers
 	Workers int
+	// Ranges of Tree, computed for the search when nil.
+	Ranges *tree.RangeCache
 }
 
 type SearchResult struct {
@@ -105,18 +107,26 @@ func Search(ctx context.Context, params *SearchParams) (*SearchResult, error) {
 	logf("\n================================")
 	logf("Starting search\n")
 
-	actions := tree.ExtractActions(p)
+	rc := params.Ranges
+	if rc == nil {
+		rc = tree.NewRangeCache(params.Tree, params.Abs, 1)
+	}
 
-	ranges, err := cfr.ComputeRange(cfr.ComputeRangeParams{
-		Actions: actions,
-		Players: root.Params.NumPlayers,
-		Board:   params.Board,
-		Abs:     params.Abs,
-	})
+	rs, err := rc.Get(params.Board)
 	if err != nil {
 		return nil, err
 	}
 
+	nr, ok := rs.Get(p)
+	if !ok {
+		return nil, errors.New("no ranges at searched node")
+	}
+
+	ranges := make([]card.RangeDist, len(nr.Weights))
+	for i, w := range nr.Weights {
+		ranges[i] = w.Normalize()
+	}
+
 	dealer := holdemdealer.NewWeighted(holdemdealer.RangeParams{
 		NumPlayers: root.Params.NumPlayers,
 		Board:      params.Board,
@@ -169,6 +179,7 @@ func Search(ctx context.Context, params *SearchParams) (*SearchResult, error) {
 
 type SearchAdvisor struct {
 	Abs         abs.Mapper
+	Ranges      *tree.RangeCache
 	RiverAbs    *river.Abs
 	Rand        frand.Rand
 	MaxDuration time.Duration
@@ -197,6 +208,7 @@ func (a SearchAdvisor) Advise(ctx context.Context, loggr poker.Logger, state bot
 		Workers:   runtime.NumCPU(),
 		Params:    state.Params,
 		RiverAbs:  a.RiverAbs,
+		Ranges:    a.Ranges,
 	})
 	if err != nil {
 		return 0, err
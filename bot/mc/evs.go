@@ -171,7 +171,7 @@ func Aggressivity(s *table.State, pos uint8) float32 {
 		}
 
 		switch record.Action.Action {
-		case table.SmallBlind, table.BigBlind:
+		case table.SmallBlind, table.BigBlind, table.Straddle, table.Ante:
 			// ignore these
 		case table.Check, table.Call:
 			actions++
@@ -10,46 +10,19 @@ type OpenRange struct {
 	Range [13][13]float64
 }
 
+// openRange is Alpha holdem opening range, weight is probability of
+// not folding heads up.
+const openRange = "22+,A2+,K2+,Q2+,J2s+,J4o+,T2s+,T5o+,T4o:0.37,92s+,95o+," +
+	"82s+,85o+,72s+,74o+,62s+,64o+,52s+,54o,42s+,43o:0.28,32s"
+
 func NewOpenRange() OpenRange {
-	openRange := OpenRange{}
-	for i := range openRange.Range {
-		for j := range openRange.Range[i] {
-			openRange.Range[i][j] = 1
-		}
-	}
-	openRange.Range[10][4] = 0.37
-	openRange.Range[10][5] = 0
-	openRange.Range[10][6] = 0
-	openRange.Range[11][3] = 0
-	for i := 4; i < 10; i++ {
-		openRange.Range[11][i] = 0
+	r, err := card.NewRangeDistFromString(openRange)
+	if err != nil {
+		panic(err)
 	}
-	openRange.Range[11][10] = 0.28
-	openRange.Range[12][3] = 0
-	for i := 4; i < 12; i++ {
-		openRange.Range[12][i] = 0
-	}
-	return openRange
+	return OpenRange{Range: r.Frequencies()}
 }
 
-//  Ahlpha holdem opening range
-//  13:13, matrix, each node represent probability of not folding in HU
-//
-//    [A    K    Q    J    T    9    8    7    6    5    4    3    2]
-//  A [1    1    1    1    1    1    1    1    1    1    1    1    1]
-//  K [1    1    1    1    1    1    1    1    1    1    1    1    1]
-//  Q [1    1    1    1    1    1    1    1    1    1    1    1    1]
-//  J [1    1    1    1    1    1    1    1    1    1    1    1    1]
-//  T [1    1    1    1    1    1    1    1    1    1    1    1    1]
-//  9 [1    1    1    1    1    1    1    1    1    1    1    1    1]
-//  8 [1    1    1    1    1    1    1    1    1    1    1    1    1]
-//  7 [1    1    1    1    1    1    1    1    1    1    1    1    1]
-//  6 [1    1    1    1    1    1    1    1    1    1    1    1    1]
-//  5 [1    1    1    1    1    1    1    1    1    1    1    1    1]
-//  4 [1    1    1    1    0.37 0.   0.   1    1    1    1    1    1]
-//  3 [1    1    1    0.   0.   0.   0.   0.   0.   0.   0.28 1    1]
-//  2 [1    1    1    0.   0.   0.   0.   0.   0.   0.   0.   0.   1]
-
 func (o *OpenRange) WeakRange(hole card.Cards) bool {
 	if len(hole) != 2 {
 		fmt.Println("invalid hole cards")
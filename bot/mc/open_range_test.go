@@ -19,3 +19,12 @@ func TestOpenRange(t *testing.T) {
 	c = card.Cards{card.Card3C, card.Card2C}
 	require.False(t, a.WeakRange(c))
 }
+
+func TestOpenRangeWeights(t *testing.T) {
+	a := NewOpenRange()
+	require.Equal(t, 1., a.Range[0][0])
+	require.InDelta(t, 0.37, a.Range[10][4], 1e-9)
+	require.InDelta(t, 0.28, a.Range[11][10], 1e-9)
+	require.Equal(t, 0., a.Range[12][4])
+	require.Equal(t, 1., a.Range[4][12])
+}
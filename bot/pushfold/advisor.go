@@ -0,0 +1,105 @@
+package pushfold
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/bot"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/pushfold"
+	"github.com/pokerdroid/poker/table"
+)
+
+var ErrNotPushFold = errors.New("not push/fold spot")
+
+// Advisor plays preflop by push/fold charts once effective stack
+// drops to Threshold big blinds. Outside of charts it returns error,
+// combine it with other advisors to play the rest.
+type Advisor struct {
+	Charts    pushfold.Charts
+	Threshold float64
+	Rand      frand.Rand
+}
+
+// NewAdvisor creates advisor with threshold of the deepest chart.
+func NewAdvisor(charts pushfold.Charts) *Advisor {
+	return &Advisor{
+		Charts:    charts,
+		Threshold: charts.MaxStack(),
+		Rand:      frand.NewHash(),
+	}
+}
+
+func (a *Advisor) Advise(ctx context.Context, logger poker.Logger, state bot.State) (table.DiscreteAction, error) {
+	st := state.State
+	prms := state.Params
+
+	if st.Street != table.Preflop {
+		return table.DNoAction, ErrNotPushFold
+	}
+
+	np := int(prms.NumPlayers)
+	bb := prms.SbAmount.Float64() * 2
+
+	// Effective stack is own stack capped by the biggest stack of
+	// other players left in hand.
+	var other float64
+	for i, p := range st.Players {
+		if uint8(i) != st.TurnPos && p.Status != table.StatusFolded {
+			other = math.Max(other, prms.InitialStacks[i].Float64())
+		}
+	}
+	stack := math.Min(prms.InitialStacks[st.TurnPos].Float64(), other) / bb
+
+	if stack > a.Threshold {
+		return table.DNoAction, ErrNotPushFold
+	}
+
+	// First seat acts after the big blind, heads up that is the
+	// small blind.
+	_, _, bbPos := table.PositionsFor(prms, st)
+	first := (int(bbPos) + 1) % np
+
+	seat := (int(st.TurnPos) - first + np) % np
+
+	// Earlier seats must have folded or gone all-in.
+	var mask uint8
+	for k := 0; k < seat; k++ {
+		switch st.Players[(first+k)%np].Status {
+		case table.StatusAllIn:
+			mask |= 1 << k
+		case table.StatusActive:
+			return table.DNoAction, ErrNotPushFold
+		}
+	}
+
+	spot := pushfold.Spot{Players: uint8(np), Seat: uint8(seat), AllIn: mask}
+
+	chart, ok := a.Charts.Find(spot, stack)
+	if !ok {
+		return table.DNoAction, ErrNotPushFold
+	}
+
+	legal := table.NewDiscreteLegalActions(prms, st)
+	freq := chart.Freq(state.Hole)
+
+	logger.Printf("push/fold: %s @ %.1fbb: %.2f", spot, stack, freq)
+
+	if a.Rand.Float64() < freq {
+		// Calling shorter all-in keeps the rest of the stack behind.
+		if _, ok := legal[table.DCall]; ok && !spot.Push() {
+			return table.DCall, nil
+		}
+		if _, ok := legal[table.DAllIn]; ok {
+			return table.DAllIn, nil
+		}
+	}
+
+	if _, ok := legal[table.DCheck]; ok {
+		return table.DCheck, nil
+	}
+
+	return table.DFold, nil
+}
@@ -0,0 +1,92 @@
+package pushfold
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/bot"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/chips"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/pushfold"
+	"github.com/pokerdroid/poker/table"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvisor(t *testing.T) {
+	ctx := context.Background()
+	logger := poker.VoidLogger{}
+
+	aa := card.NewCardsFromString("As Ah")
+	sevenTwo := card.NewCardsFromString("7s 2h")
+
+	// Push and call only pocket aces.
+	var rng card.Matrix
+	x, y := card.Coordinates(aa)
+	rng[x][y] = 1
+
+	charts := pushfold.Charts{
+		{Spot: pushfold.Spot{Players: 3, Seat: 0}, Stack: 10, Range: rng},
+		{Spot: pushfold.Spot{Players: 3, Seat: 1, AllIn: 1}, Stack: 10, Range: rng},
+		{Spot: pushfold.Spot{Players: 3, Seat: 2, AllIn: 1}, Stack: 10, Range: rng},
+	}
+
+	a := NewAdvisor(charts)
+	a.Rand = frand.NewUnsafe()
+	require.Equal(t, 10.0, a.Threshold)
+
+	prms := table.NewGameParams(3, chips.New(20))
+	prms.BtnPos = 1
+	prms.Blinds = table.DefaultBlinds(prms.NumPlayers, prms.SbAmount)
+
+	game, err := table.NewGame(prms)
+	require.NoError(t, err)
+
+	// Button acts first three handed.
+	require.Equal(t, uint8(1), game.Latest.TurnPos)
+
+	action, err := a.Advise(ctx, logger, bot.State{Params: prms, State: game.Latest, Hole: sevenTwo})
+	require.NoError(t, err)
+	require.Equal(t, table.DFold, action)
+
+	action, err = a.Advise(ctx, logger, bot.State{Params: prms, State: game.Latest, Hole: aa})
+	require.NoError(t, err)
+	require.Equal(t, table.DAllIn, action)
+
+	require.NoError(t, game.Action(table.DAllIn))
+
+	// Small blind faces button all-in.
+	action, err = a.Advise(ctx, logger, bot.State{Params: prms, State: game.Latest, Hole: aa})
+	require.NoError(t, err)
+	require.Equal(t, table.DCall, action)
+
+	require.NoError(t, game.Action(table.DFold))
+
+	// Big blind faces button all-in after small blind folded.
+	action, err = a.Advise(ctx, logger, bot.State{Params: prms, State: game.Latest, Hole: sevenTwo})
+	require.NoError(t, err)
+	require.Equal(t, table.DFold, action)
+
+	t.Run("deep", func(t *testing.T) {
+		prms := table.NewGameParams(3, chips.New(100))
+
+		game, err := table.NewGame(prms)
+		require.NoError(t, err)
+
+		_, err = a.Advise(ctx, logger, bot.State{Params: prms, State: game.Latest, Hole: aa})
+		require.ErrorIs(t, err, ErrNotPushFold)
+	})
+
+	t.Run("limp", func(t *testing.T) {
+		prms := table.NewGameParams(3, chips.New(20))
+		prms.Limp = true
+
+		game, err := table.NewGame(prms)
+		require.NoError(t, err)
+		require.NoError(t, game.Action(table.DCall))
+
+		_, err = a.Advise(ctx, logger, bot.State{Params: prms, State: game.Latest, Hole: aa})
+		require.ErrorIs(t, err, ErrNotPushFold)
+	})
+}
@@ -323,6 +323,34 @@ func Coordinates(hole Cards) (int, int) {
 	}
 }
 
+// CoordsName returns the hand class name for matrix coordinates,
+// e.g. "AKs", "QQ" or "72o".
+func CoordsName(x, y int) string {
+	hi, lo := Rank(13-x), Rank(13-y)
+	switch {
+	case x == y:
+		return hi.String() + lo.String()
+	case x < y:
+		return hi.String() + lo.String() + "s"
+	default:
+		return lo.String() + hi.String() + "o"
+	}
+}
+
+// ComboName returns the name of a specific 2-card combination with
+// the higher card first, e.g. "AhKh".
+func ComboName(hole Cards) string {
+	if len(hole) != 2 {
+		panic("invalid number of cards")
+	}
+	hi, lo := hole[0], hole[1]
+	if lo.Rank() > hi.Rank() || (lo.Rank() == hi.Rank() && lo > hi) {
+		hi, lo = lo, hi
+	}
+	return strings.ToUpper(hi.String()[:1]) + hi.String()[1:] +
+		strings.ToUpper(lo.String()[:1]) + lo.String()[1:]
+}
+
 var mcards = [13][13][]Cards{}
 
 func init() {
@@ -79,3 +79,16 @@ func TestCardsInCoords(t *testing.T) {
 	require.Equal(t, eq[1], Cards{Card2H, CardAH})
 	require.Equal(t, eq[2], Cards{Card2S, CardAS})
 }
+
+func TestCoordsName(t *testing.T) {
+	require.Equal(t, "AA", CoordsName(0, 0))
+	require.Equal(t, "AKs", CoordsName(0, 1))
+	require.Equal(t, "AKo", CoordsName(1, 0))
+	require.Equal(t, "72o", CoordsName(12, 7))
+
+	x, y := Coordinates(Cards{CardKH, CardQH})
+	require.Equal(t, "KQs", CoordsName(x, y))
+
+	require.Equal(t, "AhKh", ComboName(Cards{CardKH, CardAH}))
+	require.Equal(t, "AsAc", ComboName(Cards{CardAC, CardAS}))
+}
@@ -48,6 +48,9 @@ func (ht HandRankKind) String() string {
 type HandRank struct {
 	Kind HandRankKind `json:"kind"`
 	Rank uint32       `json:"rank"`
+	// Variant decides order of kinds, only ranks of the same
+	// variant can be compared.
+	Variant Variant `json:"variant,omitempty"`
 }
 
 func NewHandRank(ht HandRankKind, rank uint32) HandRank {
@@ -71,11 +74,13 @@ func (h HandRank) Compare(other HandRank) int {
 		return 2
 	}
 
-	if h.Kind > other.Kind {
+	ho, oo := h.Variant.Order(h.Kind), other.Variant.Order(other.Kind)
+
+	if ho > oo {
 		return 0
 	}
 
-	if h.Kind < other.Kind {
+	if ho < oo {
 		return 1
 	}
 
@@ -87,16 +92,18 @@ func (h HandRank) Compare(other HandRank) int {
 }
 
 type handRankBinary struct {
-	Kind uint8
-	Rank uint32
+	Kind    uint8
+	Rank    uint32
+	Variant uint8
 }
 
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
 func (h HandRank) MarshalBinary() ([]byte, error) {
 	buf := new(bytes.Buffer)
 	err := binary.Write(buf, binary.LittleEndian, handRankBinary{
-		Kind: uint8(h.Kind),
-		Rank: h.Rank,
+		Kind:    uint8(h.Kind),
+		Rank:    h.Rank,
+		Variant: uint8(h.Variant),
 	})
 	return buf.Bytes(), err
 }
@@ -107,5 +114,6 @@ func (h *HandRank) UnmarshalBinary(buf []byte) error {
 	err := binary.Read(bytes.NewBuffer(buf), binary.LittleEndian, &dd)
 	h.Kind = HandRankKind(dd.Kind)
 	h.Rank = dd.Rank
+	h.Variant = Variant(dd.Variant)
 	return err
 }
@@ -2,9 +2,12 @@ package card
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/pokerdroid/poker/encbin"
 	"golang.org/x/exp/constraints"
@@ -128,6 +131,31 @@ func (m Matrix) String() string {
 	return sb.String()
 }
 
+// NewMatrixFromString parses 169 numbers in row-major order, any
+// other characters are separators, so output of String is accepted.
+func NewMatrixFromString(s string) (Matrix, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.' && r != '-' && r != 'e' && r != '+'
+	})
+	if len(fields) != 13*13 {
+		return Matrix{}, fmt.Errorf("expected %d values, got %d", 13*13, len(fields))
+	}
+
+	flat := make([]float64, len(fields))
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return Matrix{}, err
+		}
+		if v < 0 {
+			return Matrix{}, errors.New("negative value in matrix")
+		}
+		flat[i] = v
+	}
+
+	return NewMatrix(flat), nil
+}
+
 // MarshalBinary returns the binary serialization of the matrix.
 // It mimics the style in encoding.go by writing each element sequentially.
 func (m Matrix) MarshalBinary() ([]byte, error) {
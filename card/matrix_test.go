@@ -69,3 +69,19 @@ func TestNewMatrixWithMultipleBlockers(t *testing.T) {
 		require.InDelta(t, 0, m[i][12], 1e-5)
 	}
 }
+
+func TestNewMatrixFromString(t *testing.T) {
+	m := NewOpenMatrix()
+
+	n, err := NewMatrixFromString(m.String())
+	require.NoError(t, err)
+
+	for i := 0; i < 13; i++ {
+		for j := 0; j < 13; j++ {
+			require.InDelta(t, m[i][j], n[i][j], 1e-4)
+		}
+	}
+
+	_, err = NewMatrixFromString("1 2 3")
+	require.Error(t, err)
+}
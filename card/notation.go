@@ -0,0 +1,347 @@
+package card
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// NewRangeDistFromString parses range text, comma or space separated
+// items of:
+//
+//   - hand classes "QQ", "AKs", "AKo" and "AK" for both,
+//   - classes with better kickers "QQ+" (QQ-AA), "A2s+" (A2s-AKs),
+//   - spans of kickers "A5s-A2s" or pairs "TT-77",
+//   - specific combos "AhKh".
+//
+// Every item can have weight "KQo:0.5" or percentage "76s@50". Weights
+// are not normalized, later items override earlier ones.
+func NewRangeDistFromString(s string) (RangeDist, error) {
+	var r RangeDist
+
+	items := strings.FieldsFunc(s, func(c rune) bool {
+		return c == ',' || c == ' ' || c == '\n' || c == '\t' || c == '\r'
+	})
+
+	for _, item := range items {
+		hand, weight, err := parseWeight(item)
+		if err != nil {
+			return r, err
+		}
+
+		combos, err := parseItem(hand)
+		if err != nil {
+			return r, err
+		}
+
+		for _, c := range combos {
+			r[RangeIndex(c)] = weight
+		}
+	}
+
+	return r, nil
+}
+
+func parseWeight(item string) (string, float64, error) {
+	i := strings.IndexAny(item, ":@")
+	if i < 0 {
+		return item, 1, nil
+	}
+
+	w, err := strconv.ParseFloat(item[i+1:], 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid weight of %q", item)
+	}
+
+	if item[i] == '@' {
+		w /= 100
+	}
+
+	// Weight is frequency, NaN fails both checks.
+	if !(w >= 0 && w <= 1) {
+		return "", 0, fmt.Errorf("invalid weight of %q", item)
+	}
+
+	return item[:i], w, nil
+}
+
+// handClass is hand class by ranks, lo equal to hi is a pair.
+type handClass struct {
+	hi, lo  Rank
+	suited  bool
+	offsuit bool
+}
+
+func (h handClass) combos() []Cards {
+	x, y := 13-int(h.hi), 13-int(h.lo)
+
+	var combos []Cards
+	if h.hi == h.lo || h.suited {
+		combos = append(combos, CardsInCoords(x, y)...)
+	}
+	if h.hi != h.lo && h.offsuit {
+		combos = append(combos, CardsInCoords(y, x)...)
+	}
+	return combos
+}
+
+// parseItem returns combos of single item without weight.
+func parseItem(s string) ([]Cards, error) {
+	if len(s) == 4 && !strings.ContainsAny(s, "+-") {
+		c0, c1 := Parse(s[:2]), Parse(s[2:])
+		if c0 == Card00 || c1 == Card00 || c0 == c1 {
+			return nil, fmt.Errorf("invalid combo %q", s)
+		}
+		return []Cards{{c0, c1}}, nil
+	}
+
+	var from, to handClass
+	var err error
+
+	switch {
+	case strings.HasSuffix(s, "+"):
+		from, err = parseClass(s[:len(s)-1])
+		if err != nil {
+			return nil, err
+		}
+		to = from
+		if from.hi == from.lo {
+			to.hi, to.lo = Ace, Ace
+		} else {
+			to.lo = from.hi - 1
+		}
+
+	case strings.Contains(s, "-"):
+		i := strings.IndexByte(s, '-')
+		from, err = parseClass(s[:i])
+		if err != nil {
+			return nil, err
+		}
+		to, err = parseClass(s[i+1:])
+		if err != nil {
+			return nil, err
+		}
+
+		// Both ends are pairs or neither is, TT-T9 isn't a span.
+		pair := from.hi == from.lo
+		if pair != (to.hi == to.lo) ||
+			!pair && (from.hi != to.hi || from.suited != to.suited || from.offsuit != to.offsuit) {
+			return nil, fmt.Errorf("invalid span %q", s)
+		}
+
+	default:
+		from, err = parseClass(s)
+		if err != nil {
+			return nil, err
+		}
+		to = from
+	}
+
+	var combos []Cards
+
+	if from.hi == from.lo {
+		lo, hi := from.lo, to.lo
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		for r := lo; r <= hi; r++ {
+			combos = append(combos, handClass{hi: r, lo: r}.combos()...)
+		}
+		return combos, nil
+	}
+
+	lo, hi := from.lo, to.lo
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	for r := lo; r <= hi; r++ {
+		h := from
+		h.lo = r
+		combos = append(combos, h.combos()...)
+	}
+
+	return combos, nil
+}
+
+// parseClass parses hand class such as "QQ", "AKs", "AKo" or "AK".
+func parseClass(s string) (handClass, error) {
+	if len(s) != 2 && len(s) != 3 {
+		return handClass{}, fmt.Errorf("invalid hand %q", s)
+	}
+
+	h := handClass{hi: parseRank(s[0]), lo: parseRank(s[1]), suited: true, offsuit: true}
+	if h.hi == NoRank || h.lo == NoRank {
+		return h, fmt.Errorf("invalid hand %q", s)
+	}
+	if h.lo > h.hi {
+		h.hi, h.lo = h.lo, h.hi
+	}
+
+	if len(s) == 3 {
+		switch s[2] {
+		case 's', 'S':
+			h.offsuit = false
+		case 'o', 'O':
+			h.suited = false
+		default:
+			return h, fmt.Errorf("invalid hand %q", s)
+		}
+		if h.hi == h.lo {
+			return h, fmt.Errorf("pair can't be suited or offsuit %q", s)
+		}
+	}
+
+	return h, nil
+}
+
+func parseRank(b byte) Rank {
+	i := strings.IndexByte(ranksStr, strings.ToUpper(string(b))[0])
+	return Rank(i + 1)
+}
+
+// String formats range as range text accepted by
+// NewRangeDistFromString. Classes with the same weight are joined into
+// spans, combos with other weight than most of their class follow
+// them. Weights are rounded to 4 decimals.
+func (r RangeDist) String() string {
+	var items []string
+
+	// Most common weight of class, suited first.
+	var w [14][14][2]float64
+
+	for hi := Ace; hi >= Two; hi-- {
+		for lo := hi; lo >= Two; lo-- {
+			w[hi][lo][0] = r.classWeight(handClass{hi: hi, lo: lo, suited: true})
+			if hi != lo {
+				w[hi][lo][1] = r.classWeight(handClass{hi: hi, lo: lo, offsuit: true})
+			}
+		}
+	}
+
+	// Pairs, spans of equal weight from aces down.
+	for hi := Ace; hi >= Two; {
+		wx := w[hi][hi][0]
+		lo := hi
+		for lo > Two && w[lo-1][lo-1][0] == wx {
+			lo--
+		}
+		if wx > 0 {
+			name := func(r Rank) string { return r.String() + r.String() }
+			switch {
+			case hi == lo:
+				items = append(items, name(hi)+formatWeight(wx))
+			case hi == Ace:
+				items = append(items, name(lo)+"+"+formatWeight(wx))
+			default:
+				items = append(items, name(hi)+"-"+name(lo)+formatWeight(wx))
+			}
+		}
+		hi = lo - 1
+	}
+
+	// Non pairs, spans of kickers of equal weight from the best kicker
+	// down. Suited and offsuit spans covering the same kickers are
+	// joined.
+	for hi := Ace; hi > Two; hi-- {
+		var spans [2][]span
+		for k := range spans {
+			for lo := hi - 1; lo >= Two; {
+				wx := w[hi][lo][k]
+				end := lo
+				for end > Two && w[hi][end-1][k] == wx {
+					end--
+				}
+				if wx > 0 {
+					spans[k] = append(spans[k], span{top: lo, bottom: end, weight: wx})
+				}
+				lo = end - 1
+			}
+		}
+
+		for _, s := range spans[0] {
+			if i := findSpan(spans[1], s); i >= 0 {
+				items = append(items, s.format(hi, ""))
+				spans[1] = append(spans[1][:i], spans[1][i+1:]...)
+				continue
+			}
+			items = append(items, s.format(hi, "s"))
+		}
+		for _, s := range spans[1] {
+			items = append(items, s.format(hi, "o"))
+		}
+	}
+
+	// Combos overriding weight of their class.
+	for hi := Ace; hi >= Two; hi-- {
+		for lo := hi; lo >= Two; lo-- {
+			for k := 0; k < 2; k++ {
+				if hi == lo && k == 1 {
+					continue
+				}
+				h := handClass{hi: hi, lo: lo, suited: k == 0, offsuit: k == 1}
+				for _, c := range h.combos() {
+					if wx := round(r[RangeIndex(c)]); wx != w[hi][lo][k] {
+						items = append(items, ComboName(c)+formatWeight(wx))
+					}
+				}
+			}
+		}
+	}
+
+	return strings.Join(items, ",")
+}
+
+// classWeight returns the most common weight of combos of class, zero
+// wins ties so that fewer combos are listed.
+func (r RangeDist) classWeight(h handClass) float64 {
+	counts := map[float64]int{}
+	for _, c := range h.combos() {
+		counts[round(r[RangeIndex(c)])]++
+	}
+
+	best := 0.
+	for wx, n := range counts {
+		if n > counts[best] || n == counts[best] && (wx == 0 || best != 0 && wx > best) {
+			best = wx
+		}
+	}
+	return best
+}
+
+type span struct {
+	top, bottom Rank
+	weight      float64
+}
+
+func (s span) format(hi Rank, suffix string) string {
+	name := func(lo Rank) string { return hi.String() + lo.String() + suffix }
+	switch {
+	case s.top == s.bottom:
+		return name(s.top) + formatWeight(s.weight)
+	case s.top == hi-1:
+		return name(s.bottom) + "+" + formatWeight(s.weight)
+	default:
+		return name(s.top) + "-" + name(s.bottom) + formatWeight(s.weight)
+	}
+}
+
+func findSpan(spans []span, s span) int {
+	for i, x := range spans {
+		if x == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func round(w float64) float64 {
+	return math.Round(w*10000) / 10000
+}
+
+func formatWeight(w float64) string {
+	if round(w) == 1 {
+		return ""
+	}
+	return ":" + strconv.FormatFloat(round(w), 'f', -1, 64)
+}
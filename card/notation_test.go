@@ -0,0 +1,94 @@
+package card
+
+import (
+	"testing"
+
+	"github.com/pokerdroid/poker/frand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRangeDistFromString(t *testing.T) {
+	r, err := NewRangeDistFromString("QQ, AKs,KQo:0.5 AhKd:0.25")
+	require.NoError(t, err)
+
+	require.InDelta(t, 6+4+12*0.5+0.25, r.Sum(), 1e-9)
+	require.Equal(t, 1., r[RangeIndex(NewCardsFromString("qh qs"))])
+	require.Equal(t, 1., r[RangeIndex(NewCardsFromString("kc ac"))])
+	require.Equal(t, 0., r[RangeIndex(NewCardsFromString("ac kd"))])
+	require.Equal(t, 0.25, r[RangeIndex(NewCardsFromString("kd ah"))])
+	require.Equal(t, 0.5, r[RangeIndex(NewCardsFromString("kh qd"))])
+
+	r, err = NewRangeDistFromString("t9")
+	require.NoError(t, err)
+	require.InDelta(t, 16, r.Sum(), 1e-9)
+
+	// Full frequency is the upper bound.
+	r, err = NewRangeDistFromString("AA@100, KK:1")
+	require.NoError(t, err)
+	require.InDelta(t, 12, r.Sum(), 1e-9)
+
+	for _, bad := range []string{"QQs", "AX", "AKx", "AhAh", "AK:x", "AK:-1", "AA:3", "AA@150", "AA:NaN", "AA@-5", "AKQJ5", "A5s-K2s", "A5s-A2o", "TT-T9", "T9-TT", "Q+", "AhK+"} {
+		_, err = NewRangeDistFromString(bad)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestNewRangeDistFromStringSpans(t *testing.T) {
+	tests := []struct {
+		spec   string
+		combos float64
+	}{
+		{"QQ+", 18},
+		{"TT-77", 24},
+		{"77-TT", 24},
+		{"A2s+", 48},
+		{"A5s-A2s", 16},
+		{"A2s-A5s", 16},
+		{"KTo+", 36},
+		{"K9+", 64},
+		{"22+,A2+,K2+", 78 + 12*16 + 11*16},
+		{"76s@50", 2},
+		{"AA,AA:0", 0},
+	}
+
+	for _, tt := range tests {
+		r, err := NewRangeDistFromString(tt.spec)
+		require.NoError(t, err, tt.spec)
+		require.InDelta(t, tt.combos, r.Sum(), 1e-9, tt.spec)
+	}
+}
+
+func TestRangeDistString(t *testing.T) {
+	tests := []string{
+		"",
+		"AA",
+		"QQ+",
+		"TT-77,55",
+		"QQ+,A2s+,KJo+",
+		"AK,A5s-A2s,KQo:0.5,76s:0.5",
+		"22+,A2+,K2+,Q2+,J2+,T2+,92+,82+,72+,62+,52+,42+,32",
+		"AhKh",
+		"JJ:0.25,AKs,AdKd:0.5",
+	}
+
+	for _, spec := range tests {
+		r, err := NewRangeDistFromString(spec)
+		require.NoError(t, err, spec)
+		require.Equal(t, spec, r.String())
+	}
+
+	// Round trip of random weights.
+	rng := frand.NewUnsafeInt(1)
+	var r RangeDist
+	for i := range r {
+		if rng.Intn(3) == 0 {
+			r[i] = float64(rng.Intn(5)) / 4
+		}
+	}
+
+	x, err := NewRangeDistFromString(r.String())
+	require.NoError(t, err)
+	for i := range r {
+		require.InDelta(t, r[i], x[i], 1e-9)
+	}
+}
@@ -0,0 +1,44 @@
+package card
+
+import "fmt"
+
+// OmahaHand is four hole cards of omaha.
+type OmahaHand [4]Card
+
+// NewOmahaHand creates hand from four distinct cards.
+func NewOmahaHand(cc Cards) (OmahaHand, error) {
+	var h OmahaHand
+	if len(cc) != 4 {
+		return h, fmt.Errorf("omaha hand needs 4 cards: %v", cc)
+	}
+	for i, c := range cc {
+		if c == Card00 || c > CardAS {
+			return h, fmt.Errorf("invalid card: %v", cc)
+		}
+		for _, x := range cc[:i] {
+			if x == c {
+				return h, fmt.Errorf("duplicate card: %v", cc)
+			}
+		}
+		h[i] = c
+	}
+	return h, nil
+}
+
+// Cards returns hand as cards.
+func (h OmahaHand) Cards() Cards {
+	return Cards{h[0], h[1], h[2], h[3]}
+}
+
+// Pairs returns all six two card combinations of the hand, hand is
+// played with exactly one of them.
+func (h OmahaHand) Pairs() [6][2]Card {
+	return [6][2]Card{
+		{h[0], h[1]}, {h[0], h[2]}, {h[0], h[3]},
+		{h[1], h[2]}, {h[1], h[3]}, {h[2], h[3]},
+	}
+}
+
+func (h OmahaHand) String() string {
+	return h.Cards().String()
+}
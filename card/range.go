@@ -90,6 +90,22 @@ func (r RangeDist) Matrix() Matrix {
 	return m.Normalize()
 }
 
+// Frequencies returns mean weight of combos of every hand class,
+// unlike Matrix it isn't normalized.
+func (r RangeDist) Frequencies() Matrix {
+	m := Matrix{}
+	for i := range r {
+		x, y, _ := RangeCoords(i)
+		m[x][y] += r[i]
+	}
+	for x := range m {
+		for y := range m[x] {
+			m[x][y] /= float64(len(CardsInCoords(x, y)))
+		}
+	}
+	return m
+}
+
 func (r RangeDist) Normalize() RangeDist {
 	total := r.Sum()
 	for i := range r {
@@ -109,6 +125,9 @@ func (r RangeDist) Sum() float64 {
 // RangeIndex returns the stable index of a 2-card combination in range [0..1325].
 // Cards are automatically ordered so that if c2 < c1, they are swapped first.
 func RangeIndex(cc Cards) int {
+	if cc[1] < cc[0] {
+		return rindex[[2]Card{cc[1], cc[0]}]
+	}
 	return rindex[[2]Card{cc[0], cc[1]}]
 }
 
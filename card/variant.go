@@ -0,0 +1,123 @@
+package card
+
+import "errors"
+
+// Variant of the game decides deck and order of hand kinds.
+type Variant uint8
+
+const (
+	Holdem Variant = iota
+	// ShortDeck removes deuces to fives. A-6-7-8-9 is the lowest
+	// straight, flush beats full house and three of a kind beats
+	// straight.
+	ShortDeck
+	// ShortDeckStraights is ShortDeck where straight beats three
+	// of a kind.
+	ShortDeckStraights
+	// Omaha deals four hole cards, hand is made of exactly two
+	// of them and three community cards.
+	Omaha
+)
+
+func NewVariantFromString(str string) (Variant, error) {
+	switch str {
+	case "holdem", "":
+		return Holdem, nil
+	case "shortdeck", "6plus":
+		return ShortDeck, nil
+	case "shortdeck-straights":
+		return ShortDeckStraights, nil
+	case "omaha", "plo":
+		return Omaha, nil
+	default:
+		return 0, errors.New("unknown variant")
+	}
+}
+
+func (v Variant) String() string {
+	switch v {
+	case Holdem:
+		return "holdem"
+	case ShortDeck:
+		return "shortdeck"
+	case ShortDeckStraights:
+		return "shortdeck-straights"
+	case Omaha:
+		return "omaha"
+	default:
+		return "unknown"
+	}
+}
+
+// Short returns true for variants played with 36 cards.
+func (v Variant) Short() bool {
+	return v == ShortDeck || v == ShortDeckStraights
+}
+
+// HoleCards returns number of cards dealt to every player.
+func (v Variant) HoleCards() int {
+	if v == Omaha {
+		return 4
+	}
+	return 2
+}
+
+// LowRank is the lowest rank in the deck.
+func (v Variant) LowRank() Rank {
+	if v.Short() {
+		return Six
+	}
+	return Two
+}
+
+// Ranks returns number of ranks in the deck.
+func (v Variant) Ranks() int {
+	return int(Ace-v.LowRank()) + 1
+}
+
+// Size returns number of cards in the deck.
+func (v Variant) Size() int {
+	return v.Ranks() * 4
+}
+
+// Cards returns all cards of the deck except omitted ones.
+func (v Variant) Cards(omit ...Card) Cards {
+	xx := All(omit...)
+	if !v.Short() {
+		return xx
+	}
+
+	short := xx[:0]
+	for _, c := range xx {
+		if c.Rank() >= Six {
+			short = append(short, c)
+		}
+	}
+	return short
+}
+
+// Order returns strength of hand kind in the variant, hands of
+// bigger order win.
+func (v Variant) Order(k HandRankKind) int {
+	if !v.Short() {
+		return int(k)
+	}
+
+	switch k {
+	case HandRankFlush:
+		return int(HandRankFullHouse)
+	case HandRankFullHouse:
+		return int(HandRankFlush)
+	}
+
+	if v == ShortDeck {
+		switch k {
+		case HandRankThreeOfaKind:
+			return int(HandRankStraight)
+		case HandRankStraight:
+			return int(HandRankThreeOfaKind)
+		}
+	}
+
+	return int(k)
+}
@@ -0,0 +1,27 @@
+package card
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariant(t *testing.T) {
+	require.Len(t, Holdem.Cards(), 52)
+	require.Len(t, ShortDeck.Cards(), 36)
+	require.Equal(t, 36, ShortDeck.Size())
+	require.Len(t, ShortDeck.Cards(CardAS, Card2C), 35)
+
+	flush := HandRank{Kind: HandRankFlush, Variant: ShortDeck}
+	full := HandRank{Kind: HandRankFullHouse, Variant: ShortDeck}
+	require.Equal(t, 0, flush.Compare(full))
+
+	flush.Variant, full.Variant = Holdem, Holdem
+	require.Equal(t, 1, flush.Compare(full))
+
+	for _, v := range []Variant{Holdem, ShortDeck, ShortDeckStraights} {
+		x, err := NewVariantFromString(v.String())
+		require.NoError(t, err)
+		require.Equal(t, v, x)
+	}
+}
@@ -23,18 +23,66 @@ type ExploitParams struct {
 	Abs        abs.Mapper
 }
 
+// Evaluation holds per seat results of evaluating average strategies,
+// values are in chips per hand.
+type Evaluation struct {
+	// BR is how much each seat gains by best responding to fixed
+	// average strategies of the others. In heads up their mean is
+	// exploitability, with more players it is only a proxy for it.
+	BR []float64
+	// EV is self-play win rate of each seat when everyone plays
+	// average strategy.
+	EV []float64
+}
+
+// Exploit returns mean of best response gains over seats.
+func (e Evaluation) Exploit() float64 {
+	if len(e.BR) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range e.BR {
+		sum += v
+	}
+	return sum / float64(len(e.BR))
+}
+
+func (e *Evaluation) add(o Evaluation) {
+	if e.BR == nil {
+		e.BR = make([]float64, len(o.BR))
+		e.EV = make([]float64, len(o.EV))
+	}
+	for i := range o.BR {
+		e.BR[i] += o.BR[i]
+		e.EV[i] += o.EV[i]
+	}
+}
+
+func (e *Evaluation) scale(x float64) {
+	for i := range e.BR {
+		e.BR[i] *= x
+		e.EV[i] *= x
+	}
+}
+
 // Exploit concurrently computes the exploitability by dividing the total
 // iterations among several workers. Each worker computes a local best-response
 // value (using BR.Run) and the final exploitability is the weighted average.
 func Exploit(ctx context.Context, p ExploitParams) float64 {
+	return Evaluate(ctx, p).Exploit()
+}
+
+// Evaluate is Exploit reporting best response gain and self-play
+// win rate of every seat.
+func Evaluate(ctx context.Context, p ExploitParams) Evaluation {
 	if p.Workers == 0 {
 		p.Workers = runtime.NumCPU()
 	}
 
 	var wg sync.WaitGroup
+	var mux sync.Mutex
 
-	// We'll accumulate sums of player0 and player1 differences
-	var sum float64
+	var sum Evaluation
 	var total float64
 
 	iters := p.Iterations / uint64(p.Workers)
@@ -51,7 +99,6 @@ func Exploit(ctx context.Context, p ExploitParams) float64 {
 			default:
 			}
 
-			// Calculate BR for player 1
 			local := RunExploitParams{
 				Game:       p.Root,
 				Params:     p.Params,
@@ -61,22 +108,24 @@ func Exploit(ctx context.Context, p ExploitParams) float64 {
 				Rng:        rng,
 			}
 
-			result := RunExploit(local)
+			result := RunEvaluate(local)
 
-			// Atomically accumulate
-			// for a real production code, protect with a sync.Mutex or use atomic.
-			sum += result
+			mux.Lock()
+			sum.add(result)
 			total++
+			mux.Unlock()
 		}(iters, frand.Clone(p.Rng))
 	}
 
 	wg.Wait()
 
 	if total == 0 {
-		return 0
+		return Evaluation{}
 	}
 
-	return sum / total
+	sum.scale(1 / total)
+
+	return sum
 }
 
 type RunExploitParams struct {
@@ -89,14 +138,23 @@ type RunExploitParams struct {
 }
 
 func RunExploit(p RunExploitParams) float64 {
-	np := p.Params.NumPlayers
+	return RunEvaluate(p).Exploit()
+}
 
-	if np != 2 {
-		panic("only 2-player games are supported")
+// RunEvaluate computes best response gain and self-play EV of every
+// seat on sampled deals. Each seat best responds on its own while
+// others keep their average strategies.
+func RunEvaluate(p RunExploitParams) Evaluation {
+	np := int(p.Params.NumPlayers)
+
+	out := Evaluation{
+		BR: make([]float64, np),
+		EV: make([]float64, np),
 	}
 
-	// One accumulator per player
-	var out float64
+	if p.Iterations == 0 {
+		return out
+	}
 
 	brr := &BR{game: p.Game, abs: p.Abs}
 	evr := &EV{game: p.Game, abs: p.Abs}
@@ -107,21 +165,20 @@ func RunExploit(p RunExploitParams) float64 {
 			panic(err)
 		}
 
-		// t1 := uint8(i % 2)
-		// t2 := uint8(1 - t1)
-
-		br0 := brr.Get(p.Rng, sample, 0)
-		ev0 := evr.Get(p.Rng, sample, 0)
+		for pid := 0; pid < np; pid++ {
+			br := brr.Get(p.Rng, sample, uint8(pid))
+			ev := evr.Get(p.Rng, sample, uint8(pid))
 
-		br1 := brr.Get(p.Rng, sample, 1)
-		ev1 := evr.Get(p.Rng, sample, 1)
-
-		out += ((br0 - ev0) + (br1 - ev1)) / 2
+			out.BR[pid] += br - ev
+			out.EV[pid] += ev
+		}
 
 		p.Sampler.Put(sample)
 	}
 
-	return out / float64(p.Iterations)
+	out.scale(1 / float64(p.Iterations))
+
+	return out
 }
 
 type BR struct {
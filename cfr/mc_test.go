@@ -6,12 +6,19 @@ import (
 	"testing"
 
 	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/abs"
+	absp "github.com/pokerdroid/poker/abs/pack"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/chips"
+	holdemdealer "github.com/pokerdroid/poker/dealer/holdem"
 	kuhndealer "github.com/pokerdroid/poker/dealer/kuhn"
 	"github.com/pokerdroid/poker/frand"
 	"github.com/pokerdroid/poker/policy"
 	"github.com/pokerdroid/poker/policy/sampler"
+	"github.com/pokerdroid/poker/table"
 	"github.com/pokerdroid/poker/tree"
 	"github.com/pokerdroid/poker/tree/profiling"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPureMCKuhn(t *testing.T) {
@@ -78,3 +85,157 @@ func TestPureMCKuhn(t *testing.T) {
 
 	t.Log("\n" + buf.String())
 }
+
+func TestMCMultiway(t *testing.T) {
+	prms := table.NewMultiwayGameParams(3, chips.NewFromInt(20))
+	prms.TerminalStreet = table.River
+
+	root, err := tree.NewRoot(prms)
+	require.NoError(t, err)
+
+	r := frand.NewUnsafeInt(0)
+	iso := absp.NewIso()
+	dealer := holdemdealer.New(holdemdealer.SamplerParams{NumPlayers: 3})
+
+	cfrmc := NewMC(MCParams{
+		PS: sampler.NewOutcome(0.4),
+		TS: sampler.NewOutcome(0.2),
+
+		Tree:     root,
+		Discount: policy.CFRP,
+		Abs:      iso,
+		Sampler:  dealer,
+
+		BU: policy.BaselineEMA(0.01),
+	})
+
+	rprms := NewRunParams(root, dealer, iso)
+	rprms.SetBatch(1000, 2)
+	rprms.Workers = 2
+	rprms.SetEpochs(5)
+	rprms.Rng = r
+	rprms.Logger = &poker.TestingLogger{T: t}
+
+	Run(context.Background(), cfrmc, rprms)
+
+	ev := Evaluate(context.Background(), ExploitParams{
+		Root:       root,
+		Params:     root.Params,
+		Sampler:    dealer,
+		Rng:        r,
+		Iterations: 100,
+		Workers:    2,
+		Abs:        iso,
+	})
+
+	require.Len(t, ev.BR, 3)
+	require.Len(t, ev.EV, 3)
+
+	for i := range ev.BR {
+		// Best response never does worse than the average strategy.
+		require.GreaterOrEqual(t, ev.BR[i], -1e-9)
+	}
+
+	t.Logf("br: %v ev: %v exploit: %f", ev.BR, ev.EV, ev.Exploit())
+}
+
+func TestMCShortDeck(t *testing.T) {
+	prms := table.NewGameParams(2, chips.NewFromInt(20))
+	prms.TerminalStreet = table.River
+	prms.Variant = card.ShortDeck
+
+	root, err := tree.NewRoot(prms)
+	require.NoError(t, err)
+
+	r := frand.NewUnsafeInt(0)
+	iso := absp.NewIsoVariant(card.ShortDeck)
+	dealer := holdemdealer.New(holdemdealer.SamplerParams{NumPlayers: 2, Variant: card.ShortDeck})
+
+	cfrmc := NewMC(MCParams{
+		PS: sampler.NewOutcome(0.4),
+		TS: sampler.NewOutcome(0.2),
+
+		Tree:     root,
+		Discount: policy.CFRP,
+		Abs:      iso,
+		Sampler:  dealer,
+
+		BU: policy.BaselineEMA(0.01),
+	})
+
+	rprms := NewRunParams(root, dealer, iso)
+	rprms.SetBatch(1000, 2)
+	rprms.Workers = 2
+	rprms.SetEpochs(3)
+	rprms.Rng = r
+	rprms.Logger = &poker.TestingLogger{T: t}
+
+	Run(context.Background(), cfrmc, rprms)
+
+	ev := Evaluate(context.Background(), ExploitParams{
+		Root:       root,
+		Params:     root.Params,
+		Sampler:    dealer,
+		Rng:        r,
+		Iterations: 100,
+		Workers:    2,
+		Abs:        iso,
+	})
+
+	require.Len(t, ev.BR, 2)
+	for i := range ev.BR {
+		require.GreaterOrEqual(t, ev.BR[i], -1e-9)
+	}
+}
+
+func TestMCOmaha(t *testing.T) {
+	prms := table.NewGameParams(2, chips.NewFromInt(20))
+	prms.TerminalStreet = table.River
+	prms.Variant = card.Omaha
+	prms.Structure = table.PotLimit
+
+	root, err := tree.NewRoot(prms)
+	require.NoError(t, err)
+
+	// Highest card is enough to check hands of 4 cards are dealt.
+	mapper := absp.AbsFn(func(cds card.Cards) abs.Cluster {
+		require.Len(t, cds[:4], 4)
+		return abs.Cluster(max(cds[0], cds[1], cds[2], cds[3]) / 4)
+	})
+
+	r := frand.NewUnsafeInt(0)
+	dealer := holdemdealer.New(holdemdealer.SamplerParams{NumPlayers: 2, Variant: card.Omaha})
+
+	cfrmc := NewMC(MCParams{
+		PS: sampler.NewOutcome(0.4),
+		TS: sampler.NewOutcome(0.2),
+
+		Tree:     root,
+		Discount: policy.CFRP,
+		Abs:      mapper,
+		Sampler:  dealer,
+
+		BU: policy.BaselineEMA(0.01),
+	})
+
+	rprms := NewRunParams(root, dealer, mapper)
+	rprms.SetBatch(1000, 2)
+	rprms.Workers = 2
+	rprms.SetEpochs(3)
+	rprms.Rng = r
+	rprms.Logger = &poker.TestingLogger{T: t}
+
+	Run(context.Background(), cfrmc, rprms)
+
+	ev := Evaluate(context.Background(), ExploitParams{
+		Root:       root,
+		Params:     root.Params,
+		Sampler:    dealer,
+		Rng:        r,
+		Iterations: 100,
+		Workers:    2,
+		Abs:        mapper,
+	})
+
+	require.Len(t, ev.BR, 2)
+}
@@ -89,7 +89,7 @@ func Run(ctx context.Context, c Runner, p RunParams) {
 	epochDone := make(chan struct{}, p.Workers)
 
 	// Per-worker storage for EV and update count.
-	var exploits []float64
+	var exploits []Evaluation
 	var mux sync.Mutex
 
 	evs := make([]float64, p.Workers)
@@ -129,7 +129,7 @@ func Run(ctx context.Context, c Runner, p RunParams) {
 
 	exploit := func(pit uint64, start time.Time, stop bool) {
 		// Run exploitation
-		ev := Exploit(ctx, ExploitParams{
+		ev := Evaluate(ctx, ExploitParams{
 			Root:       p.Game,
 			Iterations: uint64(p.Workers),
 			Params:     p.Game.Params,
@@ -177,11 +177,21 @@ func Run(ctx context.Context, c Runner, p RunParams) {
 		}
 		ravg /= float64(len(revs))
 
-		var exp float64
+		var exp Evaluation
 		for _, ev := range exploits {
-			exp += ev
+			exp.add(ev)
+		}
+		exp.scale(1 / float64(len(exploits)))
+
+		// Self-play win rates in big blinds per hand.
+		bb := p.Game.Params.SbAmount.Mul(2).Float64()
+		if bb == 0 {
+			bb = 1
+		}
+		wrs := make([]float64, len(exp.EV))
+		for i, ev := range exp.EV {
+			wrs[i] = ev / bb
 		}
-		exp /= float64(len(exploits))
 
 		// Record iteration delta and update stats.
 		st := &Stats{Start: start}
@@ -191,7 +201,8 @@ func Run(ctx context.Context, c Runner, p RunParams) {
 		st.Nodes = p.Game.Nodes
 		st.Up = sumup
 		st.EV = ravg
-		st.Exploit = exp
+		st.Exploit = exp.Exploit()
+		st.WinRates = wrs
 		st.Epoch = eps
 
 		// Report current epoch statistics.
@@ -215,6 +226,8 @@ func Run(ctx context.Context, c Runner, p RunParams) {
 
 		LOOP:
 			if p.Game.Iteration > p.Iterations {
+				// Release workers blocked on full epochDone.
+				cancel()
 				return
 			}
 			select {
@@ -139,5 +139,5 @@ func (c *SimpleMC) sampling(node tree.DecisionPoint, t *Task) float64 {
 
 	idx := frand.SampleIndex(t.Rng, px.Strategy, 0.0001)
 
-	return c.runHelper(node.GetNode(idx), node.GetTurnPos(), t)
+	return c.runHelper(node.GetNode(idx), t.TraversingID, t)
 }
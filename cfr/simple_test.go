@@ -6,11 +6,15 @@ import (
 	"testing"
 
 	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/abs"
 	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/dealer"
 	"github.com/pokerdroid/poker/frand"
 	"github.com/pokerdroid/poker/policy"
+	"github.com/pokerdroid/poker/table"
 	"github.com/pokerdroid/poker/tree"
 	"github.com/pokerdroid/poker/tree/profiling"
+	"github.com/stretchr/testify/require"
 
 	kuhndealer "github.com/pokerdroid/poker/dealer/kuhn"
 )
@@ -174,3 +178,63 @@ func TestBestResponse(t *testing.T) {
 	// 6 possible samples, 2 players
 	t.Logf("total exploitability: %f", total/float64(samples)) // 0.055 <- correct
 }
+
+// utilitySpy records players terminals are scored for.
+type utilitySpy struct {
+	sample dealer.Sample
+	pids   []uint8
+}
+
+func (s *utilitySpy) Sample(st table.Street) { s.sample.Sample(st) }
+
+func (s *utilitySpy) Cluster(n dealer.Turner, a abs.Mapper) abs.Cluster {
+	return s.sample.Cluster(n, a)
+}
+
+func (s *utilitySpy) Utility(n *tree.Terminal, pID uint8) float64 {
+	s.pids = append(s.pids, pID)
+	return s.sample.Utility(n, pID)
+}
+
+func TestSimpleUtilityOfTraverser(t *testing.T) {
+	root := tree.NewKuhn()
+
+	r := frand.NewUnsafeInt(0)
+	dl := kuhndealer.NewGameSampler(r)
+
+	cfrmc := NewSimpleMC(SimpleMCParams{
+		Tree:     root,
+		Discount: policy.CFRP,
+		Abs:      kuhndealer.Clusters,
+		BU:       policy.BaselineEMA(0.5),
+	})
+
+	pl := policy.NewUpdatePool(1)
+
+	for i := 0; i < 100; i++ {
+		sample, err := dl.Sample(r)
+		require.NoError(t, err)
+
+		tid := uint8(i % 2)
+		spy := &utilitySpy{sample: sample}
+		update := pl.Alloc()
+
+		cfrmc.runHelper(root, tid, &Task{
+			TraversingID: tid,
+			Sample:       spy,
+			Update:       update,
+			Rng:          r,
+		})
+
+		update.Process(uint64(i+1), policy.CFRP)
+		pl.Free(update)
+		dl.Put(sample)
+
+		// Terminals reached after sampled action of opponent are
+		// scored for traversing player too.
+		require.NotEmpty(t, spy.pids)
+		for _, pid := range spy.pids {
+			require.Equal(t, tid, pid)
+		}
+	}
+}
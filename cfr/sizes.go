@@ -0,0 +1,271 @@
+package cfr
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pokerdroid/poker/table"
+	"github.com/pokerdroid/poker/tree"
+)
+
+// CandidateSizes is a wide set of pot multipliers to search over.
+var CandidateSizes = []float32{0.25, 0.33, 0.5, 0.67, 0.75, 1, 1.25, 1.5, 2, 3, 4, 6}
+
+// CandidateBase is action abstraction used outside of the studied
+// group while training over candidate sizes.
+var CandidateBase = "* r0: 0.5 1 allin; * r1: 1 allin; * r2+: allin"
+
+// CandidateAbs returns action abstraction allowing every candidate
+// size (and all-in) in situations matched by group, sizes of group
+// are ignored. Elsewhere base decides, so only nodes of the group
+// branch into len(sizes)+1 bets instead of every decision on every
+// street. Train a tree with it briefly, use CollectSizeStats, InGroup
+// and SelectSizes to get sizes of the group and study next group with
+// the result followed by base as the new base.
+func CandidateAbs(sizes []float32, group table.BetRule, base table.ActionAbs) table.ActionAbs {
+	group.Sizes = append([]float32{}, sizes...)
+	group.AllIn = true
+	return append(table.ActionAbs{group}, base...)
+}
+
+// InGroup returns stats of situations matched by street and raise
+// count of group.
+func InGroup(stats []SizeStats, group table.BetRule) []SizeStats {
+	var out []SizeStats
+	for _, st := range stats {
+		if group.Street != table.NoStreet && group.Street != st.Street {
+			continue
+		}
+		if st.Raises < group.MinRaises || st.Raises > group.MaxRaises {
+			continue
+		}
+		out = append(out, st)
+	}
+	return out
+}
+
+// SizeStats summarizes how often a bet size is used in a group
+// of situations (street and number of raises).
+type SizeStats struct {
+	Street table.Street
+	// Raises is State.BetAction of nodes in the group. Last group
+	// of a street collects all nodes with Raises or more.
+	Raises uint8
+	// Size is pot multiplier, zero for all-in.
+	Size float32
+	// Reach is share of betting strategy mass given to the size.
+	Reach float64
+	// Regret is average cumulative regret of the size per policy.
+	Regret float64
+	// Policies is number of policies the size was seen in.
+	Policies int
+}
+
+type sizeKey struct {
+	street table.Street
+	raises uint8
+	size   float32
+}
+
+// CollectSizeStats aggregates average strategy and regret of every
+// bet size in the tree. Raise counts from maxRaises up are grouped
+// together.
+func CollectSizeStats(root *tree.Root, maxRaises uint8) []SizeStats {
+	stats := make(map[sizeKey]*SizeStats)
+	totals := make(map[sizeKey]float64)
+
+	tree.MustVisit(root, -1, func(n tree.Node, _ []tree.Node, _ int) bool {
+		p, ok := n.(*tree.Player)
+		if !ok || p.Actions == nil || p.Actions.Policies == nil {
+			return true
+		}
+
+		raises := min(p.State.BetAction, maxRaises)
+
+		for _, pol := range p.Actions.Policies.Map {
+			for i, a := range p.Actions.Actions {
+				if !a.IsRaise() {
+					continue
+				}
+
+				size := float32(a)
+				if a == table.DAllIn {
+					size = 0
+				}
+
+				k := sizeKey{street: p.State.Street, raises: raises, size: size}
+
+				st, ok := stats[k]
+				if !ok {
+					st = &SizeStats{Street: k.street, Raises: k.raises, Size: k.size}
+					stats[k] = st
+				}
+
+				st.Reach += pol.StrategySum[i]
+				st.Regret += pol.RegretSum[i]
+				st.Policies++
+
+				k.size = -1
+				totals[k] += pol.StrategySum[i]
+			}
+		}
+
+		return true
+	})
+
+	list := make([]SizeStats, 0, len(stats))
+	for k, st := range stats {
+		k.size = -1
+		if t := totals[k]; t > 0 {
+			st.Reach /= t
+		}
+		st.Regret /= float64(st.Policies)
+		list = append(list, *st)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.Street != b.Street {
+			return a.Street < b.Street
+		}
+		if a.Raises != b.Raises {
+			return a.Raises < b.Raises
+		}
+		return a.Size < b.Size
+	})
+
+	return list
+}
+
+// SizeSelection decides which sizes survive.
+type SizeSelection struct {
+	// MinReach prunes sizes used less than this share of betting.
+	MinReach float64
+	// MinRegret prunes sizes whose regret relative to the largest
+	// absolute regret of the group is below it, so it is in [-1, 1].
+	// Size with the highest regret of the group is never pruned by
+	// regret.
+	MinRegret float64
+	// MergeRatio merges neighbouring sizes when larger / smaller is
+	// below it. The more used size is kept and takes over the reach.
+	MergeRatio float32
+	// MaxSizes keeps at most this many sizes with the highest regret
+	// per group, zero means no limit.
+	MaxSizes int
+	// MaxRaises must match value passed to CollectSizeStats.
+	MaxRaises uint8
+}
+
+// NewSizeSelection returns SizeSelection with reasonable defaults.
+func NewSizeSelection() SizeSelection {
+	return SizeSelection{
+		MinReach:   0.05,
+		MinRegret:  -0.5,
+		MergeRatio: 1.3,
+		MaxSizes:   3,
+		MaxRaises:  3,
+	}
+}
+
+// SelectSizes merges close sizes, prunes rarely used sizes and sizes
+// with negative regret, keeps sizes with the highest regret and returns
+// action abstraction with one rule per street and raise count group.
+// All-in is always allowed.
+func SelectSizes(stats []SizeStats, p SizeSelection) table.ActionAbs {
+	type group struct {
+		street table.Street
+		raises uint8
+	}
+
+	var order []group
+	groups := make(map[group][]SizeStats)
+
+	for _, st := range stats {
+		g := group{street: st.Street, raises: st.Raises}
+		if _, ok := groups[g]; !ok {
+			order = append(order, g)
+			groups[g] = nil
+		}
+		// All-in is not a candidate.
+		if st.Size > 0 {
+			groups[g] = append(groups[g], st)
+		}
+	}
+
+	var a table.ActionAbs
+
+	for _, g := range order {
+		sizes := mergeSizes(groups[g], p.MergeRatio)
+
+		// Highest regret first, then prune and cap.
+		sort.SliceStable(sizes, func(i, j int) bool {
+			if sizes[i].Regret != sizes[j].Regret {
+				return sizes[i].Regret > sizes[j].Regret
+			}
+			return sizes[i].Reach > sizes[j].Reach
+		})
+
+		var scale float64
+		for _, st := range sizes {
+			scale = max(scale, math.Abs(st.Regret))
+		}
+
+		var keep []float32
+		for i, st := range sizes {
+			if st.Reach < p.MinReach {
+				continue
+			}
+			if i > 0 && scale > 0 && st.Regret/scale < p.MinRegret {
+				continue
+			}
+			if p.MaxSizes > 0 && len(keep) >= p.MaxSizes {
+				break
+			}
+			keep = append(keep, st.Size)
+		}
+
+		sort.Slice(keep, func(i, j int) bool { return keep[i] < keep[j] })
+
+		rule := table.BetRule{
+			Street:    g.street,
+			MinRaises: g.raises,
+			MaxRaises: g.raises,
+			Sizes:     keep,
+			AllIn:     true,
+		}
+		if g.raises == p.MaxRaises {
+			rule.MaxRaises = table.AnyRaises
+		}
+
+		a = append(a, rule)
+	}
+
+	return a
+}
+
+// mergeSizes expects sizes sorted ascending. Merged size takes over
+// reach of both and regret of the more used one.
+func mergeSizes(sizes []SizeStats, ratio float32) []SizeStats {
+	var out []SizeStats
+
+	for _, st := range sizes {
+		if len(out) == 0 {
+			out = append(out, st)
+			continue
+		}
+
+		last := &out[len(out)-1]
+		if st.Size/last.Size >= ratio {
+			out = append(out, st)
+			continue
+		}
+
+		reach := last.Reach + st.Reach
+		if st.Reach > last.Reach {
+			*last = st
+		}
+		last.Reach = reach
+	}
+
+	return out
+}
@@ -0,0 +1,103 @@
+package cfr
+
+import (
+	"testing"
+
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/chips"
+	"github.com/pokerdroid/poker/table"
+	"github.com/pokerdroid/poker/tree"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectSizes(t *testing.T) {
+	p := table.NewGameParams(2, chips.NewFromInt(200))
+	p.TerminalStreet = table.Preflop
+	base, err := table.ParseActionAbs(CandidateBase)
+	require.NoError(t, err)
+
+	group := table.BetRule{Street: table.Preflop}
+	p.ActionAbs = CandidateAbs([]float32{1, 1.1, 2, 4}, group, base)
+
+	root, err := tree.NewRoot(p)
+	require.NoError(t, err)
+	require.NoError(t, tree.ExpandFull(root))
+
+	player := root.Next.(*tree.Chance).Next.(*tree.Player)
+	acts := player.Actions
+
+	sum := func(a table.DiscreteAction, pol []float64, v float64) {
+		i := acts.GetIdx(a)
+		require.GreaterOrEqual(t, i, 0, a.String())
+		pol[i] = v
+	}
+
+	// 1 and 1.1 are close and get merged into 1.1, 4 is rarely used.
+	for cl := abs.Cluster(0); cl < 2; cl++ {
+		pol := player.Acquire(root, cl)
+		sum(table.DiscreteAction(1), pol.StrategySum, 1)
+		sum(table.DiscreteAction(1.1), pol.StrategySum, 2)
+		sum(table.DiscreteAction(2), pol.StrategySum, 6.5)
+		sum(table.DiscreteAction(4), pol.StrategySum, 0.2)
+		sum(table.DAllIn, pol.StrategySum, 0.3)
+		sum(table.DAllIn, pol.RegretSum, -4)
+		pol.Unlock()
+	}
+
+	stats := CollectSizeStats(root, 3)
+
+	var found bool
+	for _, st := range stats {
+		if st.Street == table.Preflop && st.Raises == 0 && st.Size == 2 {
+			found = true
+			require.InDelta(t, 0.65, st.Reach, 1e-9)
+			require.Equal(t, 2, st.Policies)
+		}
+		if st.Street == table.Preflop && st.Raises == 0 && st.Size == 0 {
+			require.Equal(t, -4., st.Regret)
+		}
+	}
+	require.True(t, found)
+
+	sel := NewSizeSelection()
+	sel.MaxRaises = 3
+
+	a := SelectSizes(InGroup(stats, group), sel)
+	require.Len(t, a, 1)
+	require.Equal(t, "preflop r0: 1.1 2 allin", a[0].String())
+
+	// Base sizes are used outside of the group.
+	reraise := player.Actions.Nodes[acts.GetIdx(table.DiscreteAction(2))].(*tree.Player)
+	require.Less(t, reraise.Actions.GetIdx(table.DiscreteAction(1.1)), 0)
+	require.Less(t, reraise.Actions.GetIdx(table.DiscreteAction(4)), 0)
+
+	// Output is a valid spec for cfr train.
+	parsed, err := table.ParseActionAbs(a.String())
+	require.NoError(t, err)
+	require.Equal(t, a, parsed)
+}
+
+func TestSelectSizesRegret(t *testing.T) {
+	stats := []SizeStats{
+		{Street: table.Flop, Size: 0.5, Reach: 0.1, Regret: 3},
+		{Street: table.Flop, Size: 1, Reach: 0.3, Regret: 2},
+		// Most used size keeps losing against the others.
+		{Street: table.Flop, Size: 2, Reach: 0.6, Regret: -10},
+	}
+
+	sel := NewSizeSelection()
+
+	a := SelectSizes(stats, sel)
+	require.Equal(t, "flop r0: 0.5 1 allin", a.String())
+
+	// Sizes with the highest regret are kept first.
+	sel.MaxSizes = 1
+	a = SelectSizes(stats, sel)
+	require.Equal(t, "flop r0: 0.5 allin", a.String())
+
+	// Pruning by regret can be disabled.
+	sel.MaxSizes = 0
+	sel.MinRegret = -1
+	a = SelectSizes(stats, sel)
+	require.Equal(t, "flop r0: 0.5 1 2 allin", a.String())
+}
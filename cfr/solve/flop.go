@@ -0,0 +1,26 @@
+package solve
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pokerdroid/poker/table"
+)
+
+// FlopParams describes heads up subgame starting at the flop.
+type FlopParams = Params
+
+// Flop builds local abstraction of the board and solves heads up
+// subgame from the flop with given ranges.
+func Flop(ctx context.Context, p FlopParams) (*Result, error) {
+	if len(p.Board) != 3 {
+		return nil, errors.New("board must have 3 cards")
+	}
+	return Solve(ctx, p)
+}
+
+// FlopState returns heads up state at the flop with pot and effective
+// stack in big blinds, button raised preflop and big blind called.
+func FlopState(pot, stack float64) (*table.State, table.GameParams, error) {
+	return NewState(pot, stack, table.Flop)
+}
@@ -0,0 +1,53 @@
+package solve
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pokerdroid/poker/abs/local"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/chips"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/table"
+	"github.com/pokerdroid/poker/tree"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlopState(t *testing.T) {
+	for _, pot := range []float64{2, 6.5} {
+		state, params, err := FlopState(pot, 40)
+		require.NoError(t, err)
+		require.Equal(t, table.Flop, state.Street)
+
+		paid := state.Players[0].Paid.Add(state.Players[1].Paid)
+		require.Equal(t, chips.NewFromFloat(pot).Mul(2), paid)
+		require.Equal(t, chips.NewFromFloat(pot/2+40).Mul(2), params.InitialStacks[0])
+	}
+}
+
+func TestFlop(t *testing.T) {
+	r, err := Flop(context.Background(), FlopParams{
+		Board:      card.NewCardsFromString("ah 7d 2c"),
+		Ranges:     [2]card.RangeDist{card.NewUniformRangeDist(), card.NewUniformRangeDist()},
+		Pot:        6,
+		Stack:      20,
+		Iterations: 20_000,
+		Workers:    2,
+		Rng:        frand.NewUnsafeInt(1),
+		Abs: local.Opts{
+			FlopClusters:  10,
+			TurnClusters:  10,
+			Bins:          10,
+			MaxIterations: 10,
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, r.Root.CheckAbs(r.Abs))
+	require.Greater(t, r.Root.Iteration, uint64(0))
+
+	ch, ok := r.Root.Next.(*tree.Chance)
+	require.True(t, ok)
+	p, ok := ch.Next.(*tree.Player)
+	require.True(t, ok)
+	require.Equal(t, uint8(1), p.TurnPos)
+}
@@ -0,0 +1,232 @@
+package solve
+
+import (
+	"context"
+	"errors"
+	"runtime"
+
+	"github.com/pokerdroid/poker"
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/abs/local"
+	absp "github.com/pokerdroid/poker/abs/pack"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/cfr"
+	"github.com/pokerdroid/poker/chips"
+	holdemdealer "github.com/pokerdroid/poker/dealer/holdem"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/policy"
+	"github.com/pokerdroid/poker/table"
+	"github.com/pokerdroid/poker/tree"
+)
+
+// DefaultActions is action abstraction of postflop subgames.
+var DefaultActions = "* r0: 0.33 0.75; * r1: 1 allin; * r2+: allin"
+
+// Params describes heads up subgame starting at the flop, turn or
+// river.
+type Params struct {
+	// Board has 3, 4 or 5 cards.
+	Board card.Cards
+	// Ranges of button and big blind, weights don't need to be
+	// normalized.
+	Ranges [2]card.RangeDist
+	// Pot and effective Stack behind in big blinds.
+	Pot   float64
+	Stack float64
+	// Actions is action abstraction of the subgame.
+	Actions    table.ActionAbs
+	MaxActions uint8
+	// Abs controls local abstraction of flop subgames, turn and
+	// river subgames are lossless.
+	Abs        local.Opts
+	Iterations uint64
+	Workers    int
+	Rng        frand.Rand
+	Logger     poker.Logger
+}
+
+func (p Params) Validate() error {
+	if len(p.Board) < 3 || len(p.Board) > 5 {
+		return errors.New("board must have 3, 4 or 5 cards")
+	}
+	if len(card.All(p.Board...)) != 52-len(p.Board) {
+		return errors.New("board has invalid or duplicate cards")
+	}
+	if p.Pot < 2 {
+		return errors.New("pot must be at least 2bb")
+	}
+	if p.Stack <= 0 {
+		return errors.New("stack must be positive")
+	}
+	if p.Iterations == 0 {
+		return errors.New("iterations must be positive")
+	}
+	// Combos blocked by the board are dropped by the dealer, they
+	// don't count here.
+	for _, r := range p.Ranges {
+		var sum float64
+		for i, w := range r {
+			if !card.IsAnyMatch(card.RangeCards(i), p.Board) {
+				sum += w
+			}
+		}
+		if sum <= 0 {
+			return errors.New("range is empty")
+		}
+	}
+	if len(p.Board) == 3 {
+		return p.Abs.Validate()
+	}
+	return nil
+}
+
+// Result is solved subgame with abstraction it was trained with.
+type Result struct {
+	Root *tree.Root
+	Abs  abs.Mapper
+}
+
+// Solve solves heads up subgame from the board with given ranges.
+// Flop is solved with local abstraction of the board, turn and river
+// with lossless isomorphic abstraction.
+func Solve(ctx context.Context, p Params) (*Result, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	if p.Logger == nil {
+		p.Logger = poker.VoidLogger{}
+	}
+
+	if p.Rng == nil {
+		p.Rng = frand.NewHash()
+	}
+
+	if p.Workers <= 0 {
+		p.Workers = runtime.NumCPU()
+	}
+
+	if p.MaxActions == 0 {
+		p.MaxActions = 4
+	}
+
+	if p.Actions == nil {
+		p.Actions, _ = table.ParseActionAbs(DefaultActions)
+	}
+
+	var a abs.Mapper = absp.NewIso()
+
+	if len(p.Board) == 3 {
+		p.Logger.Printf("building abstraction of %s", p.Board)
+
+		if p.Abs.Logger == nil {
+			p.Abs.Logger = p.Logger
+		}
+		if p.Abs.Rng == nil {
+			p.Abs.Rng = p.Rng
+		}
+
+		la, err := local.Build(p.Board, p.Abs)
+		if err != nil {
+			return nil, err
+		}
+		a = la
+	}
+
+	street := [...]table.Street{table.Flop, table.Turn, table.River}[len(p.Board)-3]
+
+	state, params, err := NewState(p.Pot, p.Stack, street)
+	if err != nil {
+		return nil, err
+	}
+	params.ActionAbs = p.Actions
+	params.MaxActionsPerRound = p.MaxActions
+
+	root := &tree.Root{
+		Params: params,
+		State:  state,
+	}
+
+	if x, ok := a.(abs.Identified); ok {
+		root.AbsID = x.ID()
+	}
+
+	err = tree.ExpandFull(root)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Logger.Printf("tree has %d nodes and %d states", root.Nodes, root.States)
+
+	dealer := holdemdealer.NewWeighted(holdemdealer.RangeParams{
+		NumPlayers: 2,
+		Board:      p.Board,
+		Clusters:   a,
+		Ranges:     p.Ranges[:],
+	})
+
+	mc := cfr.NewSimpleMC(cfr.SimpleMCParams{
+		Tree:     root,
+		Abs:      a,
+		Discount: policy.CFRP,
+		BU:       policy.BaselineEMA(0.25),
+	})
+
+	rp := cfr.NewRunParams(root, dealer, a)
+	rp.Workers = p.Workers
+	rp.SetBatch(1000, uint64(p.Workers))
+	rp.Iterations = p.Iterations
+	rp.Logger = p.Logger
+	rp.Rng = p.Rng
+
+	cfr.Run(ctx, mc, rp)
+
+	return &Result{Root: root, Abs: a}, nil
+}
+
+// NewState returns heads up state at the start of street with pot and
+// effective stack in big blinds. Button raised preflop, big blind
+// called and streets before were checked through.
+func NewState(pot, stack float64, street table.Street) (*table.State, table.GameParams, error) {
+	bb := chips.NewFromFloat(pot/2 + stack).Mul(2)
+
+	params := table.GameParams{
+		NumPlayers:         2,
+		MaxActionsPerRound: 4,
+		SbAmount:           chips.NewFromInt(1),
+		BetSizes:           [][]float32{{1}},
+		InitialStacks:      chips.List{bb, bb},
+		TerminalStreet:     table.River,
+		DisableV:           true,
+	}
+
+	game, err := table.NewGame(params)
+	if err != nil {
+		return nil, params, err
+	}
+
+	actions := []table.Actioner{table.DCall, table.DCheck}
+	if pot != 2 {
+		actions = []table.Actioner{
+			table.ActionAmount{Action: table.Raise, Amount: chips.NewFromFloat(pot - 1)},
+			table.DCall,
+		}
+	}
+
+	for st := table.Flop; st < street; st++ {
+		actions = append(actions, table.DCheck, table.DCheck)
+	}
+
+	for _, a := range actions {
+		err = game.Action(a)
+		if err != nil {
+			return nil, params, err
+		}
+	}
+
+	if game.Latest.Street != street {
+		return nil, params, errors.New("failed to reach the street")
+	}
+
+	return game.Latest, params, nil
+}
@@ -0,0 +1,95 @@
+package solve
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/chips"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/table"
+	"github.com/pokerdroid/poker/tree/export"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewState(t *testing.T) {
+	for _, street := range []table.Street{table.Flop, table.Turn, table.River} {
+		for _, pot := range []float64{2, 6.5} {
+			state, params, err := NewState(pot, 40, street)
+			require.NoError(t, err)
+			require.Equal(t, street, state.Street)
+
+			paid := state.Players[0].Paid.Add(state.Players[1].Paid)
+			require.Equal(t, chips.NewFromFloat(pot).Mul(2), paid)
+			require.Equal(t, chips.NewFromFloat(pot/2+40).Mul(2), params.InitialStacks[0])
+		}
+	}
+}
+
+func TestSolveRiver(t *testing.T) {
+	board := card.NewCardsFromString("ah 7d 2c 9s 4h")
+
+	// Button has nuts or air, big blind bluff catchers only.
+	ip, err := card.NewRangeDistFromString("AA, 65s, KQo")
+	require.NoError(t, err)
+	oop, err := card.NewRangeDistFromString("AJo")
+	require.NoError(t, err)
+
+	actions, err := table.ParseActionAbs("* r0: 1; * r1+: allin")
+	require.NoError(t, err)
+
+	r, err := Solve(context.Background(), Params{
+		Board:      board,
+		Ranges:     [2]card.RangeDist{ip, oop},
+		Pot:        10,
+		Stack:      10,
+		Actions:    actions,
+		Iterations: 50_000,
+		Workers:    2,
+		Rng:        frand.NewUnsafeInt(1),
+	})
+	require.NoError(t, err)
+	require.NoError(t, r.Root.CheckAbs(r.Abs))
+
+	nodes, err := export.New(export.Params{Tree: r.Root, Abs: r.Abs, Depth: 3, Board: board})
+	require.NoError(t, err)
+
+	// After check button value bets and mostly gives up with air.
+	var checked *export.Node
+	for i, n := range nodes {
+		if n.Path == "r:n:k:p" {
+			checked = &nodes[i]
+		}
+	}
+	require.NotNil(t, checked)
+
+	for _, h := range checked.Hands {
+		switch h.Hand {
+		case "AdAc":
+			require.Greater(t, h.Strategy[1], 0.8)
+		case "KdQc", "6c5c":
+			require.Less(t, h.Strategy[1], 0.5)
+		}
+	}
+
+	_, err = Solve(context.Background(), Params{
+		Board:      board,
+		Ranges:     [2]card.RangeDist{ip, {}},
+		Pot:        10,
+		Stack:      10,
+		Iterations: 1,
+	})
+	require.Error(t, err)
+
+	// Range blocked by the board is empty.
+	blocked, err := card.NewRangeDistFromString("AhAs")
+	require.NoError(t, err)
+	_, err = Solve(context.Background(), Params{
+		Board:      board,
+		Ranges:     [2]card.RangeDist{ip, blocked},
+		Pot:        10,
+		Stack:      10,
+		Iterations: 1,
+	})
+	require.Error(t, err)
+}
@@ -19,6 +19,8 @@ type Stats struct {
 	EV float64
 	// Exploit is the average exploit.
 	Exploit float64
+	// WinRates are self-play win rates of seats in big blinds per hand.
+	WinRates []float64
 	// States is the current number of states in the game.
 	States uint32
 	// Nodes is the current number of nodes in the game.
@@ -32,7 +34,15 @@ func (s *Stats) String() string {
 	now := time.Now()
 	diff := now.Sub(s.Start).Seconds()
 
-	return fmt.Sprintf("ep: %-6d | it: %-10d | it/s: %-7d | up/s: %-7d | sts: %-7d | nodes: %-7d | exp: %.8f | ev: %.9f\n",
+	var wr string
+	for i, w := range s.WinRates {
+		if i > 0 {
+			wr += " "
+		}
+		wr += fmt.Sprintf("%+.4f", w)
+	}
+
+	return fmt.Sprintf("ep: %-6d | it: %-10d | it/s: %-7d | up/s: %-7d | sts: %-7d | nodes: %-7d | exp: %.8f | ev: %.9f | wr: %s\n",
 		s.Epoch,
 		s.TotIt,
 		uint32(float64(s.It)/diff),
@@ -41,5 +51,6 @@ func (s *Stats) String() string {
 		s.Nodes,
 		s.Exploit,
 		s.EV,
+		wr,
 	)
 }
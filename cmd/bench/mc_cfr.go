@@ -21,6 +21,8 @@ type benchMcCFRArgs struct {
 
 	rounds  uint64
 	workers int
+
+	ignoreAbs bool
 }
 
 var pmccfr = benchMcCFRArgs{}
@@ -30,6 +32,7 @@ func init() {
 
 	flags.StringVar(&pmccfr.tree, "tree", "", "path to the tree")
 	flags.StringVar(&pmccfr.abs, "abs", "", "path to the abstraction")
+	flags.BoolVar(&pmccfr.ignoreAbs, "ignore-abs", false, "use solutions trained with different abstraction")
 	flags.Uint64Var(&pmccfr.rounds, "rounds", 100_000, "how many rounds to run")
 	flags.IntVar(&pmccfr.workers, "workers", runtime.NumCPU(), "worker for each instance")
 }
@@ -62,6 +65,13 @@ var mcCFR_CMD = &cobra.Command{
 			log.Fatal(err)
 		}
 
+		if !pmccfr.ignoreAbs {
+			err = game.CheckAbs(abs)
+			if err != nil {
+				log.Fatalf("%s, use --ignore-abs to run anyway", err)
+			}
+		}
+
 		rng := frand.NewHash()
 
 		advisors := []bot.Advisor{
@@ -24,6 +24,8 @@ type benchSlumbotCFRArgs struct {
 	rounds  uint64
 	workers int
 	search  bool
+
+	ignoreAbs bool
 }
 
 var pscfr = benchSlumbotCFRArgs{}
@@ -33,6 +35,7 @@ func init() {
 
 	flags.StringVar(&pscfr.tree, "tree", "", "path to the tree")
 	flags.StringVar(&pscfr.abs, "abs", "", "path to the abstraction")
+	flags.BoolVar(&pscfr.ignoreAbs, "ignore-abs", false, "use solutions trained with different abstraction")
 
 	flags.BoolVar(&pscfr.search, "search", false, "use search")
 	flags.StringVar(&pscfr.river, "river", "", "path to the river abstraction")
@@ -70,6 +73,13 @@ var slumbotCFR_CMD = &cobra.Command{
 			log.Fatal(err)
 		}
 
+		if !pscfr.ignoreAbs {
+			err = game.CheckAbs(abs)
+			if err != nil {
+				log.Fatalf("%s, use --ignore-abs to run anyway", err)
+			}
+		}
+
 		rng := frand.NewHash()
 
 		logger.Print("starting benchmark")
@@ -9,6 +9,10 @@ func init() {
 	CMD.AddCommand(exploitCMD)
 	CMD.AddCommand(analyzeCMD)
 	CMD.AddCommand(testCMD)
+	CMD.AddCommand(exportCMD)
+	CMD.AddCommand(sizesCMD)
+	CMD.AddCommand(flopCMD)
+	CMD.AddCommand(solveCMD)
 }
 
 var CMD = &cobra.Command{
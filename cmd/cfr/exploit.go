@@ -7,6 +7,7 @@ import (
 
 	absp "github.com/pokerdroid/poker/abs/pack"
 	"github.com/pokerdroid/poker/cfr"
+	pdealer "github.com/pokerdroid/poker/dealer"
 	holdemdealer "github.com/pokerdroid/poker/dealer/holdem"
 	"github.com/pokerdroid/poker/frand"
 	"github.com/pokerdroid/poker/tree"
@@ -17,6 +18,10 @@ type exploitArgs struct {
 	tree       string
 	abs        string
 	iterations uint64
+	ignoreAbs  bool
+	payouts    []float64
+	stacks     []float64
+	others     []float64
 }
 
 var ef = exploitArgs{}
@@ -28,6 +33,10 @@ func init() {
 	flags.StringVar(&ef.abs, "abs", "", "path to the abstraction")
 
 	flags.Uint64Var(&ef.iterations, "iterations", 100_000, "how many iterations to run")
+	flags.BoolVar(&ef.ignoreAbs, "ignore-abs", false, "use solutions trained with different abstraction")
+	flags.Float64SliceVar(&ef.payouts, "payouts", nil, "tournament payouts for 1st, 2nd, ... place, uses ICM instead of chip EV")
+	flags.Float64SliceVar(&ef.stacks, "stacks", nil, "tournament stacks of seats in big blinds for ICM (default tree stacks)")
+	flags.Float64SliceVar(&ef.others, "others", nil, "tournament stacks in big blinds of players not in the hand for ICM")
 
 	cobra.MarkFlagRequired(flags, "db")
 	cobra.MarkFlagRequired(flags, "tree")
@@ -55,12 +64,28 @@ var exploitCMD = &cobra.Command{
 			log.Fatal(err)
 		}
 
+		if !ef.ignoreAbs {
+			err = game.CheckAbs(abs)
+			if err != nil {
+				log.Fatalf("%s, use --ignore-abs to run anyway", err)
+			}
+		}
+
 		logger.Print("running exploit")
 
 		params := holdemdealer.SamplerParams{
 			NumPlayers: game.Params.NumPlayers,
 		}
 
+		if len(ef.payouts) > 0 {
+			stacks, others, err := icmStacks(game.Params, ef.stacks, ef.others)
+			if err != nil {
+				log.Fatal(err)
+			}
+			logger.Printf("icm payouts: %v stacks: %v others: %v", ef.payouts, stacks, others)
+			params.Utility = pdealer.NewICM(stacks, others, ef.payouts)
+		}
+
 		exploit := cfr.Exploit(ctx, cfr.ExploitParams{
 			Root:       game,
 			Params:     game.Params,
@@ -0,0 +1,118 @@
+package cmdcfr
+
+import (
+	"io"
+	"log"
+	"os"
+
+	absp "github.com/pokerdroid/poker/abs/pack"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/tree"
+	"github.com/pokerdroid/poker/tree/export"
+	"github.com/spf13/cobra"
+)
+
+type exportArgs struct {
+	tree    string
+	abs     string
+	depth   int
+	board   string
+	classes bool
+	reach   bool
+	format  string
+	output  string
+
+	ignoreAbs bool
+}
+
+var xf = exportArgs{}
+
+func init() {
+	flags := exportCMD.Flags()
+
+	flags.StringVar(&xf.tree, "tree", "", "path to the tree")
+	flags.StringVar(&xf.abs, "abs", "", "path to the abstraction")
+	flags.IntVar(&xf.depth, "depth", 2, "max depth of the tree to export")
+	flags.StringVar(&xf.board, "board", "", "board cards, e.g. \"ah kd 2c\"")
+	flags.BoolVar(&xf.classes, "classes", false, "aggregate combos into 169 hand classes")
+	flags.BoolVar(&xf.reach, "reach", false, "add reach of nodes and weights of hands to json")
+	flags.StringVar(&xf.format, "format", "json", "output format: json, csv or ranges")
+	flags.StringVar(&xf.output, "output", "", "output path (default stdout)")
+	flags.BoolVar(&xf.ignoreAbs, "ignore-abs", false, "use solutions trained with different abstraction")
+
+	cobra.MarkFlagRequired(flags, "tree")
+	cobra.MarkFlagRequired(flags, "abs")
+}
+
+var exportCMD = &cobra.Command{
+	Use:   "export",
+	Short: "will export strategies to json, csv or range text",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := log.Default()
+
+		write := export.WriteJSON
+		switch xf.format {
+		case "json":
+		case "csv":
+			write = export.WriteCSV
+		case "ranges":
+			write = export.WriteRanges
+		default:
+			logger.Fatalf("unknown format: %s", xf.format)
+		}
+
+		logger.Print("loading abstraction")
+
+		abs, err := absp.NewFromFile(xf.abs)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		logger.Print("loading tree")
+
+		game, err := tree.NewFromFile(xf.tree)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		if !xf.ignoreAbs {
+			err = game.CheckAbs(abs)
+			if err != nil {
+				logger.Fatalf("%s, use --ignore-abs to export anyway", err)
+			}
+		}
+
+		logger.Print("exporting")
+
+		nodes, err := export.New(export.Params{
+			Tree:    game,
+			Abs:     abs,
+			Depth:   xf.depth,
+			Board:   card.NewCardsFromString(xf.board),
+			Classes: xf.classes,
+			Reach:   xf.reach,
+		})
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		var w io.Writer = os.Stdout
+
+		if xf.output != "" {
+			f, err := os.Create(xf.output)
+			if err != nil {
+				logger.Fatal(err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		err = write(w, nodes)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		logger.Printf("exported %d nodes", len(nodes))
+	},
+}
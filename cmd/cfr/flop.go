@@ -0,0 +1,188 @@
+package cmdcfr
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+
+	"github.com/pokerdroid/poker/abs/local"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/cfr/solve"
+	"github.com/pokerdroid/poker/table"
+	"github.com/pokerdroid/poker/tree/export"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type spotArgs struct {
+	board      string
+	ip         string
+	oop        string
+	pot        float64
+	stack      float64
+	actions    string
+	maxactions uint8
+	iterations uint64
+	workers    int
+
+	flopClusters  int
+	turnClusters  int
+	bins          int
+	maxIterations int
+
+	depth  int
+	format string
+	output string
+}
+
+var ff = spotArgs{}
+
+func init() {
+	spotFlags(flopCMD.Flags(), &ff, "flop")
+}
+
+// spotFlags binds flags of single spot solving commands to a.
+func spotFlags(flags *pflag.FlagSet, a *spotArgs, output string) {
+	flags.StringVar(&a.board, "board", "", "board cards, e.g. \"ah kd 2c\"")
+	flags.StringVar(&a.ip, "ip", "", "range of button, range text or file with range text or 13x13 matrix (default all hands)")
+	flags.StringVar(&a.oop, "oop", "", "range of big blind, range text or file with range text or 13x13 matrix (default all hands)")
+	flags.Float64Var(&a.pot, "pot", 6, "pot in big blinds")
+	flags.Float64Var(&a.stack, "stack", 97, "effective stack behind in big blinds")
+	flags.StringVar(&a.actions, "actions", solve.DefaultActions, "action abstraction spec or file with it (see table.ActionAbs)")
+	flags.Uint8Var(&a.maxactions, "maxactions", 4, "max actions per round")
+	flags.Uint64Var(&a.iterations, "iterations", 10_000_000, "number of iterations")
+	flags.IntVar(&a.workers, "workers", runtime.NumCPU(), "number of workers")
+
+	flags.IntVar(&a.flopClusters, "flop-clusters", 200, "number of flop clusters")
+	flags.IntVar(&a.turnClusters, "turn-clusters", 200, "number of turn clusters for every turn card")
+	flags.IntVar(&a.bins, "bins", 20, "number of bins of equity histogram")
+	flags.IntVar(&a.maxIterations, "max-iterations", 50, "max iterations of k-means")
+
+	flags.IntVar(&a.depth, "depth", 4, "max depth of the tree to export")
+	flags.StringVar(&a.format, "format", "json", "output format: json, csv or ranges")
+	flags.StringVar(&a.output, "output", output, "output directory")
+
+	cobra.MarkFlagRequired(flags, "board")
+}
+
+var flopCMD = &cobra.Command{
+	Use:   "flop",
+	Short: "will solve single flop from given ranges",
+
+	Run: runSpot(&ff, solve.Flop),
+}
+
+// runSpot returns command solving spot given by a with fn and writing
+// the solution to the output directory.
+func runSpot(a *spotArgs, fn func(context.Context, solve.Params) (*solve.Result, error)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer cancel()
+
+		logger := log.Default()
+
+		write, ext := export.WriteJSON, "json"
+		switch a.format {
+		case "json":
+		case "csv":
+			write, ext = export.WriteCSV, "csv"
+		case "ranges":
+			write, ext = export.WriteRanges, "txt"
+		default:
+			logger.Fatalf("unknown format: %s", a.format)
+		}
+
+		var ranges [2]card.RangeDist
+		for i, s := range []string{a.ip, a.oop} {
+			var err error
+			ranges[i], err = readRange(s)
+			if err != nil {
+				logger.Fatal(err)
+			}
+		}
+
+		spec, err := readSpec(a.actions)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		actions, err := table.ParseActionAbs(spec)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		err = os.MkdirAll(a.output, 0755)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		board := card.NewCardsFromString(a.board)
+
+		r, err := fn(ctx, solve.Params{
+			Board:      board,
+			Ranges:     ranges,
+			Pot:        a.pot,
+			Stack:      a.stack,
+			Actions:    actions,
+			MaxActions: a.maxactions,
+			Iterations: a.iterations,
+			Workers:    a.workers,
+			Logger:     logger,
+			Abs: local.Opts{
+				FlopClusters:  a.flopClusters,
+				TurnClusters:  a.turnClusters,
+				Bins:          a.bins,
+				MaxIterations: a.maxIterations,
+			},
+		})
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		logger.Printf("saving solution to %s", a.output)
+
+		if la, ok := r.Abs.(*local.Abs); ok {
+			err = la.WriteFile(filepath.Join(a.output, "local.bin"))
+			if err != nil {
+				logger.Fatal(err)
+			}
+		}
+
+		f, err := os.Create(filepath.Join(a.output, "tree.bin"))
+		if err != nil {
+			logger.Fatal(err)
+		}
+		defer f.Close()
+
+		err = r.Root.WriteBinary(f)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		nodes, err := export.New(export.Params{
+			Tree:  r.Root,
+			Abs:   r.Abs,
+			Depth: a.depth,
+			Board: board,
+		})
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		o, err := os.Create(filepath.Join(a.output, "strategy."+ext))
+		if err != nil {
+			logger.Fatal(err)
+		}
+		defer o.Close()
+
+		err = write(o, nodes)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		logger.Printf("exported %d nodes", len(nodes))
+	}
+}
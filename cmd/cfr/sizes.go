@@ -0,0 +1,191 @@
+package cmdcfr
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/pokerdroid/poker/abs"
+	absp "github.com/pokerdroid/poker/abs/pack"
+	"github.com/pokerdroid/poker/cfr"
+	"github.com/pokerdroid/poker/chips"
+	holdemdealer "github.com/pokerdroid/poker/dealer/holdem"
+	"github.com/pokerdroid/poker/policy"
+	"github.com/pokerdroid/poker/policy/sampler"
+	"github.com/pokerdroid/poker/table"
+	"github.com/pokerdroid/poker/tree"
+	"github.com/spf13/cobra"
+)
+
+type sizesArgs struct {
+	abs string
+
+	batch   uint64
+	workers int
+	epochs  uint64
+
+	depth      int
+	maxactions int
+	candidates string
+	group      string
+	base       string
+
+	minReach  float64
+	minRegret float64
+	merge     float64
+	max       int
+	raises    int
+
+	output string
+}
+
+var sf = sizesArgs{}
+
+func init() {
+	flags := sizesCMD.Flags()
+	flags.StringVar(&sf.abs, "abs", "", "path to the abstraction")
+	cobra.MarkFlagRequired(flags, "abs")
+
+	flags.IntVar(&sf.workers, "workers", runtime.NumCPU(), "number of workers")
+	flags.Uint64Var(&sf.batch, "batch", 20000, "batch size of each worker")
+	flags.Uint64Var(&sf.epochs, "epochs", 50, "how many epochs to train")
+
+	flags.IntVar(&sf.depth, "depth", 100, "effective stack of players (default 100bb)")
+	flags.IntVar(&sf.maxactions, "maxactions", 12, "max actions per round")
+
+	var sizes []string
+	for _, s := range cfr.CandidateSizes {
+		sizes = append(sizes, strconv.FormatFloat(float64(s), 'f', -1, 32))
+	}
+	flags.StringVar(&sf.candidates, "candidates", strings.Join(sizes, ","), "candidate pot multipliers")
+	flags.StringVar(&sf.group, "group", "flop r0", "street and raise count selector of situations to study, e.g. \"turn r1\"")
+	flags.StringVar(&sf.base, "base", cfr.CandidateBase, "action abstraction spec or file with it used outside of the group")
+
+	sel := cfr.NewSizeSelection()
+	flags.Float64Var(&sf.minReach, "minreach", sel.MinReach, "prune sizes used less than this share of bets")
+	flags.Float64Var(&sf.minRegret, "minregret", sel.MinRegret, "prune sizes with regret relative to the largest regret of the group below this")
+	flags.Float64Var(&sf.merge, "merge", float64(sel.MergeRatio), "merge sizes closer than this ratio")
+	flags.IntVar(&sf.max, "max", sel.MaxSizes, "max sizes per street and raise count")
+	flags.IntVar(&sf.raises, "raises", int(sel.MaxRaises), "raise counts from this up share a rule")
+
+	flags.StringVar(&sf.output, "output", "", "path to write action abstraction spec (default stdout)")
+}
+
+var sizesCMD = &cobra.Command{
+	Use:   "sizes",
+	Short: "will recommend bet sizes by training over candidate sizes",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer cancel()
+
+		logger := log.Default()
+
+		var candidates []float32
+		for _, s := range strings.Split(sf.candidates, ",") {
+			f, err := strconv.ParseFloat(strings.TrimSpace(s), 32)
+			if err != nil {
+				logger.Fatalf("invalid candidate %q: %s", s, err)
+			}
+			candidates = append(candidates, float32(f))
+		}
+
+		group, err := table.ParseActionAbs(sf.group + ": allin")
+		if err != nil || len(group) != 1 {
+			logger.Fatalf("invalid group %q: %v", sf.group, err)
+		}
+
+		spec, err := readSpec(sf.base)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		base, err := table.ParseActionAbs(spec)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		logger.Printf("loading abstraction")
+
+		var abs abs.Mapper
+		abs, err = absp.NewFromFile(sf.abs)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		prms := table.NewGameParams(2, chips.NewFromInt(int64(sf.depth)*2))
+		prms.SbAmount = chips.NewFromFloat(1)
+		prms.TerminalStreet = table.River
+		prms.MaxActionsPerRound = uint8(sf.maxactions)
+		prms.DisableV = true
+		prms.ActionAbs = cfr.CandidateAbs(candidates, group[0], base)
+
+		game, err := tree.NewRoot(prms)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		logger.Printf("%s", game.Params.String())
+
+		dealer := holdemdealer.New(holdemdealer.SamplerParams{
+			NumPlayers: game.Params.NumPlayers,
+			Terminal:   table.River,
+		})
+
+		algo := cfr.NewMC(cfr.MCParams{
+			PS: sampler.NewOutcome(0.4),
+			TS: sampler.NewOutcome(0.2),
+
+			Tree:     game,
+			Discount: policy.CFRD(1.5, 0.5, 2),
+			Abs:      abs,
+			Sampler:  dealer,
+			BU:       policy.BaselineEMA(0.01),
+		})
+
+		rprms := cfr.NewRunParams(game, dealer, abs)
+		rprms.Logger = logger
+		rprms.Workers = sf.workers
+		rprms.SetBatch(sf.batch, uint64(sf.workers))
+		rprms.SetEpochs(sf.epochs)
+
+		logger.Printf("training over %d candidate sizes at %s", len(candidates), sf.group)
+		cfr.Run(ctx, algo, rprms)
+
+		sel := cfr.SizeSelection{
+			MinReach:   sf.minReach,
+			MinRegret:  sf.minRegret,
+			MergeRatio: float32(sf.merge),
+			MaxSizes:   sf.max,
+			MaxRaises:  uint8(sf.raises),
+		}
+
+		stats := cfr.InGroup(cfr.CollectSizeStats(game, sel.MaxRaises), group[0])
+		for _, st := range stats {
+			size := "allin"
+			if st.Size > 0 {
+				size = strconv.FormatFloat(float64(st.Size), 'f', -1, 32)
+			}
+			logger.Printf("%-7s r%d %-5s reach: %.4f regret: %.4f policies: %d",
+				st.Street, st.Raises, size, st.Reach, st.Regret, st.Policies)
+		}
+
+		// Selected sizes of the group take precedence over base.
+		out := append(cfr.SelectSizes(stats, sel), base...).String() + "\n"
+
+		if sf.output == "" {
+			os.Stdout.WriteString(out)
+			return
+		}
+
+		err = os.WriteFile(sf.output, []byte(out), 0644)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		logger.Printf("use with: cfr train --actions %s", sf.output)
+	},
+}
@@ -0,0 +1,54 @@
+package cmdcfr
+
+import (
+	"os"
+
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/cfr/solve"
+	"github.com/spf13/cobra"
+)
+
+var vf = spotArgs{}
+
+func init() {
+	spotFlags(solveCMD.Flags(), &vf, "spot")
+}
+
+var solveCMD = &cobra.Command{
+	Use:   "solve",
+	Short: "will solve single postflop spot of 3 to 5 board cards from given ranges",
+
+	Run: runSpot(&vf, solve.Solve),
+}
+
+// readSpec returns content of file s or s itself when there is no
+// such file.
+func readSpec(s string) (string, error) {
+	if _, err := os.Stat(s); err != nil {
+		return s, nil
+	}
+	data, err := os.ReadFile(s)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// readRange parses range text or 13x13 matrix, empty range has all
+// hands.
+func readRange(s string) (card.RangeDist, error) {
+	if s == "" {
+		return card.NewUniformRangeDist(), nil
+	}
+
+	spec, err := readSpec(s)
+	if err != nil {
+		return card.RangeDist{}, err
+	}
+
+	if m, err := card.NewMatrixFromString(spec); err == nil {
+		return card.NewRangeDist(m), nil
+	}
+
+	return card.NewRangeDistFromString(spec)
+}
@@ -2,6 +2,7 @@ package cmdcfr
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -11,10 +12,12 @@ import (
 	"runtime/pprof"
 
 	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/abs/omaha"
 	absp "github.com/pokerdroid/poker/abs/pack"
 	"github.com/pokerdroid/poker/card"
 	"github.com/pokerdroid/poker/cfr"
 	"github.com/pokerdroid/poker/chips"
+	pdealer "github.com/pokerdroid/poker/dealer"
 	holdemdealer "github.com/pokerdroid/poker/dealer/holdem"
 	"github.com/pokerdroid/poker/policy"
 	"github.com/pokerdroid/poker/policy/sampler"
@@ -25,7 +28,8 @@ import (
 )
 
 type traingArgs struct {
-	abs string
+	abs       string
+	ignoreAbs bool
 
 	batch   uint64
 	workers int
@@ -38,6 +42,15 @@ type traingArgs struct {
 	maxactions int
 	limp       bool
 	minBet     bool
+	actions    string
+	ante       float64
+	bbante     float64
+	structure  string
+	payouts    []float64
+	stacks     []float64
+	others     []float64
+	variant    string
+	absCache   int
 
 	cpupprof string
 	memprof  string
@@ -47,8 +60,8 @@ var tf = traingArgs{}
 
 func init() {
 	flags := trainCMD.Flags()
-	flags.StringVar(&tf.abs, "abs", "", "path to the abstraction")
-	cobra.MarkFlagRequired(flags, "abs")
+	flags.StringVar(&tf.abs, "abs", "", "path to the abstraction, short deck trains lossless without it")
+	flags.BoolVar(&tf.ignoreAbs, "ignore-abs", false, "continue tree trained with different abstraction")
 
 	batch := uint64(200000)
 	workers := runtime.NumCPU() * 4
@@ -64,11 +77,20 @@ func init() {
 
 	// Or we generate a new tree
 	flags.IntVar(&tf.depth, "depth", 100, "effective stack of players (default 100bb)")
-	flags.IntVar(&tf.players, "players", 2, "number of players")
+	flags.IntVar(&tf.players, "players", 2, "number of players, 2 to 6")
 	flags.IntVar(&tf.maxactions, "maxactions", 12, "max actions per round")
 
 	flags.BoolVar(&tf.limp, "limp", false, "use limp")
 	flags.BoolVar(&tf.minBet, "minbet", false, "use min bet")
+	flags.StringVar(&tf.actions, "actions", "", "path to action abstraction spec (see table.ActionAbs)")
+	flags.Float64Var(&tf.ante, "ante", 0, "ante posted by every player in big blinds")
+	flags.Float64Var(&tf.bbante, "bbante", 0, "big blind ante in big blinds")
+	flags.StringVar(&tf.structure, "structure", "nl", "betting structure: nl, pl or fl")
+	flags.Float64SliceVar(&tf.payouts, "payouts", nil, "tournament payouts for 1st, 2nd, ... place, uses ICM instead of chip EV")
+	flags.Float64SliceVar(&tf.stacks, "stacks", nil, "tournament stacks of seats in big blinds for ICM (default tree stacks)")
+	flags.Float64SliceVar(&tf.others, "others", nil, "tournament stacks in big blinds of players not in the hand for ICM")
+	flags.StringVar(&tf.variant, "variant", "holdem", "game variant: holdem, shortdeck, shortdeck-straights or omaha")
+	flags.IntVar(&tf.absCache, "abs-cache", omaha.DefaultCacheSize, "slots of omaha postflop cluster cache per street")
 
 	flags.StringVar(&tf.cpupprof, "cpuprof", "", "cpu profile path")
 	flags.StringVar(&tf.memprof, "memprof", "", "memory profile path")
@@ -91,6 +113,11 @@ var trainCMD = &cobra.Command{
 			logger.Fatal(err)
 		}
 
+		variant, err := card.NewVariantFromString(tf.variant)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
 		logger.Printf("loading abstraction")
 
 		var abs abs.Mapper
@@ -98,7 +125,17 @@ var trainCMD = &cobra.Command{
 		abs = absp.NewIso()
 
 		if tf.abs != "" {
-			abs, err = absp.NewFromFile(tf.abs)
+			// Omaha hands are clustered by their own abstraction.
+			if variant == card.Omaha {
+				var oa *omaha.Abs
+				oa, err = omaha.NewFromFile(tf.abs)
+				if err == nil && tf.absCache != omaha.DefaultCacheSize {
+					oa.EnableCache(tf.absCache)
+				}
+				abs = oa
+			} else {
+				abs, err = absp.NewFromFile(tf.abs)
+			}
 		}
 		if err != nil {
 			logger.Fatal(err)
@@ -110,17 +147,57 @@ var trainCMD = &cobra.Command{
 			logger.Printf("loading tree")
 			game, err = tree.NewFromFile(tf.tree)
 		} else {
-			prms := table.NewGameParams(uint8(tf.players), chips.NewFromInt(int64(tf.depth)*2))
+			if tf.players < 2 || tf.players > 6 {
+				logger.Fatal("players must be between 2 and 6")
+			}
+
+			stack := chips.NewFromInt(int64(tf.depth) * 2)
+			prms := table.NewGameParams(uint8(tf.players), stack)
+			prms.MaxActionsPerRound = uint8(tf.maxactions)
+
+			// Multiway trees use smaller action abstraction.
+			if tf.players > 2 {
+				prms = table.NewMultiwayGameParams(uint8(tf.players), stack)
+				if cmd.Flags().Changed("maxactions") {
+					prms.MaxActionsPerRound = uint8(tf.maxactions)
+				}
+			}
+
 			prms.Limp = tf.limp
 			prms.SbAmount = chips.NewFromFloat(1)
 			prms.TerminalStreet = table.River
-			prms.MaxActionsPerRound = uint8(tf.maxactions)
 			prms.MinBet = tf.minBet
+			prms.Ante = chips.NewFromFloat(tf.ante * 2)
+			prms.BBAnte = chips.NewFromFloat(tf.bbante * 2)
+			structure := tf.structure
+			if variant == card.Omaha && !cmd.Flags().Changed("structure") {
+				structure = "pl"
+			}
+			prms.Structure, err = table.NewBettingStructureFromString(structure)
+			if err != nil {
+				logger.Fatal(err)
+			}
+			prms.Variant = variant
 			prms.DisableV = true
 			prms.SetBetSizes()
+
+			if tf.actions != "" {
+				spec, err := os.ReadFile(tf.actions)
+				if err != nil {
+					logger.Fatal(err)
+				}
+				prms.ActionAbs, err = table.ParseActionAbs(string(spec))
+				if err != nil {
+					logger.Fatal(err)
+				}
+			}
+
 			game, err = tree.NewRoot(prms)
 
-			if gm, ok := abs.(*absp.Abs); ok {
+			switch gm := abs.(type) {
+			case *absp.Abs:
+				game.AbsID = gm.UID
+			case *omaha.Abs:
 				game.AbsID = gm.UID
 			}
 		}
@@ -129,14 +206,47 @@ var trainCMD = &cobra.Command{
 			logger.Fatal(err)
 		}
 
+		// Bucketed abstractions are built for 52 card deck.
+		if game.Params.Variant.Short() {
+			if tf.abs != "" {
+				logger.Fatal("short deck trains with lossless abstraction, leave --abs empty")
+			}
+			abs = absp.NewIsoVariant(game.Params.Variant)
+		} else if tf.abs == "" {
+			logger.Fatal("--abs is required")
+		}
+
+		if _, ok := abs.(*omaha.Abs); ok != (game.Params.Variant == card.Omaha) {
+			logger.Fatal("omaha trains with abstraction built by clustering omaha")
+		}
+
+		if tf.tree != "" && !tf.ignoreAbs {
+			err = game.CheckAbs(abs)
+			if err != nil {
+				logger.Fatalf("%s, use --ignore-abs to continue anyway", err)
+			}
+		}
+
 		logger.Printf("experiment: %s", args[0])
 		logger.Printf("abs: %s", game.AbsID.String())
 		logger.Printf("%s", game.Params.String())
 
-		dealer := holdemdealer.New(holdemdealer.SamplerParams{
+		sprms := holdemdealer.SamplerParams{
 			NumPlayers: game.Params.NumPlayers,
 			Terminal:   table.River,
-		})
+			Variant:    game.Params.Variant,
+		}
+
+		if len(tf.payouts) > 0 {
+			stacks, others, err := icmStacks(game.Params, tf.stacks, tf.others)
+			if err != nil {
+				logger.Fatal(err)
+			}
+			logger.Printf("icm payouts: %v stacks: %v others: %v", tf.payouts, stacks, others)
+			sprms.Utility = pdealer.NewICM(stacks, others, tf.payouts)
+		}
+
+		dealer := holdemdealer.New(sprms)
 
 		algo := cfr.NewMC(cfr.MCParams{
 			PS: sampler.NewOutcome(0.4),
@@ -220,6 +330,11 @@ var trainCMD = &cobra.Command{
 				logger.Fatal(err)
 			}
 
+			if oa, ok := abs.(*omaha.Abs); ok {
+				hits, misses := oa.CacheStats()
+				logger.Printf("omaha cache hit rate: %.3f of %d", float64(hits)/float64(max(hits+misses, 1)), hits+misses)
+			}
+
 			// Save the tree
 			logger.Printf("saving policies")
 
@@ -307,3 +422,29 @@ var trainCMD = &cobra.Command{
 		logger.Printf("done")
 	},
 }
+
+// icmStacks scales tournament stacks in big blinds to tree chips. Seats
+// without stacks given play tournament of tree stacks.
+func icmStacks(prms table.GameParams, stacks, others []float64) (chips.List, chips.List, error) {
+	if len(stacks) == 0 {
+		if len(others) > 0 {
+			return nil, nil, errors.New("--others needs --stacks of the seats")
+		}
+		return prms.InitialStacks, nil, nil
+	}
+
+	if len(stacks) != int(prms.NumPlayers) {
+		return nil, nil, fmt.Errorf("--stacks has %d stacks for %d players", len(stacks), prms.NumPlayers)
+	}
+
+	bb := prms.SbAmount.Mul(2)
+	scale := func(bbs []float64) chips.List {
+		l := chips.NewListAlloc(len(bbs))
+		for i, s := range bbs {
+			l[i] = chips.NewFromFloat(s).Mul(bb)
+		}
+		return l
+	}
+
+	return scale(stacks), scale(others), nil
+}
@@ -0,0 +1,46 @@
+package cmdclus
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/pokerdroid/poker/abs/pipeline"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	flags := buildCMD.Flags()
+
+	flags.String("config", "abs.json", "path to build config")
+}
+
+var buildCMD = &cobra.Command{
+	Use:   "build",
+	Short: "build packed abstraction from config, skipping stages that are up to date",
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		logger := log.Default()
+
+		path, err := flags.GetString("config")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		cfg, err := pipeline.NewConfigFromFile(path)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		m, err := pipeline.Build(ctx, cfg, logger)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		logger.Printf("abstraction %s built", m.UID)
+	},
+}
@@ -8,6 +8,9 @@ func init() {
 	CMD.AddCommand(turnCMD)
 	CMD.AddCommand(flopCMD)
 	CMD.AddCommand(packCMD)
+	CMD.AddCommand(omahaCMD)
+	CMD.AddCommand(inspectCMD)
+	CMD.AddCommand(buildCMD)
 }
 
 var CMD = &cobra.Command{
@@ -2,10 +2,11 @@ package cmdclus
 
 import (
 	"log"
-	"os"
 
+	"github.com/pokerdroid/poker/abs"
 	"github.com/pokerdroid/poker/abs/flop"
 	"github.com/pokerdroid/poker/abs/river"
+	"github.com/pokerdroid/poker/abs/turn"
 	"github.com/pokerdroid/poker/frand"
 	"github.com/spf13/cobra"
 )
@@ -17,8 +18,15 @@ func init() {
 	flags.Int("clusters", 10000, "number of clusters")
 	flags.Int("bins", 20, "number of bins")
 	flags.Int("maxiter", 5000, "max iterations")
+	flags.Int("batch", 0, "mini-batch size, 0 runs full k-means")
+	flags.Bool("random-seed", false, "seed centers by random points instead of k-means++")
+	flags.Int("seed-sample", 0, "seed k-means++ from sample of that many points, 0 uses all")
 	flags.String("equities", "equities.bin", "path to river equities")
-	cobra.MarkFlagRequired(flags, "equities")
+
+	flags.Bool("potential", false, "cluster by distribution over turn clusters instead of equity histograms")
+	flags.String("turn", "turn.bin", "path to turn abstraction, used with --potential")
+	flags.String("river", "river.bin", "path to river abstraction, used with --potential")
+	flags.Int("turn-samples", 2_000_000, "number of turn hands to estimate turn cluster means, 0 for all")
 }
 
 var flopCMD = &cobra.Command{
@@ -38,12 +46,64 @@ var flopCMD = &cobra.Command{
 			logger.Fatal(err)
 		}
 
-		buckets, err := flags.GetString("equities")
+		maxiter, err := flags.GetInt("maxiter")
 		if err != nil {
 			logger.Fatal(err)
 		}
 
-		maxiter, err := flags.GetInt("maxiter")
+		potential, err := flags.GetBool("potential")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		if potential {
+			turnPath, err := flags.GetString("turn")
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			riverPath, err := flags.GetString("river")
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			samples, err := flags.GetInt("turn-samples")
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			tabs, err := turn.NewFromFile(turnPath)
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			rabs, err := river.NewFromFile(riverPath)
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			logger.Printf("partitioning flop potentials")
+
+			abs, err := flop.PartitionPotential(flop.PotentialOpts{
+				Turn:           tabs,
+				River:          rabs,
+				Clusters:       clusters,
+				MaxIterations:  maxiter,
+				DeltaThreshold: 0.0000001,
+				TurnSamples:    samples,
+				Logger:         logger,
+				LogIteration:   100_000,
+				Rng:            frand.NewUnsafeInt(42),
+			})
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			writeAbs(logger, output, abs)
+			return
+		}
+
+		buckets, err := flags.GetString("equities")
 		if err != nil {
 			logger.Fatal(err)
 		}
@@ -58,6 +118,23 @@ var flopCMD = &cobra.Command{
 			logger.Fatal(err)
 		}
 
+		batch, err := flags.GetInt("batch")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		randomSeed, err := flags.GetBool("random-seed")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		seedSample, err := flags.GetInt("seed-sample")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		seeding := abs.Seeding{Random: randomSeed, Sample: seedSample}
+
 		logger.Printf("computing flop histograms")
 
 		hh, err := flop.Compute(flop.ComputeOpts{
@@ -80,19 +157,13 @@ var flopCMD = &cobra.Command{
 			LogIteration:   100_000,
 			DeltaThreshold: 0.0000001,
 			Bins:           bins,
+			BatchSize:      batch,
+			Seeding:        seeding,
 		})
 		if err != nil {
 			logger.Fatal(err)
 		}
 
-		bb, err := abs.MarshalBinary()
-		if err != nil {
-			logger.Fatal(err)
-		}
-
-		err = os.WriteFile(output, bb, 0644)
-		if err != nil {
-			logger.Fatal(err)
-		}
+		writeAbs(logger, output, abs)
 	},
 }
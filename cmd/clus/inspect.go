@@ -0,0 +1,94 @@
+package cmdclus
+
+import (
+	"fmt"
+	"log"
+
+	absp "github.com/pokerdroid/poker/abs/pack"
+	"github.com/pokerdroid/poker/abs/river"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	flags := inspectCMD.Flags()
+
+	flags.String("abs", "", "path to packed abstraction")
+	cobra.MarkFlagRequired(flags, "abs")
+
+	flags.String("equities", "", "path to equities buckets, enables equity and EMD statistics")
+	flags.Int("bins", 20, "number of histogram bins")
+	flags.Int("samples", 200_000, "number of hands evaluated per street, 0 for all")
+	flags.Int("examples", 0, "number of sample hands listed per cluster")
+	flags.Float64("tiny", 0.01, "share of average cluster size below which cluster is tiny")
+}
+
+var inspectCMD = &cobra.Command{
+	Use:   "inspect",
+	Short: "report quality of packed abstraction",
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		logger := log.Default()
+
+		path, err := flags.GetString("abs")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		equities, err := flags.GetString("equities")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		bins, err := flags.GetInt("bins")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		samples, err := flags.GetInt("samples")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		examples, err := flags.GetInt("examples")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		tiny, err := flags.GetFloat64("tiny")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		a, err := absp.NewFromFile(path)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		opts := absp.InspectOpts{
+			Bins:     bins,
+			Samples:  samples,
+			Examples: examples,
+			Tiny:     tiny,
+			Rng:      frand.NewUnsafeInt(42),
+			Logger:   logger,
+		}
+
+		if equities != "" {
+			opts.Buckets, err = river.NewBucketsFromFile(equities, logger)
+			if err != nil {
+				logger.Fatal(err)
+			}
+		}
+
+		reports, err := absp.Inspect(a, opts)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		fmt.Printf("abs: %s\n", a.UID)
+		for _, r := range reports {
+			fmt.Print(r.String())
+		}
+	},
+}
@@ -0,0 +1,94 @@
+package cmdclus
+
+import (
+	"log"
+
+	"github.com/pokerdroid/poker/abs/omaha"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	flags := omahaCMD.Flags()
+
+	flags.String("output", "omaha.bin", "path to omaha abstraction")
+	flags.IntSlice("clusters", []int{1000, 5000, 5000, 5000}, "number of preflop, flop, turn and river clusters")
+	flags.Int("bins", 20, "number of bins")
+	flags.Int("runouts", 64, "number of sampled runouts per hand")
+	flags.Int("opponents", 8, "number of random opponents per runout")
+	flags.Int("samples", 1_000_000, "number of sampled hands per postflop street")
+	flags.Int("maxiter", 5000, "max iterations")
+}
+
+var omahaCMD = &cobra.Command{
+	Use:   "omaha",
+	Short: "build omaha abstraction",
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		logger := log.Default()
+
+		output, err := flags.GetString("output")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		clusters, err := flags.GetIntSlice("clusters")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		if len(clusters) != 4 {
+			logger.Fatal("clusters must list preflop, flop, turn and river")
+		}
+
+		bins, err := flags.GetInt("bins")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		runouts, err := flags.GetInt("runouts")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		opponents, err := flags.GetInt("opponents")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		samples, err := flags.GetInt("samples")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		maxiter, err := flags.GetInt("maxiter")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		prms := omaha.BuildParams{
+			HistParams: omaha.HistParams{
+				Bins:      bins,
+				Runouts:   runouts,
+				Opponents: opponents,
+			},
+			Samples:       samples,
+			MaxIterations: maxiter,
+			Rng:           frand.NewUnsafeInt(42),
+			Logger:        logger,
+		}
+		copy(prms.Clusters[:], clusters)
+
+		a, err := omaha.Build(prms)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		err = a.WriteFile(output)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		logger.Printf("omaha abstraction %s written to %s", a.UID, output)
+	},
+}
@@ -25,6 +25,8 @@ func init() {
 
 	flags.String("river", "river.bin", "path to river abstraction")
 	cobra.MarkFlagRequired(flags, "river")
+
+	flags.String("method", "", "description of street clustering recorded in header")
 }
 
 var packCMD = &cobra.Command{
@@ -54,13 +56,19 @@ var packCMD = &cobra.Command{
 			logger.Fatal(err)
 		}
 
+		method, err := flags.GetString("method")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
 		logger.Printf("packing abstraction")
 
 		abx := absp.Abs{
-			UID:   uuid.New(),
-			Flop:  &flop.Abs{},
-			Turn:  &turn.Abs{},
-			River: &river.Abs{},
+			UID:    uuid.New(),
+			Method: method,
+			Flop:   &flop.Abs{},
+			Turn:   &turn.Abs{},
+			River:  &river.Abs{},
 		}
 
 		// read flop abstraction
@@ -110,5 +118,7 @@ var packCMD = &cobra.Command{
 		if err != nil {
 			logger.Fatal(err)
 		}
+
+		logger.Printf("%s written: %s", output, abx.Header())
 	},
 }
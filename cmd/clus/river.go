@@ -2,7 +2,6 @@ package cmdclus
 
 import (
 	"log"
-	"os"
 
 	"github.com/pokerdroid/poker/abs/river"
 	"github.com/pokerdroid/poker/frand"
@@ -16,7 +15,10 @@ func init() {
 	flags.Int("clusters", 10000, "number of clusters")
 
 	flags.String("equities", "equities.bin", "path to equities buckets")
-	cobra.MarkFlagRequired(flags, "equities")
+
+	flags.Int("ochs", 0, "cluster by equity against N preflop clusters of opponent hands (OCHS)")
+	flags.Int("samples", 5_000_000, "number of river hands clustered with --ochs, 0 for all")
+	flags.Int("maxiter", 5000, "max iterations")
 }
 
 var riverCMD = &cobra.Command{
@@ -36,6 +38,42 @@ var riverCMD = &cobra.Command{
 			logger.Fatal(err)
 		}
 
+		maxiter, err := flags.GetInt("maxiter")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		ochs, err := flags.GetInt("ochs")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		if ochs > 0 {
+			samples, err := flags.GetInt("samples")
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			logger.Printf("computing river ochs abstraction")
+
+			abs, err := river.PartitionOCHS(river.OCHSOpts{
+				Opponents:      ochs,
+				Clusters:       clusters,
+				MaxIterations:  maxiter,
+				DeltaThreshold: 0.0000001,
+				Samples:        samples,
+				Logger:         logger,
+				LogIteration:   1_000_000,
+				Rng:            frand.NewUnsafeInt(42),
+			})
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			writeAbs(logger, output, abs)
+			return
+		}
+
 		buckets, err := flags.GetString("equities")
 		if err != nil {
 			logger.Fatal(err)
@@ -52,7 +90,7 @@ var riverCMD = &cobra.Command{
 			Clusters:       clusters,
 			Logger:         logger,
 			Rng:            frand.NewUnsafeInt(42),
-			MaxIterations:  5000,
+			MaxIterations:  maxiter,
 			LogIteration:   10_000,
 			DeltaThreshold: 0.0000001,
 		})
@@ -60,14 +98,6 @@ var riverCMD = &cobra.Command{
 			logger.Fatal(err)
 		}
 
-		bb, err := hh.MarshalBinary()
-		if err != nil {
-			logger.Fatal(err)
-		}
-
-		err = os.WriteFile(output, bb, 0644)
-		if err != nil {
-			logger.Fatal(err)
-		}
+		writeAbs(logger, output, hh)
 	},
 }
@@ -1,9 +1,11 @@
 package cmdclus
 
 import (
+	"encoding"
 	"log"
 	"os"
 
+	"github.com/pokerdroid/poker/abs"
 	"github.com/pokerdroid/poker/abs/river"
 	"github.com/pokerdroid/poker/abs/turn"
 	"github.com/pokerdroid/poker/frand"
@@ -17,9 +19,15 @@ func init() {
 	flags.Int("clusters", 10000, "number of clusters")
 	flags.Int("bins", 20, "number of bins")
 	flags.Int("maxiter", 5000, "max iterations")
+	flags.Int("batch", 0, "mini-batch size, 0 runs full k-means")
+	flags.Bool("random-seed", false, "seed centers by random points instead of k-means++")
+	flags.Int("seed-sample", 0, "seed k-means++ from sample of that many points, 0 uses all")
 
 	flags.String("equities", "equities.bin", "path to equities buckets")
-	cobra.MarkFlagRequired(flags, "equities")
+
+	flags.Bool("potential", false, "cluster by distribution over river clusters instead of equity histograms")
+	flags.String("river", "river.bin", "path to river abstraction, used with --potential")
+	flags.Int("samples", 2_000_000, "number of turn hands clustered with --potential, 0 for all")
 }
 
 var turnCMD = &cobra.Command{
@@ -39,12 +47,53 @@ var turnCMD = &cobra.Command{
 			logger.Fatal(err)
 		}
 
-		buckets, err := flags.GetString("equities")
+		maxiter, err := flags.GetInt("maxiter")
 		if err != nil {
 			logger.Fatal(err)
 		}
 
-		maxiter, err := flags.GetInt("maxiter")
+		potential, err := flags.GetBool("potential")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		if potential {
+			riverPath, err := flags.GetString("river")
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			samples, err := flags.GetInt("samples")
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			rabs, err := river.NewFromFile(riverPath)
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			logger.Printf("partitioning turn potentials")
+
+			abs, err := turn.PartitionPotential(turn.PotentialOpts{
+				River:          rabs,
+				Clusters:       clusters,
+				MaxIterations:  maxiter,
+				DeltaThreshold: 0.0000001,
+				Samples:        samples,
+				Logger:         logger,
+				LogIteration:   1_000_000,
+				Rng:            frand.NewUnsafeInt(42),
+			})
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			writeAbs(logger, output, abs)
+			return
+		}
+
+		buckets, err := flags.GetString("equities")
 		if err != nil {
 			logger.Fatal(err)
 		}
@@ -59,6 +108,23 @@ var turnCMD = &cobra.Command{
 			logger.Fatal(err)
 		}
 
+		batch, err := flags.GetInt("batch")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		randomSeed, err := flags.GetBool("random-seed")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		seedSample, err := flags.GetInt("seed-sample")
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		seeding := abs.Seeding{Random: randomSeed, Sample: seedSample}
+
 		logger.Printf("computing turn histograms")
 
 		hh, err := turn.Compute(turn.ComputeOpts{
@@ -81,19 +147,25 @@ var turnCMD = &cobra.Command{
 			LogIteration:   1_000_000,
 			DeltaThreshold: 0.0000001,
 			Bins:           bins,
+			BatchSize:      batch,
+			Seeding:        seeding,
 		})
 		if err != nil {
 			logger.Fatal(err)
 		}
 
-		bb, err := abs.MarshalBinary()
-		if err != nil {
-			logger.Fatal(err)
-		}
-
-		err = os.WriteFile(output, bb, 0644)
-		if err != nil {
-			logger.Fatal(err)
-		}
+		writeAbs(logger, output, abs)
 	},
 }
+
+func writeAbs(logger *log.Logger, output string, abs encoding.BinaryMarshaler) {
+	bb, err := abs.MarshalBinary()
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	err = os.WriteFile(output, bb, 0644)
+	if err != nil {
+		logger.Fatal(err)
+	}
+}
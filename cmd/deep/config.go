@@ -12,6 +12,8 @@ import (
 type SolutionConfig struct {
 	Abs string `json:"abs"`
 	Dir string `json:"dir"`
+	// IgnoreAbs uses solutions trained with different abstraction.
+	IgnoreAbs bool `json:"ignore_abs"`
 }
 
 func NewRootsFromConfig(cfg SolutionConfig) (deep.Roots, error) {
@@ -25,6 +27,13 @@ func NewRootsFromConfig(cfg SolutionConfig) (deep.Roots, error) {
 		return nil, err
 	}
 
+	if !cfg.IgnoreAbs {
+		err = roots.CheckAbs(abs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	solutions := make(deep.Roots, len(roots))
 	for i, root := range roots {
 		solutions[i] = deep.Root{
@@ -7,6 +7,7 @@ import (
 	cmdcfr "github.com/pokerdroid/poker/cmd/cfr"
 	cmdclus "github.com/pokerdroid/poker/cmd/clus"
 	cmddeep "github.com/pokerdroid/poker/cmd/deep"
+	cmdpushfold "github.com/pokerdroid/poker/cmd/pushfold"
 	cmdserver "github.com/pokerdroid/poker/cmd/server"
 	"github.com/spf13/cobra"
 )
@@ -24,6 +25,7 @@ func main() {
 	rootCmd.AddCommand(cmdserver.CMD)
 	rootCmd.AddCommand(cmddeep.CMD)
 	rootCmd.AddCommand(cmdclus.CMD)
+	rootCmd.AddCommand(cmdpushfold.CMD)
 
 	err := rootCmd.Execute()
 	if err != nil {
@@ -0,0 +1,13 @@
+package cmdpushfold
+
+import "github.com/spf13/cobra"
+
+func init() {
+	CMD.AddCommand(equityCMD)
+	CMD.AddCommand(solveCMD)
+}
+
+var CMD = &cobra.Command{
+	Use:   "pushfold",
+	Short: "solve preflop push/fold charts",
+}
@@ -0,0 +1,51 @@
+package cmdpushfold
+
+import (
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/pushfold"
+	"github.com/spf13/cobra"
+)
+
+type equityArgs struct {
+	output  string
+	samples int
+}
+
+var eqf = equityArgs{}
+
+func init() {
+	flags := equityCMD.Flags()
+	flags.StringVar(&eqf.output, "output", "pushfold_equity.bin", "path to equity matrix")
+	flags.IntVar(&eqf.samples, "samples", 20_000, "sampled deals for each pair of hand classes")
+}
+
+var equityCMD = &cobra.Command{
+	Use:   "equity",
+	Short: "compute 169x169 all-in equity matrix",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer cancel()
+
+		logger := log.Default()
+		logger.Printf("computing equity matrix with %d samples", eqf.samples)
+
+		e, err := pushfold.NewEquity(ctx, pushfold.EquityParams{
+			Samples: eqf.samples,
+			Rng:     frand.NewUnsafe(),
+		})
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		err = e.WriteFile(eqf.output)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		logger.Printf("%s written", eqf.output)
+	},
+}
@@ -0,0 +1,112 @@
+package cmdpushfold
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/pokerdroid/poker/frand"
+	"github.com/pokerdroid/poker/pushfold"
+	"github.com/spf13/cobra"
+)
+
+type solveArgs struct {
+	equity     string
+	output     string
+	players    int
+	min        float64
+	max        float64
+	step       float64
+	ante       float64
+	tolerance  float64
+	iterations int
+	print      bool
+}
+
+var sf = solveArgs{}
+
+func init() {
+	flags := solveCMD.Flags()
+	flags.StringVar(&sf.equity, "equity", "pushfold_equity.bin", "path to equity matrix, required heads up")
+	flags.StringVar(&sf.output, "output", "pushfold_charts.json", "path to charts")
+	flags.IntVar(&sf.players, "players", 2, "number of players")
+	flags.Float64Var(&sf.min, "min", 1, "shallowest stack in big blinds")
+	flags.Float64Var(&sf.max, "max", 20, "deepest stack in big blinds")
+	flags.Float64Var(&sf.step, "step", 1, "stack step in big blinds")
+	flags.Float64Var(&sf.ante, "ante", 0, "ante in big blinds")
+	flags.Float64Var(&sf.tolerance, "tolerance", 0.0001, "heads up exploitability in big blinds per hand")
+	flags.IntVar(&sf.iterations, "iterations", 0, "max iterations (default 100k heads up, 5M multiway)")
+	flags.BoolVar(&sf.print, "print", false, "print charts")
+}
+
+var solveCMD = &cobra.Command{
+	Use:   "solve",
+	Short: "solve push/fold charts for range of stack depths",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := log.Default()
+
+		if sf.players < 2 || sf.players > pushfold.MaxPlayers {
+			logger.Fatalf("players must be between 2 and %d", pushfold.MaxPlayers)
+		}
+
+		if sf.step <= 0 || sf.min > sf.max {
+			logger.Fatal("invalid stack range")
+		}
+
+		var eq *pushfold.Equity
+		if sf.players == 2 {
+			var err error
+			eq, err = pushfold.NewEquityFromFile(sf.equity)
+			if err != nil {
+				logger.Fatal(err)
+			}
+		}
+
+		iterations := sf.iterations
+		if iterations == 0 {
+			iterations = 100_000
+			if sf.players > 2 {
+				iterations = 5_000_000
+			}
+		}
+
+		var charts pushfold.Charts
+
+		for stack := sf.min; stack <= sf.max+1e-9; stack += sf.step {
+			prms := pushfold.Params{
+				Stack:         stack,
+				Ante:          sf.ante,
+				Tolerance:     sf.tolerance,
+				MaxIterations: iterations,
+			}
+
+			var sol pushfold.Solution
+			var err error
+
+			if eq != nil {
+				sol, err = pushfold.SolveHU(eq, prms)
+			} else {
+				sol, err = pushfold.SolveMultiway(frand.NewUnsafe(), sf.players, prms)
+			}
+			if err != nil {
+				logger.Fatal(err)
+			}
+
+			logger.Printf("stack: %-6g | it: %-8d | exp: %.6f", stack, sol.Iterations, sol.Exploit)
+
+			if sf.print {
+				for _, ch := range sol.Charts {
+					fmt.Println(ch.String())
+				}
+			}
+
+			charts = append(charts, sol.Charts...)
+		}
+
+		err := charts.WriteFile(sf.output)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		logger.Printf("%s written", sf.output)
+	},
+}
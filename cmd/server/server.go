@@ -7,6 +7,7 @@ import (
 	"os/signal"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/pokerdroid/poker/abs"
 	absp "github.com/pokerdroid/poker/abs/pack"
 	"github.com/pokerdroid/poker/bot"
 	"github.com/pokerdroid/poker/bot/cfr"
@@ -20,6 +21,9 @@ type serverArgs struct {
 	abs  string
 	dir  string
 	addr string
+
+	blend     bool
+	ignoreAbs bool
 }
 
 var tf = serverArgs{}
@@ -27,12 +31,13 @@ var tf = serverArgs{}
 func init() {
 	flags := serverCMD.Flags()
 
-	flags.StringVar(&tf.abs, "abs", "", "path to the abstraction")
+	flags.StringVar(&tf.abs, "abs", "", "path to the abstraction, found in --dir by solutions when empty")
 	flags.StringVar(&tf.dir, "dir", "", "path to the directory with solutions")
 
 	flags.StringVar(&tf.addr, "addr", ":8080", "address to listen on")
+	flags.BoolVar(&tf.blend, "blend", false, "blend strategies of two solutions closest by stack")
+	flags.BoolVar(&tf.ignoreAbs, "ignore-abs", false, "use solutions trained with different abstraction")
 
-	cobra.MarkFlagRequired(flags, "abs")
 	cobra.MarkFlagRequired(flags, "dir")
 
 }
@@ -47,16 +52,6 @@ var serverCMD = &cobra.Command{
 
 		logger := log.Default()
 
-		logger.Printf("loading abstraction")
-		abs, err := absp.NewFromFile(tf.abs)
-		if err != nil {
-			logger.Fatal(err)
-		}
-
-		logger.Printf("abs: %s", abs.UID.String())
-
-		rng := frand.NewHash()
-
 		rxs, err := tree.NewFileRootsFromDir(tf.dir)
 		if err != nil {
 			logger.Fatal(err)
@@ -69,12 +64,47 @@ var serverCMD = &cobra.Command{
 			logger.Printf("absid: %s", rx.AbsID.String())
 		}
 
-		cfradv := cfr.Advisor{
+		var mapper abs.Mapper
+		if tf.abs == "" {
+			// Walks clustering subdirectory too.
+			mapper, err = rxs.Abs(tf.dir)
+		} else {
+			logger.Printf("loading abstraction %s", tf.abs)
+			mapper, err = absp.NewFromFile(tf.abs)
+		}
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		logger.Printf("abs: %s", mapper)
+
+		if !tf.ignoreAbs {
+			err = rxs.CheckAbs(mapper)
+			if err != nil {
+				logger.Fatalf("%s, use --ignore-abs to serve anyway", err)
+			}
+		}
+
+		rng := frand.NewHash()
+
+		var cfradv bot.Advisor = cfr.Advisor{
 			Roots:   rxs.Roots(),
-			Abs:     abs,
+			Abs:     mapper,
 			Rand:    rng,
 			Logger:  logger,
 			Advisor: cfr.AdvisorSimple,
+
+			IgnoreAbs: tf.ignoreAbs,
+		}
+
+		if tf.blend {
+			cfradv = cfr.Blend{
+				Roots: rxs.Roots(),
+				Abs:   mapper,
+				Rand:  rng,
+
+				IgnoreAbs: tf.ignoreAbs,
+			}
 		}
 
 		mcadv := mc.NewAdvisor()
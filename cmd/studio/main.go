@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"syscall"
 
+	"github.com/pokerdroid/poker/abs"
 	absp "github.com/pokerdroid/poker/abs/pack"
 	"github.com/pokerdroid/poker/studio"
 	studiotree "github.com/pokerdroid/poker/studio/tree"
@@ -32,8 +33,9 @@ func main() {
 }
 
 type studioArgs struct {
-	dir string
-	abs string
+	dir       string
+	abs       string
+	ignoreAbs bool
 }
 
 var tf = studioArgs{}
@@ -52,7 +54,8 @@ func init() {
 	flags.StringVarP(&tf.dir, "dir", "d", dir, "directory containing the pokerdroid solutions")
 	cobra.MarkFlagRequired(flags, "dir")
 
-	flags.StringVarP(&tf.abs, "abs", "a", "pack_600_600_1000.bin", "default abstraction")
+	flags.StringVarP(&tf.abs, "abs", "a", "", "abstraction in clustering directory, found by solutions when empty")
+	flags.BoolVar(&tf.ignoreAbs, "ignore-abs", false, "use solutions trained with different abstraction")
 }
 
 var devCMD = &cobra.Command{
@@ -85,18 +88,31 @@ var devCMD = &cobra.Command{
 
 		logger.Printf("%s", rxs.String())
 
-		pack := filepath.Join(tf.dir, "clustering", tf.abs)
-		logger.Printf("loading clustering: %s", pack)
-
-		abs, err := absp.NewFromFile(pack)
+		var mapper abs.Mapper
+		if tf.abs == "" {
+			mapper, err = rxs.Abs(filepath.Join(tf.dir, "clustering"))
+		} else {
+			pack := filepath.Join(tf.dir, "clustering", tf.abs)
+			logger.Printf("loading clustering: %s", pack)
+			mapper, err = absp.NewFromFile(pack)
+		}
 		if err != nil {
 			logger.Fatalf("failed to create abstraction: %s", err)
 		}
 
+		if !tf.ignoreAbs {
+			err = rxs.CheckAbs(mapper)
+			if err != nil {
+				logger.Fatalf("%s, use --ignore-abs to load anyway", err)
+			}
+		}
+
 		err = studiotree.Bind(studiotree.BindParams{
-			Abs:     abs,
+			Abs:     mapper,
 			Roots:   rxs.Roots(),
 			WebView: dev.WebV,
+
+			IgnoreAbs: tf.ignoreAbs,
 		})
 		if err != nil {
 			logger.Fatal("failed to bind tree:", err)
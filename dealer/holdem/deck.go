@@ -11,23 +11,34 @@ var allcards = card.AllCopy()
 
 type deck struct {
 	cards card.Cards
+	// all cards of the full deck
+	all card.Cards
 }
 
 func newDeck() *deck {
-	d := &deck{cards: make(card.Cards, len(allcards))}
-	copy(d.cards, allcards)
+	return newVariantDeck(card.Holdem)
+}
+
+func newVariantDeck(v card.Variant) *deck {
+	all := allcards
+	if v != card.Holdem {
+		all = v.Cards()
+	}
+	d := &deck{cards: make(card.Cards, len(all)), all: all}
+	copy(d.cards, all)
 	return d
 }
 
 func cloneDeck(dest, src *deck) *deck {
 	dest.cards = dest.cards[:len(src.cards)]
 	copy(dest.cards, src.cards)
+	dest.all = src.all
 	return dest
 }
 
 func (d *deck) reset() {
-	d.cards = d.cards[:52]
-	copy(d.cards, allcards)
+	d.cards = d.cards[:len(d.all)]
+	copy(d.cards, d.all)
 }
 
 func fillrnd(rng frand.Rand, deck *deck, fill card.Cards) bool {
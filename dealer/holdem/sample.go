@@ -1,6 +1,8 @@
 package holdemdealer
 
 import (
+	"slices"
+
 	"github.com/pokerdroid/poker/abs"
 	"github.com/pokerdroid/poker/card"
 	"github.com/pokerdroid/poker/dealer"
@@ -13,23 +15,35 @@ import (
 type Sample struct {
 	deck   *deck
 	hands  []card.Cards
+	ranks  []card.HandRank
 	getter abs.Mapper
 	rng    frand.Rand
 	cur    table.Street
 	term   table.Street
+	util   dealer.Utility
+	delta  []float64
+	// variant of hand rankings
+	variant card.Variant
+	// offsets of streets in hands, they depend on number of hole cards
+	offsets []int
 }
 
 var _ dealer.Sample = &Sample{}
 
-var offsets = []int{0, 2, 5, 6, 7}
+var offsets = streetOffsets(2)
 var additions = []int{0, 3, 1, 1}
 
+// streetOffsets returns number of cards of a hand on every street.
+func streetOffsets(hole int) []int {
+	return []int{0, hole, hole + 3, hole + 4, hole + 5}
+}
+
 func (c *Sample) Cards(pID uint8) card.Cards {
-	return c.hands[pID][:offsets[c.cur]]
+	return c.hands[pID][:c.offsets[c.cur]]
 }
 
 func (c *Sample) Board() card.Cards {
-	return c.hands[0][2:]
+	return c.hands[0][c.offsets[table.Preflop]:]
 }
 
 func (c *Sample) Sample(s table.Street) {
@@ -37,7 +51,7 @@ func (c *Sample) Sample(s table.Street) {
 
 	for c.cur < s {
 		// Get the offset
-		of := offsets[c.cur]
+		of := c.offsets[c.cur]
 		// Get how many elements we need to add from offset
 		ad := additions[c.cur]
 		// Expand the hand to the new street
@@ -57,10 +71,36 @@ func (c *Sample) Sample(s table.Street) {
 }
 
 func (c *Sample) Cluster(n dealer.Turner, m abs.Mapper) abs.Cluster {
-	return m.Map(c.hands[n.GetTurnPos()])
+	cards := c.hands[n.GetTurnPos()]
+
+	if st, ok := n.(dealer.Streeter); ok {
+		s := st.GetStreet()
+		if s >= table.Preflop && s <= table.River && c.offsets[s] <= len(cards) {
+			cards = cards[:c.offsets[s]]
+		}
+	}
+
+	return m.Map(cards)
 }
 
 func (c *Sample) Utility(n *tree.Terminal, pID uint8) float64 {
+	if c.util == nil {
+		return c.chips(n, pID)
+	}
+
+	if len(c.delta) < len(n.Players) {
+		c.delta = make([]float64, len(n.Players))
+	}
+
+	for i := range n.Players {
+		c.delta[i] = c.chips(n, uint8(i))
+	}
+
+	return c.util.Value(c.delta[:len(n.Players)], pID)
+}
+
+// chips returns chips won or lost by player in the hand.
+func (c *Sample) chips(n *tree.Terminal, pID uint8) float64 {
 	paid := float64(n.Players[pID].Paid)
 	pot := float64(n.Pots.Sum())
 
@@ -74,41 +114,78 @@ func (c *Sample) Utility(n *tree.Terminal, pID uint8) float64 {
 
 	c.Sample(c.term)
 
-	winners, err := eval.Judge(c.hands)
-	if err != nil {
-		panic(err)
+	return c.winnings(n, pID) - paid
+}
+
+// winnings returns what player wins at showdown. Every pot is split
+// among the best hands of players eligible for it.
+func (c *Sample) winnings(n *tree.Terminal, pID uint8) float64 {
+	if len(c.ranks) < len(c.hands) {
+		c.ranks = make([]card.HandRank, len(c.hands))
 	}
 
-	switch len(winners) {
-	// we have a draw
-	case 0:
-		panic("no winners")
+	for i, p := range n.Players {
+		if p.Status == table.StatusFolded {
+			continue
+		}
+		rank, err := eval.EvalVariant(c.variant, c.hands[i]...)
+		if err != nil {
+			panic(err)
+		}
+		c.ranks[i] = rank
+	}
+
+	pots := n.Pots
+	if len(pots) == 0 {
+		pots = table.GetPots(n.Players)
+	}
+
+	var won float64
 
-	case 1:
-		if winners[0] == pID {
-			return pot - paid
+	for _, pot := range pots {
+		if !slices.Contains(pot.Players, pID) {
+			continue
+		}
+
+		best := pot.Players[0]
+		for _, x := range pot.Players[1:] {
+			if c.ranks[x].Compare(c.ranks[best]) == 0 {
+				best = x
+			}
+		}
+
+		if c.ranks[pID].Compare(c.ranks[best]) != 2 {
+			continue
+		}
+
+		var first uint8
+		count := 0
+		for _, x := range pot.Players {
+			if c.ranks[x].Compare(c.ranks[best]) == 2 {
+				if count == 0 {
+					first = x
+				}
+				count++
+			}
+		}
+
+		share, odd := table.SplitPot(pot.Amount, count)
+		won += share.Float64()
+		if first == pID {
+			won += odd.Float64()
 		}
-		return -paid
-	default:
-		// Tie among multiple winners
-		// this however needs to involve a multiple pots.
-		//
-		// for _, wID := range winners {
-		// 	if wID == pID {
-		// 		builder.WriteString(fmt.Sprintf("paid: %f\n", (pot/float32(len(winners)))-paid))
-		// 		// If pID is among the winners, give them an equal fraction of the pot
-		// 		return (pot / float32(len(winners))) - paid
-		// 	}
-		// }
-		// If pID is not among 'winners', they lose their contribution
-		return 0
 	}
+
+	return won
 }
 
 func cloneSample(dst *Sample, src *Sample) *Sample {
 	cloneDeck(dst.deck, src.deck)
 	dst.cur = src.cur
 	dst.term = src.term
+	dst.util = src.util
+	dst.variant = src.variant
+	dst.offsets = src.offsets
 	dst.getter = src.getter
 	dst.rng = src.rng
 
@@ -6,8 +6,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pokerdroid/poker/abs"
 	"github.com/pokerdroid/poker/card"
 	"github.com/pokerdroid/poker/chips"
+	"github.com/pokerdroid/poker/dealer"
 	"github.com/pokerdroid/poker/eval"
 	"github.com/pokerdroid/poker/frand"
 	"github.com/pokerdroid/poker/table"
@@ -73,6 +75,47 @@ func TestUtility2(t *testing.T) {
 	require.Equal(t, s.Utility(tm, 1), float64(-2))
 }
 
+func TestUtilityICM(t *testing.T) {
+	stacks := chips.NewList(20, 10, 10)
+	payouts := []float64{0.5, 0.3, 0.2}
+
+	hnd := New(SamplerParams{
+		NumPlayers: 3,
+		Utility:    dealer.NewICM(stacks, nil, payouts),
+	})
+
+	sx, err := hnd.Sample(frand.NewUnsafeInt(42))
+	require.NoError(t, err)
+
+	// Big stack folds, blinds are exchanged.
+	tm := &tree.Terminal{
+		Pots: table.Pots{
+			{Amount: chips.New(3), Players: []uint8{2}},
+		},
+		Players: table.Players{
+			{Paid: chips.New(0), Status: table.StatusFolded},
+			{Paid: chips.New(1), Status: table.StatusFolded},
+			{Paid: chips.New(2), Status: table.StatusActive},
+		},
+	}
+
+	before := dealer.ICMEquity([]float64{20, 10, 10}, payouts)
+	after := dealer.ICMEquity([]float64{20, 9, 11}, payouts)
+
+	var sum float64
+	for i := uint8(0); i < 3; i++ {
+		u := sx.Utility(tm, i)
+		require.InDelta(t, after[i]-before[i], u, 1e-9)
+		sum += u
+	}
+
+	// Prize pool is constant, equity moves between players.
+	require.InDelta(t, 0, sum, 1e-9)
+
+	// Folding player gains equity when others exchange chips.
+	require.Greater(t, sx.Utility(tm, 0), 0.0)
+}
+
 func TestUtility3(t *testing.T) {
 	params := SamplerParams{NumPlayers: 2}
 	rng := frand.NewUnsafeInt(1)
@@ -222,3 +265,53 @@ func TestSampleChanceNodeSequential(t *testing.T) {
 			"Board should have %d cards after %s", expectedBoardSizes[i], street)
 	}
 }
+
+func TestUtilitySidePots(t *testing.T) {
+	board := card.NewCardsFromString("ks kd 7c 7d 2h")
+
+	s := &Sample{
+		hands: []card.Cards{
+			append(card.NewCardsFromString("as ah"), board...),
+			append(card.NewCardsFromString("ac ad"), board...),
+			append(card.NewCardsFromString("kh kc"), board...),
+		},
+		cur:  table.River,
+		term: table.River,
+	}
+
+	players := table.Players{
+		{Paid: chips.New(50), Status: table.StatusActive},
+		{Paid: chips.New(50), Status: table.StatusActive},
+		{Paid: chips.New(10), Status: table.StatusAllIn},
+	}
+
+	tm := &tree.Terminal{
+		Pots:    table.GetPots(players),
+		Players: players,
+	}
+
+	// Quads win main pot, aces chop the side pot.
+	require.Equal(t, float64(-10), s.Utility(tm, 0))
+	require.Equal(t, float64(-10), s.Utility(tm, 1))
+	require.Equal(t, float64(20), s.Utility(tm, 2))
+}
+
+type lenMapper struct{}
+
+func (lenMapper) Map(cds card.Cards) abs.Cluster {
+	return abs.Cluster(len(cds))
+}
+
+func TestClusterStreet(t *testing.T) {
+	hnd := New(SamplerParams{NumPlayers: 3})
+	sx, err := hnd.Sample(frand.NewUnsafeInt(42))
+	require.NoError(t, err)
+
+	// Showdown in a deeper branch deals the whole board.
+	sx.Sample(table.River)
+
+	for _, street := range []table.Street{table.Preflop, table.Flop, table.Turn, table.River} {
+		pl := &tree.Player{TurnPos: 1, State: &table.State{Street: street}}
+		require.Equal(t, abs.Cluster(offsets[street]), sx.Cluster(pl, lenMapper{}))
+	}
+}
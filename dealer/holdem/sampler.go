@@ -13,6 +13,10 @@ import (
 type SamplerParams struct {
 	NumPlayers uint8
 	Terminal   table.Street
+	// Utility converts chips won into utility, chip EV when nil.
+	Utility dealer.Utility
+	// Variant decides deck and hand rankings.
+	Variant card.Variant
 }
 
 type Sampler struct {
@@ -27,15 +31,20 @@ func New(p SamplerParams) *Sampler {
 	}
 
 	s := &Sampler{SamplerParams: p}
+	hole := p.Variant.HoleCards()
+
 	s.pool.New = func() interface{} {
 		g := &Sample{
-			hands: make([]card.Cards, p.NumPlayers),
-			deck:  newDeck(),
-			cur:   table.Preflop,
-			term:  p.Terminal,
+			hands:   make([]card.Cards, p.NumPlayers),
+			deck:    newVariantDeck(p.Variant),
+			cur:     table.Preflop,
+			term:    p.Terminal,
+			util:    p.Utility,
+			variant: p.Variant,
+			offsets: streetOffsets(hole),
 		}
 		for i := uint8(0); i < p.NumPlayers; i++ {
-			g.hands[i] = make(card.Cards, 0, 7)
+			g.hands[i] = make(card.Cards, 0, hole+5)
 		}
 		return g
 	}
@@ -56,7 +65,7 @@ func (c *Sampler) Sample(rng frand.Rand) (dealer.Sample, error) {
 	var ok bool
 
 	for i := uint8(0); i < c.NumPlayers; i++ {
-		g.hands[i] = g.hands[i][:2]
+		g.hands[i] = g.hands[i][:g.offsets[table.Preflop]]
 
 		ok = fillrnd(rng, g.deck, g.hands[i])
 		if !ok {
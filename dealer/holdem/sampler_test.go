@@ -86,3 +86,49 @@ func TestSampleCopy2(t *testing.T) {
 	require.NotEqual(t, sample, copy1)
 	require.NotEqual(t, sample, copy2)
 }
+
+func TestSamplerShortDeck(t *testing.T) {
+	hnd := New(SamplerParams{NumPlayers: 6, Variant: card.ShortDeck})
+	r := frand.NewUnsafeInt(0)
+
+	for i := 0; i < 100; i++ {
+		sample, err := hnd.Sample(r)
+		require.NoError(t, err)
+
+		gs := sample.(*Sample)
+		gs.Sample(table.River)
+		require.Len(t, gs.deck.cards, 36-6*2-5)
+
+		for p := uint8(0); p < 6; p++ {
+			for _, c := range gs.Cards(p) {
+				require.GreaterOrEqual(t, c.Rank(), card.Six)
+			}
+		}
+
+		hnd.Put(sample)
+	}
+}
+
+func TestSamplerOmaha(t *testing.T) {
+	hnd := New(SamplerParams{NumPlayers: 6, Variant: card.Omaha})
+	r := frand.NewUnsafeInt(0)
+
+	sample, err := hnd.Sample(r)
+	require.NoError(t, err)
+
+	gs := sample.(*Sample)
+	require.Len(t, gs.Cards(0), 4)
+
+	gs.Sample(table.Flop)
+	require.Len(t, gs.Cards(0), 7)
+	require.Len(t, gs.Board(), 3)
+
+	gs.Sample(table.River)
+	require.Len(t, gs.Board(), 5)
+	require.Len(t, gs.deck.cards, 52-6*4-5)
+
+	for p := uint8(0); p < 6; p++ {
+		require.Len(t, gs.Cards(p), 9)
+		require.Equal(t, gs.Board(), gs.Cards(p)[4:])
+	}
+}
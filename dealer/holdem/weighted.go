@@ -1,6 +1,7 @@
 package holdemdealer
 
 import (
+	"errors"
 	"sync"
 
 	"github.com/pokerdroid/poker/abs"
@@ -21,7 +22,9 @@ type RangeParams struct {
 type RangeSampler struct {
 	RangeParams
 
-	pool sync.Pool
+	// dists are ranges without hands blocked by board, normalized.
+	dists []card.RangeDist
+	pool  sync.Pool
 }
 
 func NewWeighted(p RangeParams) *RangeSampler {
@@ -29,12 +32,24 @@ func NewWeighted(p RangeParams) *RangeSampler {
 	s.deck = newDeck()
 	popcards(s.deck, p.Board)
 
+	s.dists = make([]card.RangeDist, p.NumPlayers)
+	for i := range s.dists {
+		reng := p.Ranges[i]
+		for k := range reng {
+			if card.IsAnyMatch(card.RangeCards(k), p.Board) {
+				reng[k] = 0
+			}
+		}
+		s.dists[i] = reng.Normalize()
+	}
+
 	s.pool.New = func() interface{} {
 		g := &Sample{
-			hands:  make([]card.Cards, p.NumPlayers),
-			getter: p.Clusters,
-			deck:   newDeck(),
-			cur:    table.Preflop,
+			hands:   make([]card.Cards, p.NumPlayers),
+			getter:  p.Clusters,
+			deck:    newDeck(),
+			cur:     table.Preflop,
+			offsets: offsets,
 		}
 		for i := uint8(0); i < p.NumPlayers; i++ {
 			g.hands[i] = make(card.Cards, 7)
@@ -67,38 +82,49 @@ func (c *RangeSampler) Sample(rng frand.Rand) (dealer.Sample, error) {
 		panic("invalid board")
 	}
 
-	for i := uint8(0); i < c.NumPlayers; i++ {
-		reng := c.Ranges[i]
-
-		for i := range reng {
-			if card.IsAnyMatch(card.RangeCards(i), c.Board) {
-				reng[i] = 0
-			}
+	// Hands of players can't share cards. Conflicting matchup is drawn
+	// again as whole, redrawing only the conflicting hand would skew
+	// earlier players towards hands blocking later ranges.
+	for k := 0; !c.deal(g, rng); k++ {
+		if k == maxResample {
+			c.Put(g)
+			return nil, errors.New("ranges of players conflict")
 		}
+	}
 
-		// if reng.Sum() < 1e-4 {
-		// 	pretty.Println(reng.Matrix().String())
-		// 	panic("failed to sample from range")
-		// }
-
-		zeng := reng.Normalize()
-		s := zeng.Sample(rng)
-
-		// if card.IsAnyMatch(s, c.Board) {
-		// 	pretty.Println(reng.Matrix().String(), s.String(), c.Board.String())
-		// 	pretty.Println(card.Coordinates(s))
-		// 	pretty.Println(reng)
-		// 	panic("failed to sample from range")
-		// }
+	for i := range g.hands {
+		popcards(g.deck, g.hands[i][:2])
+		g.hands[i] = append(g.hands[i][:2], c.Board...)
+	}
 
-		popcards(g.deck, s)
+	return g, nil
+}
 
+// maxResample is number of attempts to sample matchup of hands not
+// sharing cards.
+const maxResample = 1000
+
+// deal draws hole cards of every player from their range, it returns
+// false when hands share a card.
+func (c *RangeSampler) deal(g *Sample, rng frand.Rand) bool {
+	for i := range g.hands {
+		s := c.dists[i].Sample(rng)
+		if conflicts(g.hands[:i], s) {
+			return false
+		}
 		g.hands[i][0] = s[0]
 		g.hands[i][1] = s[1]
-		g.hands[i] = append(g.hands[i][:2], c.Board...)
 	}
+	return true
+}
 
-	return g, nil
+func conflicts(hands []card.Cards, s card.Cards) bool {
+	for _, h := range hands {
+		if card.IsAnyMatch(h[:2], s) {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *RangeSampler) Copy(rng frand.Rand, s dealer.Sample) (dealer.Sample, error) {
@@ -198,3 +198,69 @@ func TestWSampleCopy2(t *testing.T) {
 	require.NotEqual(t, sample, copy1)
 	require.NotEqual(t, sample, copy2)
 }
+
+func TestWeightedSamplerConflict(t *testing.T) {
+	aa := card.RangeDist{}
+	aa[card.RangeIndex(card.RangeCards(0))] = 1
+
+	open := card.NewRangeDist(card.NewOpenMatrix())
+
+	hnd := NewWeighted(RangeParams{
+		NumPlayers: 2,
+		Ranges:     []card.RangeDist{aa, open},
+	})
+
+	r := frand.NewUnsafeInt(0)
+
+	for i := 0; i < 100; i++ {
+		sample, err := hnd.Sample(r)
+		require.NoError(t, err)
+		s := sample.(*Sample)
+		require.False(t, card.IsAnyMatch(s.Cards(0), s.Cards(1)))
+		hnd.Put(sample)
+	}
+
+	hnd = NewWeighted(RangeParams{
+		NumPlayers: 2,
+		Ranges:     []card.RangeDist{aa, aa},
+	})
+
+	_, err := hnd.Sample(r)
+	require.Error(t, err)
+}
+
+func TestWeightedSamplerMatchup(t *testing.T) {
+	x := card.NewCardsFromString("ah as")
+	y := card.NewCardsFromString("kh ks")
+	a := card.NewCardsFromString("ah ad")
+	q := card.NewCardsFromString("qh qs")
+
+	var r0, r1 card.RangeDist
+	r0[card.RangeIndex(x)] = 1
+	r0[card.RangeIndex(y)] = 1
+	r1[card.RangeIndex(a)] = 1
+	r1[card.RangeIndex(q)] = 1
+
+	hnd := NewWeighted(RangeParams{
+		NumPlayers: 2,
+		Ranges:     []card.RangeDist{r0, r1},
+	})
+
+	r := frand.NewUnsafeInt(0)
+
+	// Matchups not sharing cards are equally likely, so the first
+	// player holds AhAs in one of three, not in half of them.
+	n, xs := 6000, 0
+	for i := 0; i < n; i++ {
+		sample, err := hnd.Sample(r)
+		require.NoError(t, err)
+		s := sample.(*Sample)
+		require.False(t, card.IsAnyMatch(s.Cards(0), s.Cards(1)))
+		if card.RangeIndex(s.Cards(0)) == card.RangeIndex(x) {
+			xs++
+		}
+		hnd.Put(sample)
+	}
+
+	require.InDelta(t, 1./3, float64(xs)/float64(n), 0.03)
+}
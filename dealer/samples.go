@@ -11,6 +11,13 @@ type Turner interface {
 	GetTurnPos() uint8
 }
 
+// Streeter is implemented by nodes that know their street. Samples
+// use it to map cards of that street, board can already be dealt
+// further when traversal returns from a deeper branch.
+type Streeter interface {
+	GetStreet() table.Street
+}
+
 type Sample interface {
 	Sample(s table.Street)
 	Cluster(n Turner, abs abs.Mapper) abs.Cluster
@@ -0,0 +1,173 @@
+package dealer
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"sync"
+
+	"github.com/pokerdroid/poker/chips"
+)
+
+// Utility converts chips won or lost in a hand into utility of a
+// seat. Delta holds chips won (or lost when negative) by every seat.
+// Implementations are shared by samplers and must be safe for
+// concurrent use.
+type Utility interface {
+	Value(delta []float64, pID uint8) float64
+}
+
+// ChipEV is utility of cash games, chips are money.
+type ChipEV struct{}
+
+func (ChipEV) Value(delta []float64, pID uint8) float64 {
+	return delta[pID]
+}
+
+// ICM values final stacks by their share of tournament prize pool
+// using Malmuth-Harville model. Utility of a hand is equity after
+// it minus equity before it. Equity is cached by final stacks, a tree
+// has few distinct terminal outcomes and the model is exponential in
+// number of players.
+type ICM struct {
+	// Stacks of seats at the start of the hand.
+	Stacks chips.List
+	// Others are stacks of players left in tournament who are not
+	// in the hand, like players at other tables.
+	Others chips.List
+	// Payouts for 1st, 2nd, ... place.
+	Payouts []float64
+
+	base  []float64
+	cache sync.Map
+}
+
+// NewICM creates ICM utility for seats with stacks.
+func NewICM(stacks, others chips.List, payouts []float64) *ICM {
+	m := &ICM{
+		Stacks:  stacks.Copy(),
+		Others:  others.Copy(),
+		Payouts: append([]float64{}, payouts...),
+	}
+	m.base = ICMEquity(m.stacks(nil), m.Payouts)
+	return m
+}
+
+func (m *ICM) stacks(delta []float64) []float64 {
+	st := make([]float64, 0, len(m.Stacks)+len(m.Others))
+	for i, s := range m.Stacks {
+		v := s.Float64()
+		if i < len(delta) {
+			v += delta[i]
+		}
+		if v < 0 {
+			v = 0
+		}
+		st = append(st, v)
+	}
+	for _, s := range m.Others {
+		st = append(st, s.Float64())
+	}
+	return st
+}
+
+func (m *ICM) Value(delta []float64, pID uint8) float64 {
+	eq := m.equity(m.stacks(delta))
+	return eq[pID] - m.base[pID]
+}
+
+// equity returns cached ICMEquity of stacks.
+func (m *ICM) equity(st []float64) []float64 {
+	key := stacksKey(st)
+
+	if eq, ok := m.cache.Load(key); ok {
+		return eq.([]float64)
+	}
+
+	eq := ICMEquity(st, m.Payouts)
+	m.cache.Store(key, eq)
+	return eq
+}
+
+func stacksKey(st []float64) string {
+	key := make([]byte, 8*len(st))
+	for i, v := range st {
+		binary.LittleEndian.PutUint64(key[i*8:], math.Float64bits(v))
+	}
+	return string(key)
+}
+
+// ICMEquity returns prize equity of every stack using Malmuth-Harville
+// model: player finishes first with probability equal to share of chips,
+// places below are decided the same way among remaining players.
+// Busted players split what is left for the last places.
+func ICMEquity(stacks []float64, payouts []float64) []float64 {
+	n := len(stacks)
+	eq := make([]float64, n)
+
+	if n == 0 {
+		return eq
+	}
+
+	if n > 20 {
+		panic("icm: too many players")
+	}
+
+	var total float64
+	for _, s := range stacks {
+		total += s
+	}
+
+	// prob[mask] is probability that players in mask took the first
+	// len(mask) places, sum[mask] their chips.
+	prob := make([]float64, 1<<n)
+	sum := make([]float64, 1<<n)
+	prob[0] = 1
+
+	for mask := 0; mask < 1<<n; mask++ {
+		p := prob[mask]
+		if p == 0 {
+			continue
+		}
+
+		place := bits.OnesCount(uint(mask))
+		if place >= len(payouts) {
+			continue
+		}
+
+		rest := total - sum[mask]
+
+		if rest <= 0 {
+			// Only busted players left, split remaining payouts.
+			var prize float64
+			for k := place; k < len(payouts) && k < n; k++ {
+				prize += payouts[k]
+			}
+			left := n - place
+			for i := 0; i < n; i++ {
+				if mask&(1<<i) == 0 {
+					eq[i] += p * prize / float64(left)
+				}
+			}
+			continue
+		}
+
+		for i := 0; i < n; i++ {
+			bit := 1 << i
+			if mask&bit != 0 || stacks[i] <= 0 {
+				continue
+			}
+
+			q := p * stacks[i] / rest
+			eq[i] += q * payouts[place]
+
+			next := mask | bit
+			if prob[next] == 0 {
+				sum[next] = sum[mask] + stacks[i]
+			}
+			prob[next] += q
+		}
+	}
+
+	return eq
+}
@@ -0,0 +1,59 @@
+package dealer
+
+import (
+	"testing"
+
+	"github.com/pokerdroid/poker/chips"
+	"github.com/stretchr/testify/require"
+)
+
+func TestICMEquity(t *testing.T) {
+	payouts := []float64{50, 30, 20}
+
+	// Heads up for winner takes all is chip share.
+	eq := ICMEquity([]float64{30, 10}, []float64{100})
+	require.InDeltaSlice(t, []float64{75, 25}, eq, 1e-9)
+
+	// Equal stacks split prize pool.
+	eq = ICMEquity([]float64{10, 10, 10}, payouts)
+	require.InDeltaSlice(t, []float64{100.0 / 3, 100.0 / 3, 100.0 / 3}, eq, 1e-9)
+
+	// Known 50/30/20 distribution.
+	eq = ICMEquity([]float64{5000, 3000, 2000}, payouts)
+	require.InDeltaSlice(t, []float64{38.3929, 32.75, 28.8571}, eq, 1e-4)
+
+	// Busted player takes the last place.
+	eq = ICMEquity([]float64{60, 40, 0}, payouts)
+	require.InDeltaSlice(t, []float64{42, 38, 20}, eq, 1e-9)
+
+	// More players than paid places.
+	eq = ICMEquity([]float64{40, 30, 20, 10}, []float64{70, 30})
+	var sum float64
+	for _, e := range eq {
+		sum += e
+	}
+	require.InDelta(t, 100, sum, 1e-9)
+	require.Greater(t, eq[0], eq[1])
+	require.Greater(t, eq[2], eq[3])
+}
+
+func TestICMValue(t *testing.T) {
+	m := NewICM(chips.NewList(50, 30), chips.NewList(20), []float64{50, 30, 20})
+
+	// Nothing changes, no utility.
+	require.InDelta(t, 0, m.Value([]float64{0, 0}, 0), 1e-9)
+
+	// Doubling up is worth less than chips risked.
+	win := m.Value([]float64{30, -30}, 0)
+	lose := m.Value([]float64{-30, 30}, 0)
+	require.Greater(t, win, 0.0)
+	require.Less(t, win, -lose)
+
+	require.Equal(t, 7.0, ChipEV{}.Value([]float64{7, -7}, 0))
+
+	// Cached equity is the same for every seat of the outcome.
+	st := m.stacks([]float64{30, -30})
+	_, ok := m.cache.Load(stacksKey(st))
+	require.True(t, ok)
+	require.InDelta(t, ICMEquity(st, m.Payouts)[1]-m.base[1], m.Value([]float64{30, -30}, 1), 1e-9)
+}
@@ -0,0 +1,472 @@
+package equity
+
+import (
+	"context"
+	"errors"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/eval"
+	"github.com/pokerdroid/poker/frand"
+)
+
+// Calculator computes equities of holdem ranges against each other on
+// a board with dead cards. Showdowns are enumerated exactly when there
+// are at most ExactLimit of them, otherwise they are sampled until
+// standard error of equity of every range is below StdErr.
+type Calculator struct {
+	// Board has 0, 3, 4 or 5 cards.
+	Board card.Cards
+	// Dead cards can't be dealt to players nor board.
+	Dead card.Cards
+	// StdErr is target standard error of Monte Carlo, 0.001 by default.
+	StdErr float64
+	// MaxSamples stops Monte Carlo, 100M by default.
+	MaxSamples uint64
+	// ExactLimit is max number of showdowns enumerated, 50M by default.
+	ExactLimit uint64
+	Workers    int
+	Rng        frand.Rand
+}
+
+// ComboEquity is equity of single combo of range against other ranges.
+// EV is share of the pot, ties are split.
+type ComboEquity struct {
+	Cards  card.Cards
+	Weight float64
+	Win    float64
+	Tie    float64
+	EV     float64
+}
+
+// RangeEquity is equity of range weighted by its combos.
+type RangeEquity struct {
+	Win    float64
+	Tie    float64
+	EV     float64
+	StdErr float64
+	// Combos with positive weight not blocked by board or dead cards.
+	Combos []ComboEquity
+}
+
+// Result has equities of ranges in order they were given.
+type Result struct {
+	Ranges []RangeEquity
+	// Exact is set when all showdowns were enumerated.
+	Exact bool
+	// Showdowns is number of evaluated showdowns.
+	Showdowns uint64
+}
+
+// combo is hole cards of range with their weight.
+type combo struct {
+	cards  [2]card.Card
+	mask   uint64
+	weight float64
+}
+
+// acc accumulates results of combos by range and combo.
+type acc struct {
+	win, tie, ev, mass [][]float64
+	// Sum of squares of EV of every range, Monte Carlo only.
+	sq []float64
+	n  uint64
+}
+
+func newAcc(ranges [][]combo) *acc {
+	a := &acc{sq: make([]float64, len(ranges))}
+	for _, r := range ranges {
+		a.win = append(a.win, make([]float64, len(r)))
+		a.tie = append(a.tie, make([]float64, len(r)))
+		a.ev = append(a.ev, make([]float64, len(r)))
+		a.mass = append(a.mass, make([]float64, len(r)))
+	}
+	return a
+}
+
+func (a *acc) merge(b *acc) {
+	for i := range a.win {
+		for k := range a.win[i] {
+			a.win[i][k] += b.win[i][k]
+			a.tie[i][k] += b.tie[i][k]
+			a.ev[i][k] += b.ev[i][k]
+			a.mass[i][k] += b.mass[i][k]
+		}
+		a.sq[i] += b.sq[i]
+	}
+	a.n += b.n
+}
+
+// stdErr returns standard error of EV of range i.
+func (a *acc) stdErr(i int) float64 {
+	var sum float64
+	for _, e := range a.ev[i] {
+		sum += e
+	}
+	n := float64(a.n)
+	mean := sum / n
+	v := math.Max(a.sq[i]/n-mean*mean, 0)
+	return math.Sqrt(v / n)
+}
+
+// converged reports whether standard error of every range is below se.
+func (a *acc) converged(se float64) bool {
+	for i := range a.ev {
+		if a.stdErr(i) >= se {
+			return false
+		}
+	}
+	return true
+}
+
+// showdown evaluates hands of players on full board and adds result
+// weighted by w.
+type showdown struct {
+	cards [7]card.Card
+	ranks []uint32
+}
+
+func (s *showdown) eval(a *acc, players [][2]card.Card, idx []int, board []card.Card, w float64, mc bool) {
+	copy(s.cards[2:], board)
+
+	var best uint32
+	var nb int
+	for i, p := range players {
+		s.cards[0], s.cards[1] = p[0], p[1]
+		r, err := eval.Eval(s.cards[:]...)
+		if err != nil {
+			panic(err)
+		}
+		s.ranks[i] = uint32(r.Kind)<<16 | r.Rank
+		switch {
+		case s.ranks[i] > best:
+			best, nb = s.ranks[i], 1
+		case s.ranks[i] == best:
+			nb++
+		}
+	}
+
+	share := 1 / float64(nb)
+	for i := range players {
+		k := idx[i]
+		a.mass[i][k] += w
+		if s.ranks[i] != best {
+			continue
+		}
+		if nb == 1 {
+			a.win[i][k] += w
+		} else {
+			a.tie[i][k] += w
+		}
+		a.ev[i][k] += w * share
+		if mc {
+			a.sq[i] += share * share
+		}
+	}
+	a.n++
+}
+
+// Calculate returns equity of every range against the others.
+func (calc *Calculator) Calculate(ctx context.Context, ranges ...card.RangeDist) (*Result, error) {
+	c := *calc
+
+	if len(ranges) < 2 {
+		return nil, errors.New("at least 2 ranges are needed")
+	}
+
+	if n := len(c.Board); n == 1 || n == 2 || n > 5 {
+		return nil, errors.New("board must have 0, 3, 4 or 5 cards")
+	}
+
+	var dead uint64
+	for _, cd := range append(c.Board.Clone(), c.Dead...) {
+		if cd == card.Card00 || cd > card.CardAS || dead&(1<<cd) != 0 {
+			return nil, errors.New("board and dead cards must be valid and distinct")
+		}
+		dead |= 1 << cd
+	}
+
+	combos := make([][]combo, len(ranges))
+	for i, r := range ranges {
+		for k, w := range r {
+			cds := card.RangeCards(k)
+			m := uint64(1)<<cds[0] | uint64(1)<<cds[1]
+			if w <= 0 || m&dead != 0 {
+				continue
+			}
+			combos[i] = append(combos[i], combo{cards: [2]card.Card{cds[0], cds[1]}, mask: m, weight: w})
+		}
+		if len(combos[i]) == 0 {
+			return nil, errors.New("range is empty")
+		}
+	}
+
+	if c.StdErr <= 0 {
+		c.StdErr = 0.001
+	}
+	if c.MaxSamples == 0 {
+		c.MaxSamples = 100_000_000
+	}
+	if c.ExactLimit == 0 {
+		c.ExactLimit = 50_000_000
+	}
+	if c.Workers <= 0 {
+		c.Workers = runtime.NumCPU()
+	}
+	if c.Rng == nil {
+		c.Rng = frand.NewHash()
+	}
+
+	var deck []card.Card
+	for cd := card.Card2C; cd <= card.CardAS; cd++ {
+		if dead&(1<<cd) == 0 {
+			deck = append(deck, cd)
+		}
+	}
+
+	missing := 5 - len(c.Board)
+
+	// Upper bound of showdowns, matchups sharing cards are counted too.
+	size := 1.
+	if missing > 0 {
+		size = float64(card.CombinationsLen(len(deck)-2*len(ranges), missing))
+	}
+	for _, cc := range combos {
+		size *= float64(len(cc))
+	}
+
+	var a *acc
+	var err error
+	exact := size <= float64(c.ExactLimit)
+	if exact {
+		a, err = c.enumerate(ctx, combos, deck, missing)
+	} else {
+		a, err = c.sample(ctx, combos, deck, missing)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{Exact: exact, Showdowns: a.n}
+	for i, cc := range combos {
+		var re RangeEquity
+		var mass float64
+		for k, cb := range cc {
+			ce := ComboEquity{
+				Cards:  card.Cards{cb.cards[0], cb.cards[1]},
+				Weight: cb.weight,
+			}
+			if m := a.mass[i][k]; m > 0 {
+				ce.Win = a.win[i][k] / m
+				ce.Tie = a.tie[i][k] / m
+				ce.EV = a.ev[i][k] / m
+			}
+			re.Win += a.win[i][k]
+			re.Tie += a.tie[i][k]
+			re.EV += a.ev[i][k]
+			mass += a.mass[i][k]
+			re.Combos = append(re.Combos, ce)
+		}
+		if mass == 0 {
+			return nil, errors.New("ranges conflict")
+		}
+		re.Win /= mass
+		re.Tie /= mass
+		re.EV /= mass
+		res.Ranges = append(res.Ranges, re)
+	}
+
+	if !exact {
+		for i := range res.Ranges {
+			res.Ranges[i].StdErr = a.stdErr(i)
+		}
+	}
+
+	return res, nil
+}
+
+// enumerate evaluates every matchup of combos on every runout, combos
+// of the first range are split among workers.
+func (c *Calculator) enumerate(ctx context.Context, combos [][]combo, deck []card.Card, missing int) (*acc, error) {
+	var next int64 = -1
+	var mux sync.Mutex
+	var wg sync.WaitGroup
+
+	total := newAcc(combos)
+
+	for w := 0; w < c.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			a := newAcc(combos)
+			s := &showdown{ranks: make([]uint32, len(combos))}
+			players := make([][2]card.Card, len(combos))
+			idx := make([]int, len(combos))
+			board := make([]card.Card, 5)
+			copy(board, c.Board)
+			rest := make([]card.Card, 0, len(deck))
+
+			var deal func(p int, used uint64, w float64)
+			deal = func(p int, used uint64, w float64) {
+				if p < len(combos) {
+					for k, cb := range combos[p] {
+						if cb.mask&used != 0 {
+							continue
+						}
+						players[p], idx[p] = cb.cards, k
+						deal(p+1, used|cb.mask, w*cb.weight)
+					}
+					return
+				}
+
+				rest = rest[:0]
+				for _, cd := range deck {
+					if used&(1<<cd) == 0 {
+						rest = append(rest, cd)
+					}
+				}
+
+				runouts(rest, missing, board[len(c.Board):], func() {
+					s.eval(a, players, idx, board, w, false)
+				})
+			}
+
+			for {
+				k := int(atomic.AddInt64(&next, 1))
+				if k >= len(combos[0]) || ctx.Err() != nil {
+					break
+				}
+				cb := combos[0][k]
+				players[0], idx[0] = cb.cards, k
+				deal(1, cb.mask, cb.weight)
+			}
+
+			mux.Lock()
+			total.merge(a)
+			mux.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	return total, ctx.Err()
+}
+
+// runouts calls fn for every combination of n cards of deck written
+// to out.
+func runouts(deck []card.Card, n int, out []card.Card, fn func()) {
+	if n == 0 {
+		fn()
+		return
+	}
+	for i := 0; i <= len(deck)-n; i++ {
+		out[0] = deck[i]
+		runouts(deck[i+1:], n-1, out[1:], fn)
+	}
+}
+
+// maxConflicts is number of attempts to sample matchup of combos
+// without shared cards.
+const maxConflicts = 10_000
+
+// sampleBatch is number of showdowns sampled by worker between checks
+// of standard error.
+const sampleBatch = 10_000
+
+// sample evaluates random matchups of combos drawn by their weights on
+// random runouts until standard error is below StdErr.
+func (c *Calculator) sample(ctx context.Context, combos [][]combo, deck []card.Card, missing int) (*acc, error) {
+	cums := make([][]float64, len(combos))
+	for i, cc := range combos {
+		var sum float64
+		for _, cb := range cc {
+			sum += cb.weight
+			cums[i] = append(cums[i], sum)
+		}
+	}
+
+	var mux sync.Mutex
+	var wg sync.WaitGroup
+	var done atomic.Bool
+	var ferr error
+
+	total := newAcc(combos)
+
+	for w := 0; w < c.Workers; w++ {
+		rng := frand.NewUnsafeInt(c.Rng.Int63())
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			s := &showdown{ranks: make([]uint32, len(combos))}
+			players := make([][2]card.Card, len(combos))
+			idx := make([]int, len(combos))
+			board := make([]card.Card, 5)
+			copy(board, c.Board)
+
+			for !done.Load() && ctx.Err() == nil {
+				a := newAcc(combos)
+
+				for n := 0; n < sampleBatch; n++ {
+					used, ok := uint64(0), false
+					for t := 0; t < maxConflicts && !ok; t++ {
+						used, ok = 0, true
+						for i, cum := range cums {
+							k := sort.SearchFloat64s(cum, rng.Float64()*cum[len(cum)-1])
+							if k == len(cum) {
+								k--
+							}
+							cb := combos[i][k]
+							if cb.mask&used != 0 {
+								ok = false
+								break
+							}
+							players[i], idx[i] = cb.cards, k
+							used |= cb.mask
+						}
+					}
+					if !ok {
+						mux.Lock()
+						ferr = errors.New("ranges conflict")
+						mux.Unlock()
+						done.Store(true)
+						return
+					}
+
+					for k := 5 - missing; k < 5; {
+						cd := deck[rng.Intn(len(deck))]
+						if used&(1<<cd) != 0 {
+							continue
+						}
+						used |= 1 << cd
+						board[k] = cd
+						k++
+					}
+
+					s.eval(a, players, idx, board, 1, true)
+				}
+
+				mux.Lock()
+				total.merge(a)
+				if total.n >= c.MaxSamples || total.converged(c.StdErr) {
+					done.Store(true)
+				}
+				mux.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if ferr != nil {
+		return nil, ferr
+	}
+
+	return total, ctx.Err()
+}
@@ -0,0 +1,126 @@
+package equity
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/stretchr/testify/require"
+)
+
+func ranges(t *testing.T, ss ...string) []card.RangeDist {
+	var rs []card.RangeDist
+	for _, s := range ss {
+		r, err := card.NewRangeDistFromString(s)
+		require.NoError(t, err)
+		rs = append(rs, r)
+	}
+	return rs
+}
+
+func TestCalculatePreflop(t *testing.T) {
+	c := &Calculator{Workers: 4}
+
+	res, err := c.Calculate(context.Background(), ranges(t, "AhAs", "KcKd")...)
+	require.NoError(t, err)
+	require.True(t, res.Exact)
+	require.Equal(t, uint64(card.CombinationsLen(48, 5)), res.Showdowns)
+
+	require.InDelta(t, 0.82, res.Ranges[0].EV, 0.01)
+	require.InDelta(t, 1, res.Ranges[0].EV+res.Ranges[1].EV, 1e-9)
+	require.InDelta(t, res.Ranges[0].Tie, res.Ranges[1].Tie, 1e-9)
+}
+
+func TestCalculateRiver(t *testing.T) {
+	c := &Calculator{
+		Board: card.NewCardsFromString("ah 7d 2c 9s 4h"),
+		Dead:  card.NewCardsFromString("ks"),
+	}
+
+	res, err := c.Calculate(context.Background(), ranges(t, "AA, 22", "KK, 77:0.5")...)
+	require.NoError(t, err)
+	require.True(t, res.Exact)
+
+	// Board and dead cards block combos.
+	require.Len(t, res.Ranges[0].Combos, 6)
+	require.Len(t, res.Ranges[1].Combos, 6)
+
+	for _, ce := range res.Ranges[0].Combos {
+		if ce.Cards[0].Rank() == card.Ace {
+			require.Equal(t, 1., ce.EV)
+		} else {
+			require.InDelta(t, 2./3, ce.Win, 1e-9)
+		}
+	}
+
+	// 22 beats KK only, a third of weight of villain is 77.
+	require.InDelta(t, 0.5+0.5*2/3, res.Ranges[0].EV, 1e-9)
+}
+
+func TestCalculateSampled(t *testing.T) {
+	c := &Calculator{
+		Board:   card.NewCardsFromString("ah 7d 2c 9s"),
+		Workers: 4,
+	}
+	rs := ranges(t, "AK, QQ, 98s", "77, T8s, AJ:0.5")
+
+	exact, err := c.Calculate(context.Background(), rs...)
+	require.NoError(t, err)
+	require.True(t, exact.Exact)
+
+	c.ExactLimit = 1
+	c.StdErr = 0.002
+	c.Rng = frand.NewUnsafeInt(1)
+
+	sampled, err := c.Calculate(context.Background(), rs...)
+	require.NoError(t, err)
+	require.False(t, sampled.Exact)
+
+	for i := range rs {
+		se := sampled.Ranges[i].StdErr
+		require.Greater(t, se, 0.)
+		require.Less(t, se, 0.002)
+		require.InDelta(t, exact.Ranges[i].EV, sampled.Ranges[i].EV, 5*se)
+	}
+}
+
+func TestCalculateMultiway(t *testing.T) {
+	c := &Calculator{
+		Board:  card.NewCardsFromString("ah 7d 2c"),
+		StdErr: 0.005,
+		Rng:    frand.NewUnsafeInt(1),
+	}
+
+	res, err := c.Calculate(context.Background(), ranges(t, "22+", "A2s+, KQ", "76s, T9s, JTs")...)
+	require.NoError(t, err)
+	require.Len(t, res.Ranges, 3)
+
+	var sum float64
+	for _, r := range res.Ranges {
+		sum += r.EV
+	}
+	require.InDelta(t, 1, sum, 1e-9)
+}
+
+func TestCalculateErrors(t *testing.T) {
+	ctx := context.Background()
+	aa := ranges(t, "AA")[0]
+
+	_, err := (&Calculator{}).Calculate(ctx, aa)
+	require.Error(t, err)
+
+	_, err = (&Calculator{Board: card.NewCardsFromString("ah 7d")}).Calculate(ctx, aa, aa)
+	require.Error(t, err)
+
+	_, err = (&Calculator{Board: card.NewCardsFromString("ah 7d 2c"), Dead: card.NewCardsFromString("7d")}).Calculate(ctx, aa, aa)
+	require.Error(t, err)
+
+	// Every combo is blocked by board.
+	_, err = (&Calculator{Board: card.NewCardsFromString("ah ad 2c")}).Calculate(ctx, ranges(t, "AhAs", "KK")...)
+	require.Error(t, err)
+
+	// Combos always share cards.
+	_, err = (&Calculator{Board: card.NewCardsFromString("ah 7d 2c 9s 4h")}).Calculate(ctx, ranges(t, "AsKs", "AsQs")...)
+	require.Error(t, err)
+}
@@ -58,11 +58,31 @@ func Eval(cards ...card.Card) (card.HandRank, error) {
 	return card.NewHandRank(tp, rank), nil
 }
 
+// EvalVariant evaluates hand with rankings of the variant. Omaha
+// hands are four hole cards followed by the board. Returned ranks
+// can be compared only with ranks of the same variant.
+func EvalVariant(v card.Variant, cards ...card.Card) (card.HandRank, error) {
+	switch {
+	case v.Short():
+		return evalShort(v, cards)
+	case v == card.Omaha:
+		return evalOmaha(cards)
+	default:
+		return Eval(cards...)
+	}
+}
+
 func Judge(ccc []card.Cards) ([]uint8, error) {
+	return JudgeVariant(card.Holdem, ccc)
+}
+
+// JudgeVariant returns indexes of winning hands with rankings of
+// the variant.
+func JudgeVariant(v card.Variant, ccc []card.Cards) ([]uint8, error) {
 	ranks := make([]card.HandRank, 0, len(ccc))
 
 	for _, cc := range ccc {
-		rank, err := Eval(cc...)
+		rank, err := EvalVariant(v, cc...)
 		if err != nil {
 			return nil, err
 		}
@@ -96,15 +116,23 @@ func MustJudge(ccc []card.Cards) []uint8 {
 }
 
 func JudgeBoard(hole []card.Cards, board card.Cards) ([]uint8, error) {
+	return JudgeBoardVariant(card.Holdem, hole, board)
+}
+
+func JudgeBoardVariant(v card.Variant, hole []card.Cards, board card.Cards) ([]uint8, error) {
 	var ccc []card.Cards
 	for _, h := range hole {
 		ccc = append(ccc, append(h, board...))
 	}
-	return Judge(ccc)
+	return JudgeVariant(v, ccc)
 }
 
 func MustJudgeBoard(hole []card.Cards, board card.Cards) []uint8 {
-	winners, err := JudgeBoard(hole, board)
+	return MustJudgeBoardVariant(card.Holdem, hole, board)
+}
+
+func MustJudgeBoardVariant(v card.Variant, hole []card.Cards, board card.Cards) []uint8 {
+	winners, err := JudgeBoardVariant(v, hole, board)
 	if err != nil {
 		panic(err)
 	}
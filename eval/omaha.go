@@ -0,0 +1,77 @@
+package eval
+
+import (
+	"errors"
+
+	"github.com/pokerdroid/poker/card"
+)
+
+// boardTriples are indexes of three card combinations of a board with
+// up to five cards, first 1, 4 and 10 of them cover flop, turn and river.
+var boardTriples = [10][3]uint8{
+	{0, 1, 2},
+	{0, 1, 3}, {0, 2, 3}, {1, 2, 3},
+	{0, 1, 4}, {0, 2, 4}, {0, 3, 4}, {1, 2, 4}, {1, 3, 4}, {2, 3, 4},
+}
+
+var triplesCount = [6]int{3: 1, 4: 4, 5: 10}
+
+// EvalOmaha evaluates omaha hand made of exactly two hole cards and
+// three cards of the board. Board can have 3 to 5 cards.
+func EvalOmaha(hole card.OmahaHand, board card.Cards) (card.HandRank, error) {
+	if len(board) < 3 || len(board) > 5 {
+		return card.HandRank{}, errors.New("board can be 3,4 or 5 length")
+	}
+
+	// Walk ranks table from hole pair, so pair prefix is shared by
+	// all board triples.
+	var best uint32
+	triples := boardTriples[:triplesCount[len(board)]]
+
+	for _, h := range hole.Pairs() {
+		p2 := evalCard(evalCard(53+uint32(h[0]), ranks)+uint32(h[1]), ranks)
+		for _, t := range triples {
+			p := evalCard(p2+uint32(board[t[0]]), ranks)
+			p = evalCard(p+uint32(board[t[1]]), ranks)
+			p = evalCard(p+uint32(board[t[2]]), ranks)
+			p = evalCard(p, ranks)
+			if p > best {
+				best = p
+			}
+		}
+	}
+
+	tp := card.HandRankKind(best >> 12)
+	if tp == 0 {
+		return card.HandRank{}, errors.New("wrong cards")
+	}
+
+	r := card.NewHandRank(tp, best&0x00000fff)
+	r.Variant = card.Omaha
+	return r, nil
+}
+
+// evalOmaha evaluates four hole cards followed by the board. Hole
+// cards alone rank as pair or high card like two card hands.
+func evalOmaha(cards card.Cards) (card.HandRank, error) {
+	if len(cards) < 4 {
+		return card.HandRank{}, errors.New("omaha needs 4 hole cards")
+	}
+
+	hole, err := card.NewOmahaHand(cards[:4])
+	if err != nil {
+		return card.HandRank{}, err
+	}
+
+	if len(cards) == 4 {
+		kind := card.HandRankHighCard
+		for _, p := range hole.Pairs() {
+			if p[0].Rank() == p[1].Rank() {
+				kind = card.HandRankOnePair
+			}
+		}
+		return card.HandRank{Kind: kind, Variant: card.Omaha}, nil
+	}
+
+	return EvalOmaha(hole, cards[4:])
+}
@@ -0,0 +1,58 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/pokerdroid/poker/card"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalOmaha(t *testing.T) {
+	eval := func(s string) card.HandRank {
+		r, err := EvalVariant(card.Omaha, card.NewCardsFromString(s)...)
+		require.NoError(t, err)
+		return r
+	}
+
+	// Exactly two hole cards play, trips in hand are just a pair.
+	r := eval("Ah Ad Ac 2c Ks Kd 7h 8h 9d")
+	require.Equal(t, card.HandRankTwoPairs, r.Kind)
+
+	// Single suited hole card doesn't make flush with four on board.
+	r = eval("Ah Kd Qc 2c 3h 7h 8h 9h Td")
+	require.NotEqual(t, card.HandRankFlush, r.Kind)
+
+	r = eval("Ah Kh Qc 2c 3h 7h 8d 9h Td")
+	require.Equal(t, card.HandRankFlush, r.Kind)
+
+	// Flop and turn boards.
+	r = eval("As Ks Qh Jh Ts 9s 8s")
+	require.Equal(t, card.HandRankFlush, r.Kind)
+
+	r = eval("As Ks Qh Jh Ts 9d 8c 2c")
+	require.Equal(t, card.HandRankStraight, r.Kind)
+
+	r = eval("As Ad Qh Jh")
+	require.Equal(t, card.HandRankOnePair, r.Kind)
+
+	_, err := EvalVariant(card.Omaha, card.NewCardsFromString("As Ks Qh Ts 9s")...)
+	require.Error(t, err)
+
+	_, err = card.NewOmahaHand(card.NewCardsFromString("As As Qh Jh"))
+	require.Error(t, err)
+
+	winners, err := JudgeBoardVariant(card.Omaha, []card.Cards{
+		card.NewCardsFromString("Ah Ad Ac 2c"),
+		card.NewCardsFromString("Qs Js 3c 2d"),
+	}, card.NewCardsFromString("Ks Kd 7h 8h 9d"))
+	require.NoError(t, err)
+	require.Equal(t, []uint8{0}, winners)
+}
+
+func BenchmarkEvalOmaha(b *testing.B) {
+	hole, _ := card.NewOmahaHand(card.NewCardsFromString("Ah Kh Qc 2c"))
+	board := card.NewCardsFromString("3h 7h 8d 9h Td")
+	for i := 0; i < b.N; i++ {
+		_, _ = EvalOmaha(hole, board)
+	}
+}
@@ -0,0 +1,175 @@
+package eval
+
+import (
+	"errors"
+	"math/bits"
+
+	"github.com/pokerdroid/poker/card"
+)
+
+// Short deck hands are evaluated from rank counts and suit masks,
+// bit i of a mask is rank Six+i.
+const shortRanks = 9
+
+// shortStraights maps rank mask to 1 + index of the top card of the
+// best straight, zero if there is none. A-6-7-8-9 tops at nine.
+var shortStraights [1 << shortRanks]uint8
+
+func init() {
+	for m := range shortStraights {
+		for top := shortRanks - 1; top >= 4; top-- {
+			w := 0x1f << (top - 4)
+			if m&w == w {
+				shortStraights[m] = uint8(top + 1)
+				break
+			}
+		}
+		// Ace plays low below six.
+		w := 1<<(shortRanks-1) | 0xf
+		if shortStraights[m] == 0 && m&w == w {
+			shortStraights[m] = 4
+		}
+	}
+}
+
+func evalShort(v card.Variant, cards card.Cards) (card.HandRank, error) {
+	size := len(cards)
+	if size != 7 && size != 6 && size != 5 && size != 2 {
+		return card.HandRank{}, errors.New("cards can be 7,6,5 or 2 length")
+	}
+
+	var counts [shortRanks]uint8
+	var suits [4]uint16
+	var ranks uint16
+
+	for _, c := range cards {
+		if c == card.Card00 || c > card.CardAS || c.Rank() < card.Six {
+			return card.HandRank{}, errors.New("wrong cards")
+		}
+		r := int(c.Rank() - card.Six)
+		counts[r]++
+		suits[c.Suite()-card.Clubs] |= 1 << r
+		ranks |= 1 << r
+	}
+
+	if size == 2 {
+		kind := card.HandRankHighCard
+		if counts[cards[0].Rank()-card.Six] == 2 {
+			kind = card.HandRankOnePair
+		}
+		return card.HandRank{Kind: kind, Variant: v}, nil
+	}
+
+	// Ranks with the given count from the highest.
+	var quads, trips, pairs []uint32
+	for r := shortRanks - 1; r >= 0; r-- {
+		switch counts[r] {
+		case 4:
+			quads = append(quads, uint32(r+1))
+		case 3:
+			trips = append(trips, uint32(r+1))
+		case 2:
+			pairs = append(pairs, uint32(r+1))
+		}
+	}
+
+	hand := func(kind card.HandRankKind, rank uint32) (card.HandRank, error) {
+		return card.HandRank{Kind: kind, Rank: rank, Variant: v}, nil
+	}
+
+	var flush uint16
+	for _, s := range suits {
+		if bits.OnesCount16(s) >= 5 {
+			flush = s
+		}
+	}
+
+	if flush != 0 {
+		if top := shortStraights[flush]; top != 0 {
+			return hand(card.HandRankStraightFlush, uint32(top))
+		}
+	}
+
+	if len(quads) > 0 {
+		return hand(card.HandRankFourOfaKind, pack(quads[0], kickers(ranks, 1, quads[0])...))
+	}
+
+	full := card.HandRank{}
+	if len(trips) > 0 && len(trips)+len(pairs) > 1 {
+		// Second trips play as a pair.
+		pair := uint32(0)
+		if len(trips) > 1 {
+			pair = trips[1]
+		}
+		if len(pairs) > 0 && pairs[0] > pair {
+			pair = pairs[0]
+		}
+		full = card.HandRank{Kind: card.HandRankFullHouse, Rank: pack(trips[0], pair), Variant: v}
+	}
+
+	// Flush beats full house.
+	if flush != 0 {
+		return hand(card.HandRankFlush, pack(0, kickers(flush, 5)...))
+	}
+
+	if !full.Empty() {
+		return full, nil
+	}
+
+	var straight, three card.HandRank
+	if top := shortStraights[ranks]; top != 0 {
+		straight = card.HandRank{Kind: card.HandRankStraight, Rank: uint32(top), Variant: v}
+	}
+	if len(trips) > 0 {
+		three = card.HandRank{Kind: card.HandRankThreeOfaKind, Rank: pack(trips[0], kickers(ranks, 2, trips[0])...), Variant: v}
+	}
+
+	switch {
+	case straight.Empty() && !three.Empty():
+		return three, nil
+	case three.Empty() && !straight.Empty():
+		return straight, nil
+	case !three.Empty():
+		if three.Compare(straight) == 0 {
+			return three, nil
+		}
+		return straight, nil
+	}
+
+	switch {
+	case len(pairs) > 1:
+		return hand(card.HandRankTwoPairs, pack(pairs[0], append([]uint32{pairs[1]}, kickers(ranks, 1, pairs[0], pairs[1])...)...))
+	case len(pairs) == 1:
+		return hand(card.HandRankOnePair, pack(pairs[0], kickers(ranks, 3, pairs[0])...))
+	default:
+		return hand(card.HandRankHighCard, pack(0, kickers(ranks, 5)...))
+	}
+}
+
+// kickers returns n highest ranks of mask, except the skipped ones.
+func kickers(mask uint16, n int, skip ...uint32) []uint32 {
+	var out []uint32
+	for r := shortRanks - 1; r >= 0 && len(out) < n; r-- {
+		if mask&(1<<r) == 0 {
+			continue
+		}
+		v := uint32(r + 1)
+		skipped := false
+		for _, s := range skip {
+			skipped = skipped || s == v
+		}
+		if !skipped {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// pack packs ranks into 4 bits each, the first is the most significant.
+func pack(first uint32, rest ...uint32) uint32 {
+	r := first
+	for _, x := range rest {
+		r = r<<4 | x
+	}
+	return r
+}
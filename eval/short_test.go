@@ -0,0 +1,94 @@
+package eval
+
+import (
+	"testing"
+
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalShortDeck(t *testing.T) {
+	eval := func(v card.Variant, s string) card.HandRank {
+		r, err := EvalVariant(v, card.NewCardsFromString(s)...)
+		require.NoError(t, err)
+		return r
+	}
+
+	wheel := eval(card.ShortDeck, "As 6h 7d 8c 9s Jd Kd")
+	require.Equal(t, card.HandRankStraight, wheel.Kind)
+
+	low := eval(card.ShortDeck, "6s 7h 8d 9c Ts Jd Kd")
+	require.Equal(t, card.HandRankStraight, low.Kind)
+	require.Equal(t, 0, low.Compare(wheel))
+
+	flush := eval(card.ShortDeck, "6h 7h 8h Th Qh Qd Qs")
+	require.Equal(t, card.HandRankFlush, flush.Kind)
+
+	full := eval(card.ShortDeck, "Ah Ad As Kh Kd 6c 7c")
+	require.Equal(t, card.HandRankFullHouse, full.Kind)
+	require.Equal(t, 0, flush.Compare(full))
+
+	// Three of a kind beats straight unless straights variant.
+	trips := eval(card.ShortDeck, "6h 6d 6s Kh Qd 8c 7c")
+	require.Equal(t, 0, trips.Compare(wheel))
+
+	trips = eval(card.ShortDeckStraights, "6h 6d 6s Kh Qd 8c 7c")
+	wheel = eval(card.ShortDeckStraights, "As 6h 7d 8c 9s Jd Kd")
+	require.Equal(t, 1, trips.Compare(wheel))
+
+	// Hand with both plays the stronger one.
+	both := eval(card.ShortDeck, "9h 9d 9s 6c 7c 8h Ad")
+	require.Equal(t, card.HandRankThreeOfaKind, both.Kind)
+	both = eval(card.ShortDeckStraights, "9h 9d 9s 6c 7c 8h Ad")
+	require.Equal(t, card.HandRankStraight, both.Kind)
+
+	sf := eval(card.ShortDeck, "Ah 6h 7h 8h 9h 9d 9s")
+	require.Equal(t, card.HandRankStraightFlush, sf.Kind)
+
+	_, err := EvalVariant(card.ShortDeck, card.NewCardsFromString("2h 6h 7h 8h 9h")...)
+	require.Error(t, err)
+
+	winners, err := JudgeBoardVariant(card.ShortDeck, []card.Cards{
+		card.NewCardsFromString("As Kd"),
+		card.NewCardsFromString("Th Jc"),
+	}, card.NewCardsFromString("6h 7d 8c 9s Qh"))
+	require.NoError(t, err)
+	require.Equal(t, []uint8{1}, winners)
+}
+
+// Hands of kinds which keep their order must compare as in holdem.
+func TestEvalShortDeckAgrees(t *testing.T) {
+	rng := frand.NewUnsafe()
+	deck := card.ShortDeck.Cards()
+
+	same := map[card.HandRankKind]bool{
+		card.HandRankHighCard:    true,
+		card.HandRankOnePair:     true,
+		card.HandRankTwoPairs:    true,
+		card.HandRankFourOfaKind: true,
+	}
+
+	for i := 0; i < 20_000; i++ {
+		perm := rng.Perm(len(deck))
+		h1 := card.Cards{deck[perm[0]], deck[perm[1]], deck[perm[4]], deck[perm[5]], deck[perm[6]], deck[perm[7]], deck[perm[8]]}
+		h2 := card.Cards{deck[perm[2]], deck[perm[3]], deck[perm[4]], deck[perm[5]], deck[perm[6]], deck[perm[7]], deck[perm[8]]}
+
+		s1, err := EvalVariant(card.ShortDeck, h1...)
+		require.NoError(t, err)
+		s2, err := EvalVariant(card.ShortDeck, h2...)
+		require.NoError(t, err)
+
+		r1, _ := Eval(h1...)
+		r2, _ := Eval(h2...)
+
+		if s1.Kind != r1.Kind || s2.Kind != r2.Kind {
+			continue
+		}
+
+		if (s1.Kind == s2.Kind && s1.Kind != card.HandRankStraight && s1.Kind != card.HandRankStraightFlush) ||
+			(same[s1.Kind] && same[s2.Kind]) {
+			require.Equal(t, r1.Compare(r2), s1.Compare(s2), "%s vs %s", h1, h2)
+		}
+	}
+}
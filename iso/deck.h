@@ -13,8 +13,10 @@
 #include <inttypes.h>
 
 #define SUITS     4
+#ifndef RANKS
 #define RANKS    13
-#define CARDS    52
+#endif
+#define CARDS    (SUITS*RANKS)
 
 typedef uint_fast32_t card_t;
 
@@ -3,6 +3,7 @@ package iso
 /*
 #cgo CFLAGS: -Wno-pointer-bool-conversion
 #include "hand_index.h"
+#include "short_index.h"
 */
 import "C"
 import (
@@ -26,18 +27,41 @@ func uintToCard(val uint8) card.Card {
 	return card.Card(1 + rank*4 + suit)
 }
 
+// shortOffset shifts short deck cards so six is the lowest rank.
+const shortOffset = 4 * uint8(card.Six-card.Two)
+
 type Indexer struct {
 	ptr *C.hand_indexer_t
+	// short indexes 36 card deck
+	short bool
 }
 
 func New(rounds int, cardsPerRound []uint8) (*Indexer, error) {
-	indexer := &Indexer{ptr: &C.hand_indexer_t{}}
+	return newIndexer(rounds, cardsPerRound, false)
+}
 
-	success := C.hand_indexer_init(
-		C.uint_fast32_t(rounds),
-		(*C.uint8_t)(&cardsPerRound[0]),
-		indexer.ptr,
-	)
+// NewShortDeck creates indexer of 36 card short deck.
+func NewShortDeck(rounds int, cardsPerRound []uint8) (*Indexer, error) {
+	return newIndexer(rounds, cardsPerRound, true)
+}
+
+func newIndexer(rounds int, cardsPerRound []uint8, short bool) (*Indexer, error) {
+	indexer := &Indexer{ptr: &C.hand_indexer_t{}, short: short}
+
+	var success C._Bool
+	if short {
+		success = C.short_hand_indexer_init(
+			C.uint_fast32_t(rounds),
+			(*C.uint8_t)(&cardsPerRound[0]),
+			indexer.ptr,
+		)
+	} else {
+		success = C.hand_indexer_init(
+			C.uint_fast32_t(rounds),
+			(*C.uint8_t)(&cardsPerRound[0]),
+			indexer.ptr,
+		)
+	}
 
 	if !success {
 		return nil, fmt.Errorf("failed to initialize hand indexer")
@@ -55,21 +79,49 @@ func MustNew(rounds int, cardsPerRound []uint8) *Indexer {
 	return ind
 }
 
+func MustNewShortDeck(rounds int, cardsPerRound []uint8) *Indexer {
+	ind, err := NewShortDeck(rounds, cardsPerRound)
+	if err != nil {
+		panic(err)
+	}
+	return ind
+}
+
 func (hi *Indexer) Index(cards card.Cards) uint64 {
 	cc := make([]uint8, len(cards))
 	for i, r := range cards {
 		cc[i] = cardToUint(r)
 	}
+
+	if hi.short {
+		for i := range cc {
+			cc[i] -= shortOffset
+		}
+		return uint64(C.short_hand_index_last(hi.ptr, (*C.uint8_t)(&cc[0])))
+	}
+
 	return uint64(C.hand_index_last(hi.ptr, (*C.uint8_t)(&cc[0])))
 }
 
 func (hi *Indexer) Size(round int) int {
+	if hi.short {
+		return int(C.short_hand_indexer_size(hi.ptr, C.uint_fast32_t(round)))
+	}
 	return int(C.hand_indexer_size(hi.ptr, C.uint_fast32_t(round)))
 }
 
 func (hi *Indexer) Unindex(round int, index uint64, size int) card.Cards {
 	cards := make([]uint8, size)
-	C.hand_unindex(hi.ptr, C.uint_fast32_t(round), C.hand_index_t(index), (*C.uint8_t)(&cards[0]))
+
+	if hi.short {
+		C.short_hand_unindex(hi.ptr, C.uint_fast32_t(round), C.hand_index_t(index), (*C.uint8_t)(&cards[0]))
+		for i := range cards {
+			cards[i] += shortOffset
+		}
+	} else {
+		C.hand_unindex(hi.ptr, C.uint_fast32_t(round), C.hand_index_t(index), (*C.uint8_t)(&cards[0]))
+	}
+
 	cx := cards[:]
 	xx := make(card.Cards, len(cx))
 	for i, a := range cx {
@@ -79,5 +131,9 @@ func (hi *Indexer) Unindex(round int, index uint64, size int) card.Cards {
 }
 
 func (hi *Indexer) free() {
+	if hi.short {
+		C.short_hand_indexer_free(hi.ptr)
+		return
+	}
 	C.hand_indexer_free(hi.ptr)
 }
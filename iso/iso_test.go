@@ -35,3 +35,30 @@ func TestPreflopLookup(t *testing.T) {
 		require.Equal(t, preflopLookup[[2]card.Card{c[0], c[1]}], idx)
 	}
 }
+
+func TestShortDeck(t *testing.T) {
+	require.Equal(t, 81, ShortPreflop.Size())
+	require.Equal(t, 186696, ShortFlop.Size())
+	require.Equal(t, 1340856, ShortTurn.Size())
+	require.Equal(t, 7723728, ShortRiver.Size())
+
+	// Every hand maps to index which unindexes to isomorphic hand.
+	deck := card.ShortDeck.Cards()
+	for _, c := range card.CombinationsFrom(deck, 2) {
+		idx := ShortPreflop.Index(c)
+		require.Less(t, int(idx), ShortPreflop.Size())
+
+		back := ShortPreflop.Unindex(uint64(idx))
+		require.Equal(t, idx, ShortPreflop.Index(back))
+		require.GreaterOrEqual(t, back[0].Rank(), card.Six)
+		require.GreaterOrEqual(t, back[1].Rank(), card.Six)
+	}
+
+	flop := card.NewCardsFromString("As Kd 6h 7h Th")
+	idx := ShortFlop.Index(flop)
+	require.Less(t, int(idx), ShortFlop.Size())
+	require.Equal(t, idx, ShortFlop.Index(ShortFlop.Unindex(uint64(idx))))
+
+	require.Equal(t, River, Variant(card.Holdem).River)
+	require.Equal(t, ShortRiver, Variant(card.ShortDeck).Street(7))
+}
@@ -0,0 +1,27 @@
+/**
+ * short_index.c
+ *
+ * hand_index.c compiled for nine ranks, public and helper symbols
+ * are prefixed so both indexers link into one binary
+ */
+
+#define RANKS 9
+
+#define hand_indexer_init          short_hand_indexer_init
+#define hand_indexer_free          short_hand_indexer_free
+#define hand_indexer_size          short_hand_indexer_size
+#define hand_indexer_state_init    short_hand_indexer_state_init
+#define hand_index_all             short_hand_index_all
+#define hand_index_last            short_hand_index_last
+#define hand_index_next_round      short_hand_index_next_round
+#define hand_unindex               short_hand_unindex
+#define enumerate_configurations_r short_enumerate_configurations_r
+#define enumerate_configurations   short_enumerate_configurations
+#define count_configurations       short_count_configurations
+#define tabulate_configurations    short_tabulate_configurations
+#define enumerate_permutations_r   short_enumerate_permutations_r
+#define enumerate_permutations     short_enumerate_permutations
+#define count_permutations         short_count_permutations
+#define tabulate_permutations      short_tabulate_permutations
+
+#include "hand_index.c"
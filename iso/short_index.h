@@ -0,0 +1,19 @@
+/**
+ * short_index.h
+ *
+ * hand indexer of 36 card short deck, cards are ranks six to ace
+ * with the same suit layout as deck.h
+ */
+
+#ifndef _SHORT_INDEX_H_
+#define _SHORT_INDEX_H_
+
+#include "hand_index.h"
+
+_Bool short_hand_indexer_init(uint_fast32_t rounds, const uint8_t cards_per_round[], hand_indexer_t * indexer);
+void short_hand_indexer_free(hand_indexer_t * indexer);
+hand_index_t short_hand_indexer_size(const hand_indexer_t * indexer, uint_fast32_t round);
+hand_index_t short_hand_index_last(const hand_indexer_t * indexer, const uint8_t cards[]);
+_Bool short_hand_unindex(const hand_indexer_t * indexer, uint_fast32_t round, hand_index_t index, uint8_t cards[]);
+
+#endif /* _SHORT_INDEX_H_ */
@@ -8,22 +8,63 @@ type Street struct {
 	indx  *Indexer
 	rx    int
 	cards int
+	// lookup of preflop index, indexer is used when nil
+	lookup map[[2]card.Card]uint32
 }
 
 var (
 	River   = &Street{indx: MustNew(2, []uint8{2, 5}), rx: 1, cards: 7}
 	Turn    = &Street{indx: MustNew(2, []uint8{2, 4}), rx: 1, cards: 6}
 	Flop    = &Street{indx: MustNew(2, []uint8{2, 3}), rx: 1, cards: 5}
-	Preflop = &Street{indx: MustNew(1, []uint8{2}), rx: 0, cards: 2}
+	Preflop = &Street{indx: MustNew(1, []uint8{2}), rx: 0, cards: 2, lookup: preflopLookup}
 )
 
+var (
+	ShortRiver   = &Street{indx: MustNewShortDeck(2, []uint8{2, 5}), rx: 1, cards: 7}
+	ShortTurn    = &Street{indx: MustNewShortDeck(2, []uint8{2, 4}), rx: 1, cards: 6}
+	ShortFlop    = &Street{indx: MustNewShortDeck(2, []uint8{2, 3}), rx: 1, cards: 5}
+	ShortPreflop = &Street{indx: MustNewShortDeck(1, []uint8{2}), rx: 0, cards: 2}
+)
+
+// Streets holds indexers of every street of a variant.
+type Streets struct {
+	Preflop *Street
+	Flop    *Street
+	Turn    *Street
+	River   *Street
+}
+
+// Variant returns indexers of the variant deck.
+func Variant(v card.Variant) Streets {
+	if v.Short() {
+		return Streets{Preflop: ShortPreflop, Flop: ShortFlop, Turn: ShortTurn, River: ShortRiver}
+	}
+	return Streets{Preflop: Preflop, Flop: Flop, Turn: Turn, River: River}
+}
+
+// Street returns indexer of hand with n cards.
+func (s Streets) Street(n int) *Street {
+	switch n {
+	case 2:
+		return s.Preflop
+	case 5:
+		return s.Flop
+	case 6:
+		return s.Turn
+	case 7:
+		return s.River
+	default:
+		panic("invalid number of cards")
+	}
+}
+
 func (hi *Street) Size() int {
 	return hi.indx.Size(hi.rx)
 }
 
 func (hi *Street) Index(cards card.Cards) uint32 {
-	if hi.cards == 2 {
-		return preflopLookup[[2]card.Card{cards[0], cards[1]}]
+	if hi.lookup != nil {
+		return hi.lookup[[2]card.Card{cards[0], cards[1]}]
 	}
 	return uint32(hi.indx.Index(cards))
 }
@@ -0,0 +1,155 @@
+package pushfold
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/pokerdroid/poker/card"
+)
+
+// Spot identifies decision in push/fold game. Seats are numbered in
+// acting order, 0 acts first and the last one is big blind.
+type Spot struct {
+	Players uint8 `json:"players"`
+	Seat    uint8 `json:"seat"`
+	// AllIn is bitmask of earlier seats which pushed or called.
+	// Seat facing no all-in decides to push, otherwise to call.
+	AllIn uint8 `json:"allin"`
+}
+
+// Push returns true if nobody is all-in before the seat.
+func (s Spot) Push() bool {
+	return s.AllIn == 0
+}
+
+// SeatName returns name of seat in acting order.
+func SeatName(players, seat uint8) string {
+	switch {
+	case seat == players-1:
+		return "bb"
+	case seat == players-2:
+		return "sb"
+	case seat == players-3:
+		return "btn"
+	case seat == players-4:
+		return "co"
+	case seat == players-5:
+		return "hj"
+	default:
+		return "utg"
+	}
+}
+
+func (s Spot) String() string {
+	if s.Push() {
+		return SeatName(s.Players, s.Seat) + " push"
+	}
+
+	var in []string
+	for i := uint8(0); i < s.Seat; i++ {
+		if s.AllIn&(1<<i) != 0 {
+			in = append(in, SeatName(s.Players, i))
+		}
+	}
+
+	return SeatName(s.Players, s.Seat) + " call vs " + strings.Join(in, "+")
+}
+
+// Chart is equilibrium range of a spot at a stack depth.
+type Chart struct {
+	Spot
+	// Stack is effective stack in big blinds.
+	Stack float64 `json:"stack"`
+	// Range holds probability to push or call for hand classes.
+	Range card.Matrix `json:"range"`
+}
+
+// Freq returns probability to push or call with hole cards.
+func (c Chart) Freq(hole card.Cards) float64 {
+	x, y := card.Coordinates(hole)
+	return c.Range[x][y]
+}
+
+// Percent returns share of all combos played.
+func (c Chart) Percent() float64 {
+	var sum float64
+	for x := 0; x < 13; x++ {
+		for y := 0; y < 13; y++ {
+			sum += c.Range[x][y] * float64(len(card.CardsInCoords(x, y)))
+		}
+	}
+	return sum / 1326
+}
+
+func (c Chart) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("%s @ %gbb (%.1f%%)\n", c.Spot, c.Stack, c.Percent()*100))
+	for x := 0; x < 13; x++ {
+		for y := 0; y < 13; y++ {
+			name := card.CoordsName(x, y)
+			switch f := c.Range[x][y]; {
+			case f >= 0.995:
+				sb.WriteString(fmt.Sprintf(" %-4s", name))
+			case f <= 0.005:
+				sb.WriteString("  .  ")
+			default:
+				sb.WriteString(fmt.Sprintf(" %3.0f%%", f*100))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// Charts is collection of charts for different spots and stacks.
+type Charts []Chart
+
+// Find returns chart of spot with the closest stack.
+func (c Charts) Find(s Spot, stack float64) (Chart, bool) {
+	var best Chart
+	found := false
+
+	for _, ch := range c {
+		if ch.Spot != s {
+			continue
+		}
+		if !found || math.Abs(ch.Stack-stack) < math.Abs(best.Stack-stack) {
+			best = ch
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// MaxStack returns the deepest stack charts are solved for.
+func (c Charts) MaxStack() float64 {
+	var m float64
+	for _, ch := range c {
+		m = math.Max(m, ch.Stack)
+	}
+	return m
+}
+
+// NewChartsFromFile loads charts stored by WriteFile.
+func NewChartsFromFile(path string) (Charts, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Charts
+	return c, json.Unmarshal(data, &c)
+}
+
+func (c Charts) WriteFile(path string) error {
+	data, err := json.MarshalIndent(c, "", " ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,209 @@
+package pushfold
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/encbin"
+	"github.com/pokerdroid/poker/eval"
+	"github.com/pokerdroid/poker/frand"
+)
+
+// Classes is number of starting hand classes. Class index is
+// x*13+y of card.Matrix coordinates.
+const Classes = 169
+
+// Class returns hand class index of hole cards.
+func Class(hole card.Cards) int {
+	x, y := card.Coordinates(hole)
+	return x*13 + y
+}
+
+// ClassCards returns all combos of hand class.
+func ClassCards(c int) []card.Cards {
+	return card.CardsInCoords(c/13, c%13)
+}
+
+// Equity is preflop all-in equity of hand classes against each other
+// with card removal.
+type Equity struct {
+	// Eq is equity of first class against second, ties count half.
+	Eq [Classes][Classes]float32
+	// Combos is number of combo pairs of two classes without shared cards.
+	Combos [Classes][Classes]uint16
+}
+
+type EquityParams struct {
+	// Samples is number of sampled deals for each pair of classes.
+	Samples int
+	Workers int
+	Rng     frand.Rand
+}
+
+// NewEquity computes equity matrix sampling boards for every pair
+// of combos. Combo counts are exact.
+func NewEquity(ctx context.Context, p EquityParams) (*Equity, error) {
+	if p.Workers == 0 {
+		p.Workers = runtime.NumCPU()
+	}
+
+	if p.Rng == nil {
+		p.Rng = frand.NewHash()
+	}
+
+	e := &Equity{}
+
+	for i := 0; i < Classes; i++ {
+		for j := 0; j < Classes; j++ {
+			e.Combos[i][j] = uint16(countCombos(i, j))
+		}
+		e.Eq[i][i] = 0.5
+	}
+
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < p.Workers; w++ {
+		wg.Add(1)
+		go func(rng frand.Rand) {
+			defer wg.Done()
+			for i := range jobs {
+				for j := i + 1; j < Classes; j++ {
+					if e.Combos[i][j] == 0 {
+						continue
+					}
+					eq := sampleEquity(rng, i, j, p.Samples)
+					e.Eq[i][j] = eq
+					e.Eq[j][i] = 1 - eq
+				}
+			}
+		}(frand.Clone(p.Rng))
+	}
+
+LOOP:
+	for i := 0; i < Classes; i++ {
+		select {
+		case <-ctx.Done():
+			break LOOP
+		case jobs <- i:
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return e, ctx.Err()
+}
+
+func countCombos(i, j int) int {
+	var n int
+	for _, a := range ClassCards(i) {
+		for _, b := range ClassCards(j) {
+			if card.IsNotAnyMatch(a, b) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func sampleEquity(rng frand.Rand, i, j, samples int) float32 {
+	ci, cj := ClassCards(i), ClassCards(j)
+
+	var won float64
+	var n int
+
+	h1 := make(card.Cards, 7)
+	h2 := make(card.Cards, 7)
+
+	for n < samples {
+		a := ci[rng.Intn(len(ci))]
+		b := cj[rng.Intn(len(cj))]
+		if !card.IsNotAnyMatch(a, b) {
+			continue
+		}
+
+		used := uint64(1)<<a[0] | uint64(1)<<a[1] | uint64(1)<<b[0] | uint64(1)<<b[1]
+		h1[0], h1[1] = a[0], a[1]
+		h2[0], h2[1] = b[0], b[1]
+		dealBoard(rng, &used, h1[2:], h2[2:])
+
+		won += showdown(h1, h2)
+		n++
+	}
+
+	return float32(won / float64(samples))
+}
+
+// dealBoard deals five cards not in used to every board.
+func dealBoard(rng frand.Rand, used *uint64, boards ...card.Cards) {
+	for k := 0; k < 5; {
+		c := card.Card(rng.Intn(52) + 1)
+		if *used&(1<<c) != 0 {
+			continue
+		}
+		*used |= 1 << c
+		for _, b := range boards {
+			b[k] = c
+		}
+		k++
+	}
+}
+
+// showdown returns 1 if first hand wins, 0.5 for tie and 0 otherwise.
+func showdown(h1, h2 card.Cards) float64 {
+	r1, _ := eval.Eval(h1...)
+	r2, _ := eval.Eval(h2...)
+	switch r1.Compare(r2) {
+	case 0:
+		return 1
+	case 2:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+func (e *Equity) MarshalBinary() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	for i := range e.Eq {
+		err := encbin.MarshalValues(buf, e.Eq[i], e.Combos[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (e *Equity) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewReader(data)
+	for i := range e.Eq {
+		err := encbin.UnmarshalValues(buf, &e.Eq[i], &e.Combos[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewEquityFromFile loads equity matrix stored by WriteFile.
+func NewEquityFromFile(path string) (*Equity, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	e := &Equity{}
+	return e, e.UnmarshalBinary(data)
+}
+
+func (e *Equity) WriteFile(path string) error {
+	data, err := e.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,56 @@
+package pushfold
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	tstEquityOnce sync.Once
+	tstEquity     *Equity
+)
+
+// tstNewEquity returns coarse equity matrix shared by tests.
+func tstNewEquity(t *testing.T) *Equity {
+	tstEquityOnce.Do(func() {
+		var err error
+		tstEquity, err = NewEquity(context.Background(), EquityParams{
+			Samples: 300,
+			Rng:     frand.NewUnsafeInt(1),
+		})
+		require.NoError(t, err)
+	})
+	return tstEquity
+}
+
+func TestEquity(t *testing.T) {
+	e := tstNewEquity(t)
+
+	aa := Class(card.NewCardsFromString("As Ah"))
+	ak := Class(card.NewCardsFromString("As Ks"))
+	ako := Class(card.NewCardsFromString("As Kh"))
+	s72 := Class(card.NewCardsFromString("7d 2c"))
+
+	// Each of 6 AA combos leaves 2 aces for AK.
+	require.Equal(t, uint16(6*6), e.Combos[aa][ako])
+	require.Equal(t, uint16(6*2), e.Combos[aa][ak])
+	require.Equal(t, uint16(6), e.Combos[aa][aa])
+	require.Equal(t, e.Combos[aa][ak], e.Combos[ak][aa])
+
+	require.InDelta(t, 0.87, e.Eq[aa][s72], 0.03)
+	require.InDelta(t, 1, e.Eq[aa][s72]+e.Eq[s72][aa], 1e-6)
+	require.Equal(t, float32(0.5), e.Eq[ak][ak])
+
+	pth := filepath.Join(t.TempDir(), "eq.bin")
+	require.NoError(t, e.WriteFile(pth))
+
+	e2, err := NewEquityFromFile(pth)
+	require.NoError(t, err)
+	require.Equal(t, e, e2)
+}
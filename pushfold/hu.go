@@ -0,0 +1,229 @@
+package pushfold
+
+import (
+	"errors"
+	"math"
+
+	"github.com/pokerdroid/poker/card"
+)
+
+// Params of push/fold game. Amounts are in big blinds, small blind
+// is half of big blind.
+type Params struct {
+	// Stack is effective stack of every player, blinds and antes included.
+	Stack float64
+	// Ante posted by every player.
+	Ante float64
+	// Tolerance is exploitability in big blinds per hand to stop at.
+	Tolerance float64
+	// MaxIterations caps number of iterations.
+	MaxIterations int
+}
+
+func (p Params) Validate() error {
+	if p.Stack < 1+p.Ante {
+		return errors.New("stack must cover big blind and ante")
+	}
+	if p.MaxIterations <= 0 {
+		return errors.New("max iterations must be positive")
+	}
+	return nil
+}
+
+// Solution holds charts of every spot at one stack depth.
+type Solution struct {
+	Stack      float64
+	Charts     Charts
+	Exploit    float64
+	Iterations int
+}
+
+// hu is heads up push/fold game: small blind pushes or folds, big
+// blind calls or folds. Values are from small blind point of view.
+type hu struct {
+	e *Equity
+	p Params
+
+	// Regrets and strategy sums of fold (0) and push or call (1).
+	rsb, rbb [Classes][2]float64
+	ssb, sbb [Classes][2]float64
+
+	total float64
+}
+
+// SolveHU solves heads up push/fold with CFR+ on the equity matrix.
+// It stops once exploitability of average strategies is below
+// tolerance.
+func SolveHU(e *Equity, p Params) (Solution, error) {
+	if err := p.Validate(); err != nil {
+		return Solution{}, err
+	}
+
+	g := &hu{e: e, p: p}
+	for i := range e.Combos {
+		for j := range e.Combos[i] {
+			g.total += float64(e.Combos[i][j])
+		}
+	}
+
+	var sb, bb [Classes]float64
+	exploit := math.Inf(1)
+
+	it := 1
+	for ; it <= p.MaxIterations; it++ {
+		// Alternating updates, big blind sees updated small blind.
+		current(&g.rsb, &sb)
+		current(&g.rbb, &bb)
+		g.updateSB(&sb, &bb, it)
+
+		current(&g.rsb, &sb)
+		g.updateBB(&sb, it)
+
+		if it%50 == 0 || it == p.MaxIterations {
+			exploit = g.exploit()
+			if exploit <= p.Tolerance {
+				break
+			}
+		}
+	}
+
+	if it > p.MaxIterations {
+		it = p.MaxIterations
+	}
+
+	push, call := g.average()
+
+	return Solution{
+		Stack: p.Stack,
+		Charts: Charts{
+			{Spot: Spot{Players: 2, Seat: 0}, Stack: p.Stack, Range: push},
+			{Spot: Spot{Players: 2, Seat: 1, AllIn: 1}, Stack: p.Stack, Range: call},
+		},
+		Exploit:    exploit,
+		Iterations: it,
+	}, nil
+}
+
+// current sets probability of push or call from regrets.
+func current(r *[Classes][2]float64, s *[Classes]float64) {
+	for i := range r {
+		sum := r[i][0] + r[i][1]
+		if sum > 0 {
+			s[i] = r[i][1] / sum
+		} else {
+			s[i] = 0.5
+		}
+	}
+}
+
+// called is small blind result when big blind calls.
+func (g *hu) called(i, j int) float64 {
+	return float64(g.e.Eq[i][j])*2*g.p.Stack - g.p.Stack
+}
+
+// sbValues returns small blind values of fold and push with class i.
+func (g *hu) sbValues(i int, bb *[Classes]float64) (fold, push float64) {
+	fold = -(0.5 + g.p.Ante)
+	var w float64
+	for j := 0; j < Classes; j++ {
+		n := float64(g.e.Combos[i][j])
+		if n == 0 {
+			continue
+		}
+		w += n
+		push += n * ((1-bb[j])*(1+g.p.Ante) + bb[j]*g.called(i, j))
+	}
+	return fold, push / w
+}
+
+// bbValues returns big blind values of fold and call with class j
+// against push range, weighted by combos.
+func (g *hu) bbValues(j int, sb *[Classes]float64) (fold, call float64) {
+	for i := 0; i < Classes; i++ {
+		n := float64(g.e.Combos[i][j]) * sb[i]
+		if n == 0 {
+			continue
+		}
+		fold -= n * (1 + g.p.Ante)
+		call -= n * g.called(i, j)
+	}
+	return fold, call
+}
+
+func (g *hu) updateSB(sb, bb *[Classes]float64, it int) {
+	for i := 0; i < Classes; i++ {
+		fold, push := g.sbValues(i, bb)
+		v := (1-sb[i])*fold + sb[i]*push
+		g.rsb[i][0] = math.Max(0, g.rsb[i][0]+fold-v)
+		g.rsb[i][1] = math.Max(0, g.rsb[i][1]+push-v)
+		g.ssb[i][0] += float64(it) * (1 - sb[i])
+		g.ssb[i][1] += float64(it) * sb[i]
+	}
+}
+
+func (g *hu) updateBB(sb *[Classes]float64, it int) {
+	var bb [Classes]float64
+	current(&g.rbb, &bb)
+
+	for j := 0; j < Classes; j++ {
+		fold, call := g.bbValues(j, sb)
+		v := (1-bb[j])*fold + bb[j]*call
+		g.rbb[j][0] = math.Max(0, g.rbb[j][0]+fold-v)
+		g.rbb[j][1] = math.Max(0, g.rbb[j][1]+call-v)
+		g.sbb[j][0] += float64(it) * (1 - bb[j])
+		g.sbb[j][1] += float64(it) * bb[j]
+	}
+}
+
+func averageOf(s *[Classes][2]float64) (avg [Classes]float64) {
+	for i := range s {
+		sum := s[i][0] + s[i][1]
+		if sum > 0 {
+			avg[i] = s[i][1] / sum
+		}
+	}
+	return avg
+}
+
+func toMatrix(s [Classes]float64) card.Matrix {
+	var m card.Matrix
+	for i, v := range s {
+		m[i/13][i%13] = v
+	}
+	return m
+}
+
+func (g *hu) average() (push, call card.Matrix) {
+	return toMatrix(averageOf(&g.ssb)), toMatrix(averageOf(&g.sbb))
+}
+
+// exploit returns mean gain of best responses to average strategies
+// in big blinds per hand.
+func (g *hu) exploit() float64 {
+	sb := averageOf(&g.ssb)
+	bb := averageOf(&g.sbb)
+
+	// Best response of small blind against average big blind.
+	var brsb float64
+	for i := 0; i < Classes; i++ {
+		var n float64
+		for j := 0; j < Classes; j++ {
+			n += float64(g.e.Combos[i][j])
+		}
+		fold, push := g.sbValues(i, &bb)
+		brsb += n * math.Max(fold, push)
+	}
+
+	// Best response of big blind against average small blind,
+	// folded small blinds are the same for any response.
+	var brbb float64
+	for j := 0; j < Classes; j++ {
+		fold, call := g.bbValues(j, &sb)
+		brbb += math.Max(fold, call)
+		for i := 0; i < Classes; i++ {
+			brbb += float64(g.e.Combos[i][j]) * (1 - sb[i]) * (0.5 + g.p.Ante)
+		}
+	}
+
+	return (brsb + brbb) / g.total / 2
+}
@@ -0,0 +1,45 @@
+package pushfold
+
+import (
+	"testing"
+
+	"github.com/pokerdroid/poker/card"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolveHU(t *testing.T) {
+	e := tstNewEquity(t)
+
+	sol, err := SolveHU(e, Params{Stack: 10, Tolerance: 0.001, MaxIterations: 20_000})
+	require.NoError(t, err)
+	require.LessOrEqual(t, sol.Exploit, 0.001)
+	require.Len(t, sol.Charts, 2)
+
+	push, ok := sol.Charts.Find(Spot{Players: 2, Seat: 0}, 10)
+	require.True(t, ok)
+	call, ok := sol.Charts.Find(Spot{Players: 2, Seat: 1, AllIn: 1}, 10)
+	require.True(t, ok)
+
+	t.Log("\n" + push.String())
+	t.Log("\n" + call.String())
+
+	// Known 10bb equilibrium pushes about 58% and calls about 37%.
+	require.InDelta(t, 0.58, push.Percent(), 0.05)
+	require.InDelta(t, 0.37, call.Percent(), 0.05)
+
+	aa := card.NewCardsFromString("As Ah")
+	s72 := card.NewCardsFromString("7d 2c")
+
+	require.InDelta(t, 1, push.Freq(aa), 0.01)
+	require.InDelta(t, 1, call.Freq(aa), 0.01)
+	require.InDelta(t, 0, push.Freq(s72), 0.01)
+	require.InDelta(t, 0, call.Freq(s72), 0.01)
+
+	// Shallower stacks push wider.
+	short, err := SolveHU(e, Params{Stack: 3, Tolerance: 0.001, MaxIterations: 20_000})
+	require.NoError(t, err)
+	require.Greater(t, short.Charts[0].Percent(), push.Percent())
+
+	_, err = SolveHU(e, Params{Stack: 0.5, MaxIterations: 1})
+	require.Error(t, err)
+}
@@ -0,0 +1,250 @@
+package pushfold
+
+import (
+	"errors"
+	"math"
+
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/eval"
+	"github.com/pokerdroid/poker/frand"
+)
+
+// MaxPlayers is the largest push/fold game SolveMultiway handles.
+const MaxPlayers = 8
+
+// multiway is push/fold game of n players. Seats act in order, first
+// seat facing no all-in pushes or folds, later seats call or fold.
+// Information set is seat with bitmask of earlier seats all-in.
+type multiway struct {
+	n   int
+	p   Params
+	rng frand.Rand
+
+	// Regrets and strategy sums per information set and class.
+	regrets [][Classes][2]float64
+	sums    [][Classes][2]float64
+
+	posts []float64
+	class []int
+	ranks []card.HandRank
+	hands []card.Cards
+	board card.Cards
+
+	// vals holds values of both actions for every depth.
+	vals [][2][]float64
+	it   int
+}
+
+func infoset(seat int, mask uint8) int {
+	return (1 << seat) - 1 + int(mask)
+}
+
+// SolveMultiway solves push/fold game of 2 to MaxPlayers players by
+// chance sampled CFR with linear averaging. Each iteration deals hands
+// and board and walks every push/fold sequence. It runs MaxIterations,
+// exploitability is not measured.
+func SolveMultiway(rng frand.Rand, players int, p Params) (Solution, error) {
+	if players < 2 || players > MaxPlayers {
+		return Solution{}, errors.New("invalid number of players")
+	}
+
+	if err := p.Validate(); err != nil {
+		return Solution{}, err
+	}
+
+	g := &multiway{
+		n:       players,
+		p:       p,
+		rng:     rng,
+		regrets: make([][Classes][2]float64, 1<<players),
+		sums:    make([][Classes][2]float64, 1<<players),
+		posts:   make([]float64, players),
+		class:   make([]int, players),
+		ranks:   make([]card.HandRank, players),
+		hands:   make([]card.Cards, players),
+		board:   make(card.Cards, 5),
+		vals:    make([][2][]float64, players+1),
+	}
+
+	for i := range g.posts {
+		g.posts[i] = p.Ante
+		g.hands[i] = make(card.Cards, 7)
+	}
+	g.posts[players-1] += 1
+	g.posts[players-2] += 0.5
+
+	for i := range g.vals {
+		g.vals[i][0] = make([]float64, players)
+		g.vals[i][1] = make([]float64, players)
+	}
+
+	reach := make([]float64, players)
+	out := make([]float64, players)
+
+	for g.it = 1; g.it <= p.MaxIterations; g.it++ {
+		g.deal()
+
+		for i := range reach {
+			reach[i] = 1
+		}
+
+		g.walk(0, 0, reach, out)
+	}
+
+	sol := Solution{Stack: p.Stack, Iterations: p.MaxIterations}
+
+	for seat := 0; seat < players; seat++ {
+		for mask := 0; mask < 1<<seat; mask++ {
+			// Big blind wins the blinds when everybody folds.
+			if seat == players-1 && mask == 0 {
+				continue
+			}
+
+			avg := averageOf(&g.sums[infoset(seat, uint8(mask))])
+			sol.Charts = append(sol.Charts, Chart{
+				Spot:  Spot{Players: uint8(players), Seat: uint8(seat), AllIn: uint8(mask)},
+				Stack: p.Stack,
+				Range: toMatrix(avg),
+			})
+		}
+	}
+
+	return sol, nil
+}
+
+func (g *multiway) deal() {
+	var used uint64
+
+	for i := range g.hands {
+		for k := 0; k < 2; {
+			c := card.Card(g.rng.Intn(52) + 1)
+			if used&(1<<c) != 0 {
+				continue
+			}
+			used |= 1 << c
+			g.hands[i][k] = c
+			k++
+		}
+	}
+
+	dealBoard(g.rng, &used, g.board)
+
+	for i, h := range g.hands {
+		copy(h[2:], g.board)
+		g.ranks[i], _ = eval.Eval(h...)
+		g.class[i] = Class(h[:2])
+	}
+}
+
+// walk sets out to values of all players after seats before seat
+// acted, mask holds seats which are all-in.
+func (g *multiway) walk(seat int, mask uint8, reach []float64, out []float64) {
+	if seat == g.n {
+		g.payoff(mask, out)
+		return
+	}
+
+	// Big blind wins the blinds when everybody folds.
+	if seat == g.n-1 && mask == 0 {
+		g.payoff(1<<seat, out)
+		return
+	}
+
+	is := infoset(seat, mask)
+	c := g.class[seat]
+	r := &g.regrets[is][c]
+
+	var s [2]float64
+	if sum := math.Max(0, r[0]) + math.Max(0, r[1]); sum > 0 {
+		s[0], s[1] = math.Max(0, r[0])/sum, math.Max(0, r[1])/sum
+	} else {
+		s[0], s[1] = 0.5, 0.5
+	}
+
+	vals := g.vals[seat]
+	own := reach[seat]
+
+	for a := 0; a < 2; a++ {
+		next := mask
+		if a == 1 {
+			next |= 1 << seat
+		}
+		reach[seat] = own * s[a]
+		g.walk(seat+1, next, reach, vals[a])
+	}
+	reach[seat] = own
+
+	for i := range out {
+		out[i] = s[0]*vals[0][i] + s[1]*vals[1][i]
+	}
+
+	// Counterfactual reach is product of other players' reach.
+	cf := 1.0
+	for i, x := range reach {
+		if i != seat {
+			cf *= x
+		}
+	}
+
+	for a := 0; a < 2; a++ {
+		r[a] += cf * (vals[a][seat] - out[seat])
+		g.sums[is][c][a] += float64(g.it) * own * s[a]
+	}
+}
+
+// payoff sets out to chips won by every player in big blinds.
+func (g *multiway) payoff(in uint8, out []float64) {
+	var dead float64
+	var count int
+
+	for i := 0; i < g.n; i++ {
+		if in&(1<<i) == 0 {
+			out[i] = -g.posts[i]
+			dead += g.posts[i]
+		} else {
+			count++
+		}
+	}
+
+	// Uncontested player takes dead money and keeps own posts.
+	if count == 1 {
+		for i := 0; i < g.n; i++ {
+			if in&(1<<i) != 0 {
+				out[i] = dead
+			}
+		}
+		return
+	}
+
+	best := -1
+	for i := 0; i < g.n; i++ {
+		if in&(1<<i) == 0 {
+			continue
+		}
+		if best < 0 || g.ranks[i].Compare(g.ranks[best]) == 0 {
+			best = i
+		}
+	}
+
+	var winners int
+	for i := 0; i < g.n; i++ {
+		if in&(1<<i) != 0 && g.ranks[i].Compare(g.ranks[best]) == 2 {
+			winners++
+		}
+	}
+
+	// Dead money of folders includes their antes and blinds, all-in
+	// players commit whole stack.
+	pot := dead + float64(count)*g.p.Stack
+	share := pot / float64(winners)
+
+	for i := 0; i < g.n; i++ {
+		if in&(1<<i) == 0 {
+			continue
+		}
+		out[i] = -g.p.Stack
+		if g.ranks[i].Compare(g.ranks[best]) == 2 {
+			out[i] += share
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package pushfold
+
+import (
+	"testing"
+
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/frand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolveMultiway(t *testing.T) {
+	sol, err := SolveMultiway(frand.NewUnsafeInt(1), 3, Params{Stack: 10, MaxIterations: 500_000})
+	require.NoError(t, err)
+
+	// btn push, sb push, sb call, bb call vs btn, sb and both.
+	require.Len(t, sol.Charts, 6)
+
+	btn, ok := sol.Charts.Find(Spot{Players: 3, Seat: 0}, 10)
+	require.True(t, ok)
+	sb, ok := sol.Charts.Find(Spot{Players: 3, Seat: 1}, 10)
+	require.True(t, ok)
+	both, ok := sol.Charts.Find(Spot{Players: 3, Seat: 2, AllIn: 3}, 10)
+	require.True(t, ok)
+
+	t.Log("\n" + btn.String())
+	t.Log("\n" + sb.String())
+	t.Log("\n" + both.String())
+
+	aa := card.NewCardsFromString("As Ah")
+	s72 := card.NewCardsFromString("7d 2c")
+
+	require.Greater(t, btn.Freq(aa), 0.95)
+	require.Less(t, btn.Freq(s72), 0.2)
+
+	// Button acts with two players behind and pushes tighter than
+	// small blind heads up against big blind.
+	require.Less(t, btn.Percent(), sb.Percent())
+	// Overcalling against two all-ins needs the tightest range.
+	require.Less(t, both.Percent(), btn.Percent())
+
+	// Heads up agrees with exact solver.
+	hu, err := SolveMultiway(frand.NewUnsafeInt(1), 2, Params{Stack: 10, MaxIterations: 500_000})
+	require.NoError(t, err)
+	require.InDelta(t, 0.58, hu.Charts[0].Percent(), 0.05)
+	require.InDelta(t, 0.37, hu.Charts[1].Percent(), 0.05)
+
+	_, err = SolveMultiway(frand.NewUnsafeInt(1), 9, Params{Stack: 10, MaxIterations: 1})
+	require.Error(t, err)
+}
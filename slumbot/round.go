@@ -144,13 +144,13 @@ func Move(p table.GameParams, r *table.State, rx *Round) (state *table.State, er
 		board := rx.Response.GetBoardCards()
 		rx.Board = board
 
-		return state, table.ShiftStreet(state)
+		return state, table.ShiftStreet(p, state)
 
 	case table.RuleShiftStreetUntilEnd:
 		board := rx.Response.GetBoardCards()
 		rx.Board = board
 
-		err := table.ShiftStreet(state)
+		err := table.ShiftStreet(p, state)
 		if err != nil {
 			return nil, err
 		}
@@ -4,7 +4,7 @@ import (
 	"errors"
 	"fmt"
 
-	absp "github.com/pokerdroid/poker/abs/pack"
+	"github.com/pokerdroid/poker/abs"
 	"github.com/pokerdroid/poker/card"
 	"github.com/pokerdroid/poker/chips"
 	"github.com/pokerdroid/poker/table"
@@ -36,18 +36,19 @@ type Result struct {
 
 type Solutions struct {
 	Roots []*tree.Root
-	Abs   *absp.Abs
+	Abs   abs.Mapper
 }
 
 type Inspector struct {
 	tree     *Tree
-	abs      *absp.Abs
+	abs      abs.Mapper
 	solution *int
 	root     []*tree.Root
+	ranges   map[*tree.Root]*tree.RangeCache
 }
 
-func NewInspector(abs *absp.Abs, roots []*tree.Root) *Inspector {
-	return &Inspector{abs: abs, root: roots}
+func NewInspector(m abs.Mapper, roots []*tree.Root, ranges map[*tree.Root]*tree.RangeCache) *Inspector {
+	return &Inspector{abs: m, root: roots, ranges: ranges}
 }
 
 func (i *Inspector) Get(actions []Action) (r *Result, err error) {
@@ -123,7 +124,16 @@ func (i *Inspector) Get(actions []Action) (r *Result, err error) {
 	if player, ok := current.(*tree.Player); ok {
 		r.Actions = player.Actions.Actions
 
-		matrix := NewMatrixBuilder(player, board, i.abs, i.tree.GetMoves())
+		var nr *tree.NodeRanges
+		if rc := i.ranges[i.tree.root]; rc != nil {
+			rs, err := rc.Get(board)
+			if err != nil {
+				return nil, err
+			}
+			nr, _ = rs.Get(player)
+		}
+
+		matrix := NewMatrixBuilder(player, board, i.abs, nr)
 
 		data, err := matrix.Build()
 		if err != nil {
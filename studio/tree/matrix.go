@@ -1,13 +1,9 @@
 package studiotree
 
 import (
-	"fmt"
-
 	"github.com/pokerdroid/poker/abs"
-	absp "github.com/pokerdroid/poker/abs/pack"
 	"github.com/pokerdroid/poker/card"
 	"github.com/pokerdroid/poker/policy"
-	"github.com/pokerdroid/poker/table"
 	"github.com/pokerdroid/poker/tree"
 )
 
@@ -27,16 +23,18 @@ type MatrixBuilder struct {
 	data   [13][13]Cluster
 	player *tree.Player
 	board  card.Cards
-	abs    *absp.Abs
-	moves  []PlayerAction
+	abs    abs.Mapper
+	ranges *tree.NodeRanges
 }
 
-func NewMatrixBuilder(player *tree.Player, board card.Cards, abs *absp.Abs, moves []PlayerAction) *MatrixBuilder {
+// NewMatrixBuilder creates builder of player matrix, reach of hands
+// is read from ranges, every hand is reached when ranges are nil.
+func NewMatrixBuilder(player *tree.Player, board card.Cards, mapper abs.Mapper, ranges *tree.NodeRanges) *MatrixBuilder {
 	m := &MatrixBuilder{
 		player: player,
 		board:  board,
-		abs:    abs,
-		moves:  moves,
+		abs:    mapper,
+		ranges: ranges,
 	}
 
 	return m
@@ -125,52 +123,10 @@ func (m *MatrixBuilder) average() error {
 }
 
 func (m *MatrixBuilder) reach(cards card.Cards) float64 {
-	prob := float64(1.0)
-
-	for _, move := range m.moves {
-		if move.Player.TurnPos != m.player.TurnPos {
-			continue
-		}
-
-		var expected int
-		switch move.Player.State.Street {
-		case table.Preflop:
-			expected = 2
-		case table.Flop:
-			expected = 5
-		case table.Turn:
-			expected = 6
-		case table.River:
-			expected = 7
-		default:
-			panic("unrecognized street")
-		}
-
-		// Calculate number of board cards needed.
-		req := expected - len(cards)
-		if len(m.board) < req {
-			panic(fmt.Sprintf("insufficient board cards: expected %d, got %d", req, len(m.board)))
-		}
-
-		handCards := append(cards, m.board[:req]...)
-		if len(handCards) != expected {
-			panic(fmt.Sprintf("illegal card count: got %d, expected %d", len(handCards), expected))
-		}
-
-		cl := m.abs.Map(handCards)
-
-		pol, ok := move.Player.Actions.Policies.Get(cl)
-		if !ok {
-			continue
-		}
-
-		strat := pol.GetAverageStrategy()
-		if move.Action < 0 || move.Action >= len(strat) {
-			panic("invalid action index in reach calculation")
-		}
-		prob *= strat[move.Action]
+	if m.ranges == nil {
+		return 1
 	}
-	return prob
+	return m.ranges.Weights[m.player.TurnPos][card.RangeIndex(cards)]
 }
 
 func (m *MatrixBuilder) Get() [13][13]Cluster {
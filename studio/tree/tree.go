@@ -2,7 +2,7 @@ package studiotree
 
 import (
 	"github.com/pokerdroid/poker"
-	absp "github.com/pokerdroid/poker/abs/pack"
+	"github.com/pokerdroid/poker/abs"
 	"github.com/pokerdroid/poker/bot"
 	"github.com/pokerdroid/poker/bot/cfr"
 	"github.com/pokerdroid/poker/bot/mc"
@@ -16,13 +16,20 @@ import (
 
 type BindParams struct {
 	Roots   []*tree.Root
-	Abs     *absp.Abs
+	Abs     abs.Mapper
 	WebView webview.WebView
 	Logger  poker.Logger
+
+	// IgnoreAbs lets agents play solutions trained with other abstraction.
+	IgnoreAbs bool
 }
 
+// rangeCacheSize is number of boards ranges are kept for per solution.
+const rangeCacheSize = 64
+
 func Bind(p BindParams) error {
-	inspector := NewInspector(p.Abs, p.Roots)
+	ranges := tree.NewRangeCaches(p.Roots, p.Abs, rangeCacheSize)
+	inspector := NewInspector(p.Abs, p.Roots, ranges)
 
 	p.WebView.Bind("rpc_tree_solutions", func() (response []*tree.Root, err error) {
 		return p.Roots, nil
@@ -40,6 +47,8 @@ func Bind(p BindParams) error {
 		Rand:    frand.NewHash(),
 		Abs:     p.Abs,
 		Advisor: cfr.AdvisorSimple,
+
+		IgnoreAbs: p.IgnoreAbs,
 	}
 
 	agents["search"] = cfr.Advisor{
@@ -47,6 +56,17 @@ func Bind(p BindParams) error {
 		Rand:    frand.NewHash(),
 		Abs:     p.Abs,
 		Advisor: cfr.AdvisorWithSearch,
+		Ranges:  ranges,
+
+		IgnoreAbs: p.IgnoreAbs,
+	}
+
+	agents["blend"] = cfr.Blend{
+		Roots: p.Roots,
+		Rand:  frand.NewHash(),
+		Abs:   p.Abs,
+
+		IgnoreAbs: p.IgnoreAbs,
 	}
 
 	gm := NewGameManager(p.Logger)
@@ -0,0 +1,470 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pokerdroid/poker/chips"
+	"github.com/pokerdroid/poker/encbin"
+)
+
+// Position is position of a player relative to others.
+type Position uint8
+
+const (
+	PositionAny Position = iota
+	// PositionIP acts last on postflop streets.
+	PositionIP
+	// PositionOOP acts before some other player on postflop streets.
+	PositionOOP
+)
+
+func (p Position) String() string {
+	switch p {
+	case PositionIP:
+		return "ip"
+	case PositionOOP:
+		return "oop"
+	default:
+		return "any"
+	}
+}
+
+// AnyRaises is max raise count matching any number of raises.
+const AnyRaises = uint8(255)
+
+// BetRule selects bet sizes for situations it matches.
+// Fold, check and call are not affected by rules.
+type BetRule struct {
+	// Street to match, NoStreet matches every street.
+	Street   Street   `json:"street"`
+	Position Position `json:"position"`
+	// Number of bets and raises made on the street (State.BetAction),
+	// both inclusive.
+	MinRaises uint8 `json:"min_raises"`
+	MaxRaises uint8 `json:"max_raises"`
+	// Stack to pot ratio, MinSPR is inclusive, MaxSPR is exclusive.
+	// Zero MaxSPR means no upper bound.
+	MinSPR float32 `json:"min_spr"`
+	MaxSPR float32 `json:"max_spr"`
+	// Sizes are pot multipliers same as GameParams.BetSizes.
+	Sizes []float32 `json:"sizes"`
+	// AllIn allows all-in in addition to sizes.
+	AllIn bool `json:"allin"`
+}
+
+// Match returns true if rule applies to player on turn.
+func (b BetRule) Match(p GameParams, r *State) bool {
+	if b.Street != NoStreet && b.Street != r.Street {
+		return false
+	}
+
+	if r.BetAction < b.MinRaises || r.BetAction > b.MaxRaises {
+		return false
+	}
+
+	if b.Position != PositionAny {
+		ip := InPosition(p, r)
+		if ip != (b.Position == PositionIP) {
+			return false
+		}
+	}
+
+	if b.MinSPR > 0 || b.MaxSPR > 0 {
+		spr := SPR(p, r)
+		if spr < b.MinSPR {
+			return false
+		}
+		if b.MaxSPR > 0 && spr >= b.MaxSPR {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns rule in the same syntax ParseActionAbs accepts.
+func (b BetRule) String() string {
+	var sel []string
+
+	if b.Street == NoStreet {
+		sel = append(sel, "*")
+	} else {
+		sel = append(sel, b.Street.String())
+	}
+
+	if b.Position != PositionAny {
+		sel = append(sel, b.Position.String())
+	}
+
+	switch {
+	case b.MinRaises == 0 && b.MaxRaises == AnyRaises:
+	case b.MaxRaises == AnyRaises:
+		sel = append(sel, fmt.Sprintf("r%d+", b.MinRaises))
+	case b.MinRaises == b.MaxRaises:
+		sel = append(sel, fmt.Sprintf("r%d", b.MinRaises))
+	default:
+		sel = append(sel, fmt.Sprintf("r%d-%d", b.MinRaises, b.MaxRaises))
+	}
+
+	if b.MinSPR > 0 {
+		sel = append(sel, "spr>="+formatSize(b.MinSPR))
+	}
+
+	if b.MaxSPR > 0 {
+		sel = append(sel, "spr<"+formatSize(b.MaxSPR))
+	}
+
+	var sizes []string
+	for _, s := range b.Sizes {
+		sizes = append(sizes, formatSize(s))
+	}
+
+	if b.AllIn {
+		sizes = append(sizes, "allin")
+	}
+
+	if len(sizes) == 0 {
+		sizes = append(sizes, "none")
+	}
+
+	return strings.Join(sel, " ") + ": " + strings.Join(sizes, " ")
+}
+
+func formatSize(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
+
+// ActionAbs is declarative action abstraction. First matching rule
+// decides bet sizes, if none matches GameParams.BetSizes are used.
+//
+// It can be parsed from text, one rule per line (or separated by ";"):
+//
+//	flop oop r0: none        # no donk bets
+//	flop: 0.33 0.75 allin
+//	river: 75% 150% allin
+//	preflop r3+: allin       # only all-in facing a 4-bet
+//	* spr<1: allin
+//
+// Selectors are street (or "*"), "ip"/"oop", raise count "rN", "rN+"
+// or "rN-M", and "spr>=X", "spr<X". Raise count is number of bets and
+// raises on the street, blinds are not counted so preflop open is r1.
+// Sizes are pot multipliers or percentages, "allin" allows all-in
+// and "none" disables betting.
+type ActionAbs []BetRule
+
+// Match returns first rule matching the state.
+func (a ActionAbs) Match(p GameParams, r *State) (BetRule, bool) {
+	for _, b := range a {
+		if b.Match(p, r) {
+			return b, true
+		}
+	}
+	return BetRule{}, false
+}
+
+func (a ActionAbs) String() string {
+	lines := make([]string, len(a))
+	for i, b := range a {
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (a ActionAbs) Clone() ActionAbs {
+	if a == nil {
+		return nil
+	}
+	c := make(ActionAbs, len(a))
+	for i, b := range a {
+		c[i] = b
+		if b.Sizes != nil {
+			c[i].Sizes = append([]float32{}, b.Sizes...)
+		}
+	}
+	return c
+}
+
+// ParseActionAbs parses action abstraction from text.
+func ParseActionAbs(spec string) (ActionAbs, error) {
+	var a ActionAbs
+
+	spec = strings.ReplaceAll(spec, ";", "\n")
+
+	for n, line := range strings.Split(spec, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		b, err := parseBetRule(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+
+		a = append(a, b)
+	}
+
+	return a, nil
+}
+
+func parseBetRule(line string) (b BetRule, err error) {
+	sel, sizes, ok := strings.Cut(line, ":")
+	if !ok {
+		return b, fmt.Errorf("missing ':' in %q", line)
+	}
+
+	b.MaxRaises = AnyRaises
+
+	for _, tok := range strings.Fields(strings.ToLower(sel)) {
+		switch {
+		case tok == "*" || tok == "any":
+			b.Street = NoStreet
+
+		case tok == "ip":
+			b.Position = PositionIP
+
+		case tok == "oop":
+			b.Position = PositionOOP
+
+		case strings.HasPrefix(tok, "spr>="):
+			b.MinSPR, err = parseFloat(tok[5:])
+
+		case strings.HasPrefix(tok, "spr<"):
+			b.MaxSPR, err = parseFloat(tok[4:])
+
+		case len(tok) > 1 && tok[0] == 'r' && tok[1] >= '0' && tok[1] <= '9':
+			b.MinRaises, b.MaxRaises, err = parseRaises(tok[1:])
+
+		default:
+			b.Street, err = NewStreetFromString(tok)
+			if err == nil && (b.Street == Finished || b.Street == NoStreet) {
+				err = fmt.Errorf("invalid street %q", tok)
+			}
+		}
+
+		if err != nil {
+			return b, fmt.Errorf("selector %q: %w", tok, err)
+		}
+	}
+
+	for _, tok := range strings.Fields(strings.ReplaceAll(strings.ToLower(sizes), ",", " ")) {
+		switch {
+		case tok == "allin":
+			b.AllIn = true
+
+		case tok == "none":
+
+		case strings.HasSuffix(tok, "%"):
+			var f float32
+			f, err = parseFloat(tok[:len(tok)-1])
+			b.Sizes = append(b.Sizes, f/100)
+
+		default:
+			var f float32
+			f, err = parseFloat(tok)
+			b.Sizes = append(b.Sizes, f)
+		}
+
+		if err != nil {
+			return b, fmt.Errorf("size %q: %w", tok, err)
+		}
+	}
+
+	return b, nil
+}
+
+func parseFloat(s string) (float32, error) {
+	f, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return 0, err
+	}
+	if f <= 0 {
+		return 0, fmt.Errorf("must be positive")
+	}
+	return float32(f), nil
+}
+
+func parseRaises(s string) (uint8, uint8, error) {
+	if x, ok := strings.CutSuffix(s, "+"); ok {
+		n, err := strconv.ParseUint(x, 10, 8)
+		return uint8(n), AnyRaises, err
+	}
+
+	if lo, hi, ok := strings.Cut(s, "-"); ok {
+		l, err := strconv.ParseUint(lo, 10, 8)
+		if err != nil {
+			return 0, 0, err
+		}
+		h, err := strconv.ParseUint(hi, 10, 8)
+		if err != nil {
+			return 0, 0, err
+		}
+		if h < l {
+			return 0, 0, fmt.Errorf("invalid range")
+		}
+		return uint8(l), uint8(h), nil
+	}
+
+	n, err := strconv.ParseUint(s, 10, 8)
+	return uint8(n), uint8(n), err
+}
+
+func mustParseActionAbs(spec string) ActionAbs {
+	a, err := ParseActionAbs(spec)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (a ActionAbs) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (a *ActionAbs) UnmarshalText(text []byte) (err error) {
+	*a, err = ParseActionAbs(string(text))
+	return err
+}
+
+// Size returns the number of bytes needed to store ActionAbs.
+func (a ActionAbs) Size() uint64 {
+	size := uint64(1) // Rules length
+	for _, b := range a {
+		size += 1 + 1 + 1 + 1 // Street, Position, MinRaises, MaxRaises
+		size += 4 + 4         // MinSPR, MaxSPR
+		size += 1             // AllIn
+		size += 1 + uint64(len(b.Sizes))*4
+	}
+	return size
+}
+
+func (a ActionAbs) MarshalBinary() ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	err := encbin.MarshalValues(buf, uint8(len(a)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range a {
+		err = encbin.MarshalValues(
+			buf,
+			b.Street,
+			b.Position,
+			b.MinRaises,
+			b.MaxRaises,
+			b.MinSPR,
+			b.MaxSPR,
+			b.AllIn,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		err = encbin.MarshalSliceLen[float32, uint8](buf, b.Sizes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (a *ActionAbs) UnmarshalBinary(data []byte) error {
+	return a.unmarshal(bytes.NewReader(data))
+}
+
+func (a *ActionAbs) unmarshal(buf *bytes.Reader) error {
+	var n uint8
+	err := encbin.UnmarshalValues(buf, &n)
+	if err != nil {
+		return err
+	}
+
+	*a = nil
+	if n == 0 {
+		return nil
+	}
+
+	*a = make(ActionAbs, n)
+
+	for i := range *a {
+		b := &(*a)[i]
+
+		err = encbin.UnmarshalValues(
+			buf,
+			&b.Street,
+			&b.Position,
+			&b.MinRaises,
+			&b.MaxRaises,
+			&b.MinSPR,
+			&b.MaxSPR,
+			&b.AllIn,
+		)
+		if err != nil {
+			return err
+		}
+
+		b.Sizes, err = encbin.UnmarhsalSliceLen[float32, uint8](buf)
+		if err != nil {
+			return err
+		}
+
+		if len(b.Sizes) == 0 {
+			b.Sizes = nil
+		}
+	}
+
+	return nil
+}
+
+// InPosition returns true if player on turn acts last on
+// postflop streets among players still in the hand.
+func InPosition(p GameParams, r *State) bool {
+	n := len(r.Players)
+
+	// Follows ShiftTurnStreetStart.
+	start := int(streetStart(p, r))
+
+	last := -1
+	for i := 0; i < n; i++ {
+		pos := (start + i) % n
+		if r.Players[pos].Status != StatusFolded {
+			last = pos
+		}
+	}
+
+	return last == int(r.TurnPos)
+}
+
+// SPR returns effective stack to pot ratio for player on turn.
+func SPR(p GameParams, r *State) float32 {
+	pot := r.Players.PaidSum()
+	if pot.Equal(chips.Zero) {
+		return 0
+	}
+
+	stack := p.InitialStacks[r.TurnPos].Sub(r.Players[r.TurnPos].Paid)
+
+	rest := chips.Zero
+	for i, pl := range r.Players {
+		if uint8(i) == r.TurnPos || pl.Status == StatusFolded {
+			continue
+		}
+		s := p.InitialStacks[i].Sub(pl.Paid)
+		if s.GreaterThan(rest) {
+			rest = s
+		}
+	}
+
+	return chips.Min(stack, rest).Div(pot).Float32()
+}
@@ -0,0 +1,165 @@
+package table
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pokerdroid/poker/chips"
+	"github.com/stretchr/testify/require"
+)
+
+const testActionAbs = `
+flop oop r0: none  # no donk bets
+flop: 0.5 0.75 allin
+river: 75% 150% allin
+preflop r3+: allin
+* spr<1: allin
+`
+
+func TestParseActionAbs(t *testing.T) {
+	a, err := ParseActionAbs(testActionAbs)
+	require.NoError(t, err)
+	require.Len(t, a, 5)
+
+	require.Equal(t, BetRule{
+		Street:    Flop,
+		Position:  PositionOOP,
+		MinRaises: 0,
+		MaxRaises: 0,
+	}, a[0])
+
+	require.Equal(t, []float32{0.75, 1.5}, a[2].Sizes)
+	require.True(t, a[2].AllIn)
+	require.Equal(t, uint8(3), a[3].MinRaises)
+	require.Equal(t, AnyRaises, a[3].MaxRaises)
+	require.Equal(t, float32(1), a[4].MaxSPR)
+
+	b, err := ParseActionAbs(a.String())
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+
+	for _, bad := range []string{"flop 0.5", "flop: x", "flop r2-1: 1", "nowhere: 1", "flop: -1"} {
+		_, err = ParseActionAbs(bad)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestActionAbsLegalActions(t *testing.T) {
+	p := NewGameParams(2, chips.NewFromInt(200))
+	p.Limp = true
+
+	var err error
+	p.ActionAbs, err = ParseActionAbs(testActionAbs)
+	require.NoError(t, err)
+
+	game, err := NewGame(p)
+	require.NoError(t, err)
+
+	require.NoError(t, game.Action(ActionAmount{Action: Call, Amount: chips.NewFromInt(1)}))
+	require.NoError(t, game.Action(ActionAmount{Action: Check}))
+	require.Equal(t, Flop, game.Latest.Street)
+
+	// BB acts first on flop and can't lead.
+	require.False(t, InPosition(p, game.Latest))
+	require.Equal(t, []DiscreteAction{DCheck}, NewDiscreteLegalActions(p, game.Latest).List())
+
+	require.NoError(t, game.Action(ActionAmount{Action: Check}))
+
+	require.True(t, InPosition(p, game.Latest))
+	require.Equal(t,
+		[]DiscreteAction{DAllIn, DCheck, 0.5, 0.75},
+		NewDiscreteLegalActions(p, game.Latest).List(),
+	)
+}
+
+func TestActionAbsRaiseCount(t *testing.T) {
+	p := NewGameParams(2, chips.NewFromInt(400))
+
+	var err error
+	p.ActionAbs, err = ParseActionAbs(testActionAbs)
+	require.NoError(t, err)
+
+	game, err := NewGame(p)
+	require.NoError(t, err)
+
+	// No rule matches yet, bet sizes are used.
+	require.Contains(t, NewDiscreteLegalActions(p, game.Latest), DiscreteAction(1))
+
+	for _, amount := range []int64{5, 14, 40} {
+		require.NoError(t, game.Action(ActionAmount{Action: Raise, Amount: chips.NewFromInt(amount)}))
+	}
+
+	require.Equal(t, uint8(3), game.Latest.BetAction)
+	require.Equal(t,
+		[]DiscreteAction{DAllIn, DFold, DCall},
+		NewDiscreteLegalActions(p, game.Latest).List(),
+	)
+}
+
+func TestMultiwayGameParams(t *testing.T) {
+	for np := uint8(3); np <= 6; np++ {
+		p := NewMultiwayGameParams(np, chips.NewFromInt(200))
+		require.Equal(t, np*2, p.MaxActionsPerRound)
+
+		game, err := NewGame(p)
+		require.NoError(t, err)
+
+		// Open to 2.25bb or 3bb, no open shove at 100bb.
+		require.Equal(t,
+			[]DiscreteAction{DFold, 1.5, 2},
+			NewDiscreteLegalActions(p, game.Latest).List(),
+		)
+	}
+}
+
+func TestSPR(t *testing.T) {
+	p := NewGameParams(2, chips.NewFromInt(20))
+
+	game, err := NewGame(p)
+	require.NoError(t, err)
+
+	// 18 effective behind, 3 in the pot.
+	require.InDelta(t, 6, SPR(p, game.Latest), 1e-5)
+}
+
+func TestGameParamsActionAbsMarshal(t *testing.T) {
+	p := NewGameParams(2, chips.NewFromInt(100))
+
+	var err error
+	p.ActionAbs, err = ParseActionAbs(testActionAbs)
+	require.NoError(t, err)
+
+	data, err := p.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, p.Size(), uint64(len(data)))
+
+	var p2 GameParams
+	require.NoError(t, p2.UnmarshalBinary(data))
+	require.Equal(t, p.ActionAbs, p2.ActionAbs)
+	require.Equal(t, p.ActionAbs, p.Clone().ActionAbs)
+
+	// Params without ActionAbs are still readable.
+	p.ActionAbs = nil
+	data, err = p.MarshalBinary()
+	require.NoError(t, err)
+
+	var p3 GameParams
+	// Drop empty ActionAbs, antes, blinds and betting structure.
+	require.NoError(t, p3.UnmarshalBinary(data[:len(data)-1-8-1-2]))
+	require.Nil(t, p3.ActionAbs)
+}
+
+func TestGameParamsActionAbsJSON(t *testing.T) {
+	p := NewGameParams(2, chips.NewFromInt(100))
+
+	var err error
+	p.ActionAbs, err = ParseActionAbs(testActionAbs)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(p)
+	require.NoError(t, err)
+
+	var p2 GameParams
+	require.NoError(t, json.Unmarshal(data, &p2))
+	require.Equal(t, p.ActionAbs, p2.ActionAbs)
+}
@@ -31,6 +31,8 @@ const (
 	Call
 	Raise
 	AllIn
+	Ante
+	Straddle
 )
 
 // NewActionFromString returns ActionKind from string.
@@ -52,6 +54,10 @@ func NewActionFromString(str string) (ActionKind, error) {
 		return BigBlind, nil
 	case "allin":
 		return AllIn, nil
+	case "ante":
+		return Ante, nil
+	case "straddle":
+		return Straddle, nil
 	default:
 		return 0, errors.New("invalid action")
 	}
@@ -76,6 +82,10 @@ func (a ActionKind) String() string {
 		return "raise"
 	case AllIn:
 		return "allin"
+	case Ante:
+		return "ante"
+	case Straddle:
+		return "straddle"
 	case NoAction:
 		return "no action"
 	default:
@@ -102,6 +112,10 @@ func (a ActionKind) Short() string {
 		return "b"
 	case AllIn:
 		return "a"
+	case Ante:
+		return "ante"
+	case Straddle:
+		return "st"
 	default:
 		return "u"
 	}
@@ -109,12 +123,17 @@ func (a ActionKind) Short() string {
 
 // IsBet if bet or blinds.
 func (a ActionKind) IsBet() bool {
-	return a == Bet || a == SmallBlind || a == BigBlind
+	return a == Bet || a.IsBlind()
 }
 
-// IsBlind if small or big blind.
+// IsBlind if small blind, big blind or straddle.
 func (a ActionKind) IsBlind() bool {
-	return a == SmallBlind || a == BigBlind
+	return a == SmallBlind || a == BigBlind || a == Straddle
+}
+
+// IsForced if blind or ante.
+func (a ActionKind) IsForced() bool {
+	return a.IsBlind() || a == Ante
 }
 
 // IsRaise if raise or all-in.
@@ -207,6 +226,14 @@ func (a DiscreteAction) GetAction(p GameParams, r *State) (ActionKind, chips.Chi
 	pt := r.Players.PaidSum()
 	amount := pt.Mul(chips.NewFromFloat32(float32(a)))
 
+	switch p.Structure {
+	case FixedLimit:
+		// There is just one size in limit.
+		amount = r.CallAmount.Add(p.LimitBet(r.Street))
+	case PotLimit:
+		amount = chips.Min(amount, MaxRaise(p, r))
+	}
+
 	if r.CallAmount.Equal(chips.Zero) {
 		return Bet, amount.Round(2)
 	}
@@ -242,7 +269,7 @@ func (da DiscreteAction) Short() string {
 // LegalActions represents legal actions for a player.
 // It is a map of ActionKind to chips.Chips.
 // Chip amount is minimum of chips needed to perform action.
-// Maximum in NLH is player stack.
+// Maximum in NLH is player stack, otherwise see MaxRaise.
 type LegalActions map[ActionKind]chips.Chips
 
 // NewLegalActions will create LegalActions for a given state.
@@ -260,18 +287,26 @@ func NewLegalActions(p GameParams, r *State) LegalActions {
 
 	max := p.MaxActionsPerRound
 	if r.Street == Preflop {
-		max += 2
+		max += p.liveBets()
 	}
 
 	reachedMax := r.StreetAction+1 >= max
 
+	maxRaise := MaxRaise(p, r)
+
 	if callAmount.Equal(chips.Zero) && !reachedMax {
 		actions[Check] = chips.Zero
 		bb := p.SbAmount.Mul(chips.NewFromInt(2))
-		if stack.GreaterThan(bb) {
+		if p.Structure == FixedLimit {
+			bb = p.LimitBet(r.Street)
+		}
+		if stack.GreaterThan(bb) && bb.LessThanOrEqual(maxRaise) {
 			actions[Bet] = bb
 		}
-		actions[AllIn] = stack
+		// All in only if stack is within the limit.
+		if stack.Equal(maxRaise) {
+			actions[AllIn] = stack
+		}
 
 		return actions
 	}
@@ -293,15 +328,13 @@ func NewLegalActions(p GameParams, r *State) LegalActions {
 		return actions
 	}
 
-	minRaise := callAmount
+	// Raise to biggest commitment plus last full raise.
+	minRaise := callAmount.Add(FullRaise(p, r))
 
-	if r.BSC.Amount.GreaterThan(chips.Zero) {
-		if r.BSC.Action.IsBet() {
-			minRaise = minRaise.Add(r.BSC.Amount)
-		}
-		if r.BSC.Action.IsRaise() {
-			minRaise = r.BSC.Addition.Add(r.BSC.Amount)
-		}
+	// Only incomplete raises since player acted, can't re-raise.
+	if !RaiseOpen(p, r) {
+		actions[Call] = callAmount
+		return actions
 	}
 
 	rest := chips.Zero
@@ -317,13 +350,13 @@ func NewLegalActions(p GameParams, r *State) LegalActions {
 
 	restaz := rest.GreaterThan(chips.Zero)
 
-	if minRaise.LessThan(stack) && restaz {
+	if minRaise.LessThan(stack) && minRaise.LessThanOrEqual(maxRaise) && restaz {
 		actions[Raise] = minRaise
 	}
 
 	actions[Call] = callAmount
 
-	if callAmount.LessThan(stack) && restaz {
+	if callAmount.LessThan(stack) && stack.Equal(maxRaise) && restaz {
 		actions[AllIn] = stack
 	}
 
@@ -347,7 +380,7 @@ func (la LegalActions) Validate(p GameParams, r *State, ax ActionAmount) error {
 	a := ax.Action
 	amount := ax.Amount
 
-	if _, ok := la[a]; !ok && a != BigBlind && a != SmallBlind {
+	if _, ok := la[a]; !ok && !a.IsBlind() {
 		acts := make([]string, 0)
 		for act := range la {
 			acts = append(acts, act.String())
@@ -371,6 +404,10 @@ func (la LegalActions) Validate(p GameParams, r *State, ax ActionAmount) error {
 		return fmt.Errorf("%d: illegal bet amount: %s < %s", nps, amount.String(), la[Bet].String())
 	}
 
+	if (a == Raise || a == Bet) && amount.GreaterThan(MaxRaise(p, r)) {
+		return fmt.Errorf("%d: illegal %s amount: %s > %s (%s)", nps, a, amount.String(), MaxRaise(p, r).String(), p.Structure)
+	}
+
 	if a == AllIn && !amount.Equal(stack) {
 		return fmt.Errorf("%d: illegal all-in amount: %s != %s", nps, amount.String(), stack.String())
 	}
@@ -417,6 +454,8 @@ func (d DiscreteLegalActions) List() []DiscreteAction {
 
 // NewDiscreteLegalActions creates new DiscreteLegalActions for a given state.
 // This is using NewLegalActions and converting it to discrete space.
+// Bet sizes come from first matching ActionAbs rule, or BetSizes
+// when no rule matches.
 func NewDiscreteLegalActions(p GameParams, r *State) DiscreteLegalActions {
 	actions := make(DiscreteLegalActions)
 	legalActions := NewLegalActions(p, r)
@@ -429,7 +468,7 @@ func NewDiscreteLegalActions(p GameParams, r *State) DiscreteLegalActions {
 		actions[DCheck] = chips.Zero
 	}
 
-	openAct := r.Street == Preflop && r.StreetAction == 2
+	openAct := r.Street == Preflop && r.StreetAction == p.liveBets()
 	amount, ok := legalActions[Call]
 
 	if openAct && p.Limp && ok {
@@ -454,18 +493,33 @@ func NewDiscreteLegalActions(p GameParams, r *State) DiscreteLegalActions {
 		actions[DAllIn] = legalActions[AllIn]
 	}
 
-	np := r.Players[r.TurnPos]
-	stack := p.InitialStacks[r.TurnPos].Sub(np.Paid)
+	maxRaise := MaxRaise(p, r)
+	pot := r.Players.PaidSum()
 
-	if len(p.BetSizes) == 0 {
+	if p.Structure == FixedLimit {
+		if !minRaise.Equal(chips.Zero) {
+			actions[DiscreteAction(minRaise.Div(pot))] = minRaise
+		}
 		return actions
 	}
 
-	pot := r.Players.PaidSum()
-	betSizes := p.BetSizes[len(p.BetSizes)-1]
+	var betSizes []float32
+
+	if rule, ok := p.ActionAbs.Match(p, r); ok {
+		if !rule.AllIn {
+			delete(actions, DAllIn)
+		}
+		betSizes = rule.Sizes
+	} else {
+		if len(p.BetSizes) == 0 {
+			return actions
+		}
+
+		betSizes = p.BetSizes[len(p.BetSizes)-1]
 
-	if r.BetAction < uint8(len(p.BetSizes)) {
-		betSizes = p.BetSizes[r.BetAction]
+		if r.BetAction < uint8(len(p.BetSizes)) {
+			betSizes = p.BetSizes[r.BetAction]
+		}
 	}
 
 	if !minRaise.Equal(chips.Zero) {
@@ -474,13 +528,24 @@ func NewDiscreteLegalActions(p GameParams, r *State) DiscreteLegalActions {
 			actions[DiscreteAction(minRaise.Div(pot))] = minRaise
 		}
 
+		over := false
 		for _, amount := range betSizes {
 			na := pot.Mul(chips.NewFromFloat32(amount))
-			if na.LessThan(minRaise) || na.GreaterThan(stack) {
+			if na.GreaterThan(maxRaise) {
+				over = true
+			}
+			if na.LessThan(minRaise) || na.GreaterThan(maxRaise) {
 				continue
 			}
 			actions[DiscreteAction(amount)] = na
 		}
+
+		// Sizes over the pot limit become the pot sized raise, unless
+		// all-in fits in the pot.
+		_, allin := legalActions[AllIn]
+		if p.Structure == PotLimit && over && !allin && maxRaise.GreaterThanOrEqual(minRaise) {
+			actions[DiscreteAction(maxRaise.Div(pot))] = maxRaise
+		}
 	}
 
 	return actions
@@ -513,7 +578,7 @@ func FromActionToDiscrete(p GameParams, r *State, ax ActionAmount) DiscreteActio
 	action := ax.Action
 	amount := ax.Amount
 
-	if action.IsBlind() {
+	if action.IsForced() {
 		return DNoAction
 	}
 
@@ -495,9 +495,9 @@ func TestMinBettingRules(t *testing.T) {
 		err = game.Action(ActionAmount{Action: Raise, Amount: chips.NewFromInt(25)})
 		require.NoError(t, err)
 
-		// First player - min raise should be 40 (previous bet 25 + size of raise 15)
+		// First player has 10 in, min raise is to 40 (previous bet 25 + size of raise 15)
 		legal = NewLegalActions(p, game.Latest)
-		require.Equal(t, chips.NewFromInt(40), legal[Raise], "min raise should be 40")
+		require.Equal(t, chips.NewFromInt(30), legal[Raise], "min raise should be 30 more")
 	})
 
 	t.Run("all-in less than min raise", func(t *testing.T) {
@@ -533,9 +533,10 @@ func TestMinBettingRules(t *testing.T) {
 		err = game.Action(ActionAmount{Action: AllIn, Amount: chips.NewFromInt(28)})
 		require.NoError(t, err)
 
-		// P0's min raise should still be 40 (based on full raise amount)
+		// All in is not a full raise, P0 can just call or fold
 		legal := NewLegalActions(p, game.Latest)
 		require.Equal(t, chips.NewFromInt(8), legal[Call], "call should be 8")
+		require.NotContains(t, legal, Raise)
 	})
 
 	t.Run("continuous reraises heads-up", func(t *testing.T) {
@@ -559,27 +560,27 @@ func TestMinBettingRules(t *testing.T) {
 		err = game.Action(ActionAmount{Action: Bet, Amount: chips.NewFromInt(8)})
 		require.NoError(t, err)
 
-		// SB raises to 24 (+16)
+		// SB puts in 24 more, raise to 26 (+16)
 		err = game.Action(ActionAmount{Action: Raise, Amount: chips.NewFromInt(24)})
 		require.NoError(t, err)
 		legal := NewLegalActions(p, game.Latest)
-		require.Equal(t, chips.NewFromInt(40), legal[Raise],
-			"min raise should be 40 (current bet 24 + previous raise 16)")
+		require.Equal(t, chips.NewFromInt(32), legal[Raise],
+			"min raise should be to 42 (current bet 26 + previous raise 16)")
 
-		// BB raises to 56 (+32)
+		// BB puts in 56 more, raise to 66 (+40)
 		err = game.Action(ActionAmount{Action: Raise, Amount: chips.NewFromInt(56)})
 		require.NoError(t, err)
 		legal = NewLegalActions(p, game.Latest)
-		require.Equal(t, chips.NewFromInt(88), legal[Raise],
-			"min raise should be 88 (current bet 56 + previous raise 32)")
+		require.Equal(t, chips.NewFromInt(80), legal[Raise],
+			"min raise should be to 106 (current bet 66 + previous raise 40)")
 
-		// SB raises to 120 (+64)
+		// SB puts in 120 more, raise to 146 (+80)
 		err = game.Action(ActionAmount{Action: Raise, Amount: chips.NewFromInt(120)})
 		require.NoError(t, err)
 		legal = NewLegalActions(p, game.Latest)
 
-		require.Equal(t, chips.NewFromInt(184), legal[Raise],
-			"min raise should be 184 (current bet 120 + previous raise 64)")
+		require.Equal(t, chips.NewFromInt(160), legal[Raise],
+			"min raise should be to 226 (current bet 146 + previous raise 80)")
 	})
 
 	t.Run("multiplayer min raise after fold", func(t *testing.T) {
@@ -603,11 +604,11 @@ func TestMinBettingRules(t *testing.T) {
 		err = game.Action(ActionAmount{Action: Call, Amount: chips.NewFromInt(1)})
 		require.NoError(t, err)
 
-		// BB (P1) bets 8
+		// BB (P1) bets 8, raise to 10
 		err = game.Action(ActionAmount{Action: Bet, Amount: chips.NewFromInt(8)})
 		require.NoError(t, err)
 
-		// UTG (P2) raises to 24
+		// UTG (P2) puts in 24 more, raise to 26
 		err = game.Action(ActionAmount{Action: Raise, Amount: chips.NewFromInt(24)})
 		require.NoError(t, err)
 
@@ -615,10 +616,10 @@ func TestMinBettingRules(t *testing.T) {
 		err = game.Action(ActionAmount{Action: Fold})
 		require.NoError(t, err)
 
-		// BB's (P1) min raise should be to 40 (current bet 24 + previous raise 16)
+		// BB's (P1) min raise should be to 42 (current bet 26 + previous raise 16)
 		legal := NewLegalActions(p, game.Latest)
-		require.Equal(t, chips.NewFromInt(40), legal[Raise],
-			"min raise should be 40 after previous player folded")
+		require.Equal(t, chips.NewFromInt(32), legal[Raise],
+			"min raise should be 32 more after previous player folded")
 	})
 }
 
@@ -0,0 +1,202 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pokerdroid/poker/chips"
+	"github.com/pokerdroid/poker/encbin"
+)
+
+// ForcedBet is a bet posted before cards are dealt.
+type ForcedBet struct {
+	// Seat relative to the button, 0 is the button itself.
+	Seat uint8 `json:"seat"`
+	// Action is SmallBlind, BigBlind or Straddle.
+	Action ActionKind  `json:"action"`
+	Amount chips.Chips `json:"amount"`
+	// Dead bet is dead money same as ante, it doesn't count
+	// as street commitment and player doesn't get an option.
+	Dead bool `json:"dead"`
+}
+
+func (f ForcedBet) String() string {
+	s := fmt.Sprintf("%s@%d:%s", f.Action, f.Seat, f.Amount.StringFixed(2))
+	if f.Dead {
+		s += "(dead)"
+	}
+	return s
+}
+
+// Blinds are forced bets in the order they are posted.
+//
+// Preflop action starts left of the seat posting the last live bet,
+// so UTG straddle moves the first action to UTG+1. Button straddle
+// (seat 0) moves it to the small blind and the button acts last.
+type Blinds []ForcedBet
+
+// DefaultBlinds returns small and big blind in ring positions. Heads
+// up the button posts small blind, otherwise the two seats after the
+// button.
+func DefaultBlinds(np uint8, sb chips.Chips) Blinds {
+	if np == 2 {
+		return Blinds{
+			{Seat: 0, Action: SmallBlind, Amount: sb},
+			{Seat: 1, Action: BigBlind, Amount: sb.Mul(2)},
+		}
+	}
+	return Blinds{
+		{Seat: 1, Action: SmallBlind, Amount: sb},
+		{Seat: 2, Action: BigBlind, Amount: sb.Mul(2)},
+	}
+}
+
+// StraddleBlinds returns default blinds with a straddle of given amount.
+// Button straddle is posted by the button, otherwise by UTG.
+func StraddleBlinds(np uint8, sb, amount chips.Chips, button bool) Blinds {
+	b := DefaultBlinds(np, sb)
+
+	seat := uint8(3) % np
+	if button {
+		seat = 0
+	}
+
+	return append(b, ForcedBet{Seat: seat, Action: Straddle, Amount: amount})
+}
+
+func (b Blinds) String() string {
+	s := make([]string, len(b))
+	for i, f := range b {
+		s[i] = f.String()
+	}
+	return strings.Join(s, " ")
+}
+
+func (b Blinds) Clone() Blinds {
+	if b == nil {
+		return nil
+	}
+	return append(Blinds{}, b...)
+}
+
+// Find returns first forced bet of given action.
+func (b Blinds) Find(a ActionKind) (ForcedBet, bool) {
+	for _, f := range b {
+		if f.Action == a {
+			return f, true
+		}
+	}
+	return ForcedBet{}, false
+}
+
+// Size returns the number of bytes needed to store Blinds.
+func (b Blinds) Size() uint64 {
+	// Length + Seat, Action, Amount, Dead per bet
+	return 1 + uint64(len(b))*(1+1+4+1)
+}
+
+func (b Blinds) MarshalBinary() ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	err := encbin.MarshalValues(buf, uint8(len(b)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range b {
+		err = encbin.MarshalValues(buf, f.Seat, f.Action, f.Amount, f.Dead)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (b *Blinds) UnmarshalBinary(data []byte) error {
+	return b.unmarshal(bytes.NewReader(data))
+}
+
+func (b *Blinds) unmarshal(buf *bytes.Reader) error {
+	var n uint8
+	err := encbin.UnmarshalValues(buf, &n)
+	if err != nil {
+		return err
+	}
+
+	*b = nil
+	if n == 0 {
+		return nil
+	}
+
+	*b = make(Blinds, n)
+
+	for i := range *b {
+		f := &(*b)[i]
+		err = encbin.UnmarshalValues(buf, &f.Seat, &f.Action, &f.Amount, &f.Dead)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ForcedBets returns configured blinds. Games without Blinds keep
+// the legacy posting order, the button posts small blind and the next
+// seat big blind at any table size.
+func (g GameParams) ForcedBets() Blinds {
+	if len(g.Blinds) > 0 {
+		return g.Blinds
+	}
+	return Blinds{
+		{Seat: 0, Action: SmallBlind, Amount: g.SbAmount},
+		{Seat: 1, Action: BigBlind, Amount: g.SbAmount.Mul(2)},
+	}
+}
+
+// liveBets returns number of live forced bets without allocating.
+func (g GameParams) liveBets() uint8 {
+	if len(g.Blinds) == 0 {
+		return 2
+	}
+	var n uint8
+	for _, f := range g.Blinds {
+		if !f.Dead {
+			n++
+		}
+	}
+	return n
+}
+
+// PositionsFor returns button, small blind and big blind seats
+// following posting order of the game. If the game has no small
+// blind, it is the seat of the big blind.
+func PositionsFor(p GameParams, s *State) (btn, sb, bb uint8) {
+	n := uint8(len(s.Players))
+	btn = s.BtnPos % n
+	blinds := p.ForcedBets()
+
+	bb = btn
+	if f, ok := blinds.Find(BigBlind); ok {
+		bb = (btn + f.Seat) % n
+	}
+
+	sb = bb
+	if f, ok := blinds.Find(SmallBlind); ok {
+		sb = (btn + f.Seat) % n
+	}
+
+	return btn, sb, bb
+}
+
+// streetStart returns seat that opens postflop streets, small blind
+// or big blind heads up.
+func streetStart(p GameParams, s *State) uint8 {
+	_, sb, bb := PositionsFor(p, s)
+	if len(s.Players) == 2 {
+		return bb
+	}
+	return sb
+}
@@ -0,0 +1,153 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/pokerdroid/poker/chips"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeInitialBetsLegacy(t *testing.T) {
+	p := NewGameParams(6, chips.NewFromInt(100))
+
+	s, err := MakeInitialBets(p, NewState(p))
+	require.NoError(t, err)
+
+	// Without Blinds the button posts small blind.
+	require.Equal(t, chips.NewList(1, 2, 0, 0, 0, 0), s.PSC)
+	require.Equal(t, uint8(2), s.TurnPos)
+
+	btn, sb, bb := PositionsFor(p, s)
+	require.Equal(t, []uint8{0, 0, 1}, []uint8{btn, sb, bb})
+
+	for i := 0; i < 5; i++ {
+		s, err = MakeAction(p, s, DCall)
+		require.NoError(t, err)
+		s, err = Move(p, s)
+		require.NoError(t, err)
+	}
+	require.Equal(t, uint8(1), s.TurnPos)
+
+	s, err = MakeAction(p, s, DCheck)
+	require.NoError(t, err)
+	s, err = Move(p, s)
+	require.NoError(t, err)
+
+	// Postflop small blind acts first.
+	require.Equal(t, Flop, s.Street)
+	require.Equal(t, uint8(0), s.TurnPos)
+	require.False(t, InPosition(p, s))
+}
+
+func TestMakeInitialBetsRing(t *testing.T) {
+	p := NewGameParams(6, chips.NewFromInt(100))
+	p.Blinds = DefaultBlinds(6, p.SbAmount)
+
+	s, err := MakeInitialBets(p, NewState(p))
+	require.NoError(t, err)
+
+	require.Equal(t, chips.NewList(0, 1, 2, 0, 0, 0), s.PSC)
+	require.Equal(t, uint8(3), s.TurnPos)
+
+	btn, sb, bb := PositionsFor(p, s)
+	require.Equal(t, []uint8{0, 1, 2}, []uint8{btn, sb, bb})
+
+	// Everybody calls, big blind has an option.
+	for i := 0; i < 5; i++ {
+		s, err = MakeAction(p, s, DCall)
+		require.NoError(t, err)
+		s, err = Move(p, s)
+		require.NoError(t, err)
+	}
+	require.Equal(t, Preflop, s.Street)
+	require.Equal(t, uint8(2), s.TurnPos)
+
+	s, err = MakeAction(p, s, DCheck)
+	require.NoError(t, err)
+	s, err = Move(p, s)
+	require.NoError(t, err)
+
+	// Postflop small blind acts first, button last.
+	require.Equal(t, Flop, s.Street)
+	require.Equal(t, uint8(1), s.TurnPos)
+	require.False(t, InPosition(p, s))
+}
+
+func TestMakeInitialBetsStraddle(t *testing.T) {
+	tests := []struct {
+		name   string
+		button bool
+		first  uint8
+		option uint8
+	}{
+		{name: "utg", button: false, first: 4, option: 3},
+		{name: "button", button: true, first: 1, option: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewGameParams(6, chips.NewFromInt(100))
+			p.Blinds = StraddleBlinds(6, p.SbAmount, chips.NewFromInt(4), tt.button)
+
+			s, err := MakeInitialBets(p, NewState(p))
+			require.NoError(t, err)
+
+			require.Equal(t, chips.NewFromInt(4), s.PSC[tt.option])
+			require.Equal(t, tt.first, s.TurnPos)
+			require.Equal(t, chips.NewFromInt(4).Sub(s.PSC[tt.first]), s.CallAmount)
+
+			// Everybody calls, straddle has an option.
+			for i := 0; i < 5; i++ {
+				s, err = MakeAction(p, s, DCall)
+				require.NoError(t, err)
+				s, err = Move(p, s)
+				require.NoError(t, err)
+			}
+			require.Equal(t, Preflop, s.Street)
+			require.Equal(t, tt.option, s.TurnPos)
+
+			la := NewLegalActions(p, s)
+			require.Contains(t, la, Check)
+
+			s, err = MakeAction(p, s, DCheck)
+			require.NoError(t, err)
+			s, err = Move(p, s)
+			require.NoError(t, err)
+			require.Equal(t, Flop, s.Street)
+			require.Equal(t, chips.NewFromInt(24), s.Players.PaidSum())
+		})
+	}
+}
+
+func TestMakeInitialBetsDeadBlind(t *testing.T) {
+	p := NewGameParams(3, chips.NewFromInt(100))
+	p.Blinds = append(DefaultBlinds(3, p.SbAmount), ForcedBet{
+		Seat:   0,
+		Action: SmallBlind,
+		Amount: p.SbAmount,
+		Dead:   true,
+	})
+
+	s, err := MakeInitialBets(p, NewState(p))
+	require.NoError(t, err)
+
+	// Dead blind is in the pot, but button still has to call the big blind.
+	require.Equal(t, chips.NewFromInt(1), s.Players[0].Paid)
+	require.Equal(t, chips.NewList(0, 1, 2), s.PSC)
+	require.Equal(t, uint8(0), s.TurnPos)
+	require.Equal(t, chips.NewFromInt(2), s.CallAmount)
+	require.Equal(t, chips.List{1, 0, 0}, Antes(p, s.BtnPos))
+}
+
+func TestBlindsMarshal(t *testing.T) {
+	b := StraddleBlinds(6, chips.NewFromInt(1), chips.NewFromInt(4), true)
+
+	data, err := b.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, b.Size(), uint64(len(data)))
+
+	var b2 Blinds
+	require.NoError(t, b2.UnmarshalBinary(data))
+	require.Equal(t, b, b2)
+	require.Equal(t, "sb@1:1.00 bb@2:2.00 straddle@0:4.00", b2.String())
+}
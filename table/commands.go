@@ -37,6 +37,7 @@ func MakeAction(p GameParams, r *State, aa Actioner) (*State, error) {
 	}
 
 	np.Paid = np.Paid.Add(amount)
+	prev := r.PSC.Max()
 
 	state := r.Next()
 	state.Players[r.TurnPos] = np
@@ -57,31 +58,115 @@ func MakeAction(p GameParams, r *State, aa Actioner) (*State, error) {
 		state.BetAction++
 	}
 
+	trackRaise(p, state, r.TurnPos, action, prev)
+
 	return state, nil
 }
 
+// MakeInitialBets posts forced bets of the game in configured order,
+// then antes and dead blinds. Preflop action starts left of the
+// seat posting the last live bet.
 func MakeInitialBets(p GameParams, r *State) (state *State, err error) {
-	state, err = MakeAction(p, r, ActionAmount{
-		Action: SmallBlind,
-		Amount: p.SbAmount,
-	})
-	if err != nil {
-		return
+	n := uint8(len(r.Players))
+	state = r
+	last := uint8(0)
+
+	for _, fb := range p.ForcedBets() {
+		if fb.Dead {
+			continue
+		}
+
+		pos := (r.BtnPos + fb.Seat) % n
+		if state.TurnPos != pos {
+			if state == r {
+				state = state.Next()
+			}
+			state.TurnPos = pos
+		}
+
+		// Short stack posts what it has left.
+		stack := p.InitialStacks[pos].Sub(state.Players[pos].Paid)
+
+		state, err = MakeAction(p, state, ActionAmount{
+			Action: fb.Action,
+			Amount: chips.Min(fb.Amount, stack),
+		})
+		if err != nil {
+			return
+		}
+
+		last = fb.Seat
 	}
+
+	state = PostAntes(p, state)
+	state.TurnPos = (r.BtnPos + last) % n
+
 	err = ShiftTurn(p, state)
 	if err != nil {
 		return
 	}
-	state, err = MakeAction(p, state, ActionAmount{
-		Action: BigBlind,
-		Amount: p.SbAmount.Mul(chips.NewFromInt(2)),
-	})
-	if err != nil {
-		return
+	return state, nil
+}
+
+// Antes returns dead money posted by each player, antes and dead
+// blinds. Live blinds are posted first so dead money is capped by
+// what is left of the stack after them.
+func Antes(p GameParams, btn uint8) chips.List {
+	antes := chips.NewListAlloc(p.NumPlayers)
+
+	hasDead := false
+	for _, fb := range p.Blinds {
+		hasDead = hasDead || fb.Dead
 	}
-	err = ShiftTurn(p, state)
-	if err != nil {
-		return
+
+	if p.Ante.Equal(chips.Zero) && p.BBAnte.Equal(chips.Zero) && !hasDead {
+		return antes
 	}
-	return state, nil
+
+	stacks := p.InitialStacks.Copy()
+
+	for _, fb := range p.ForcedBets() {
+		pos := (btn + fb.Seat) % p.NumPlayers
+		if fb.Dead {
+			antes[pos] = antes[pos].Add(fb.Amount)
+			continue
+		}
+		stacks[pos] = stacks[pos].Sub(chips.Min(fb.Amount, stacks[pos]))
+		if fb.Action == BigBlind {
+			antes[pos] = antes[pos].Add(p.BBAnte)
+		}
+	}
+
+	for i := range antes {
+		antes[i] = chips.Min(antes[i].Add(p.Ante), stacks[i])
+	}
+
+	return antes
+}
+
+// PostAntes returns new state with antes paid. Antes are dead money,
+// they are added to what players paid but not to street commitment.
+// Players that can't cover the ante go all-in.
+func PostAntes(p GameParams, r *State) *State {
+	antes := Antes(p, r.BtnPos)
+	if antes.Sum().Equal(chips.Zero) {
+		return r
+	}
+
+	state := r.Next()
+
+	for i, ante := range antes {
+		if ante.Equal(chips.Zero) {
+			continue
+		}
+
+		np := &state.Players[i]
+		np.Paid = np.Paid.Add(ante)
+
+		if p.InitialStacks[i].Sub(np.Paid).LessThanOrEqual(chips.Zero) {
+			np.Status = StatusAllIn
+		}
+	}
+
+	return state
 }
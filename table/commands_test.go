@@ -29,6 +29,52 @@ func TestMakeInitialBets(t *testing.T) {
 	require.Equal(t, chips.NewFromInt(2), newS.Players[1].Paid) // BB
 }
 
+func TestMakeInitialBetsAntes(t *testing.T) {
+	p := NewGameParams(3, chips.NewFromInt(100))
+	p.Ante = chips.NewFromFloat(0.5)
+	p.BBAnte = chips.NewFromInt(2)
+	p.InitialStacks[2] = chips.NewFromFloat(0.25)
+
+	s, err := MakeInitialBets(p, NewState(p))
+	require.NoError(t, err)
+
+	// Blinds are posted first, antes are dead money.
+	require.Equal(t, chips.NewFromFloat(1.5), s.Players[0].Paid)
+	require.Equal(t, chips.NewFromFloat(4.5), s.Players[1].Paid)
+	require.Equal(t, chips.NewFromFloat(0.25), s.Players[2].Paid)
+	require.Equal(t, StatusAllIn, s.Players[2].Status)
+	require.Equal(t, chips.NewList(1, 2, 0), s.PSC)
+
+	// Small blind still has to call one chip.
+	require.Equal(t, uint8(0), s.TurnPos)
+	require.Equal(t, chips.NewFromInt(1), s.CallAmount)
+
+	var antes chips.List
+	for _, h := range s.History() {
+		if h.Action.Action == Ante {
+			antes = append(antes, h.Action.Amount)
+		}
+	}
+	require.ElementsMatch(t, chips.List{0.5, 2.5, 0.25}, antes)
+	require.Equal(t, "r:ante3.25", s.Path(p.SbAmount))
+
+	s, err = MakeAction(p, s, DCall)
+	require.NoError(t, err)
+	s, err = Move(p, s)
+	require.NoError(t, err)
+	require.Equal(t, "r:ante3.25:n:c", s.Path(p.SbAmount))
+
+	// Third player matched 0.25 of everyone, the rest of dead money
+	// is in the side pot.
+	pots := GetPots(s.Players)
+	require.Equal(t, chips.NewFromFloat(3.25+4), pots.Sum())
+	require.Len(t, pots, 2)
+	require.Equal(t, chips.NewFromFloat(0.75), pots[0].Amount)
+	require.Equal(t, []uint8{0, 1, 2}, pots[0].Players)
+	require.Equal(t, chips.NewFromFloat(6.5), pots[1].Amount)
+	require.Equal(t, []uint8{0, 1}, pots[1].Players)
+}
+
 func TestMakeAction_Fold(t *testing.T) {
 	p := GameParams{
 		NumPlayers:         2,
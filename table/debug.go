@@ -13,11 +13,17 @@ func Debug(s *State, params GameParams) string {
 	b.WriteString(fmt.Sprintf("Street: %s\n", s.Street))
 	b.WriteString(fmt.Sprintf("SB Amount: %s\n", params.SbAmount.StringFixed(2)))
 	b.WriteString(fmt.Sprintf("BB Amount: %s\n", params.SbAmount.Mul(2).StringFixed(2)))
+	if params.Ante.GreaterThan(0) || params.BBAnte.GreaterThan(0) {
+		b.WriteString(fmt.Sprintf("Ante: %s, BB Ante: %s\n", params.Ante.StringFixed(2), params.BBAnte.StringFixed(2)))
+	}
+	if len(params.Blinds) > 0 {
+		b.WriteString(fmt.Sprintf("Blinds: %s\n", params.Blinds))
+	}
 	b.WriteString(fmt.Sprintf("Street Action Count: %d\n", s.StreetAction))
 	b.WriteString(fmt.Sprintf("Call Amount: %s\n", s.CallAmount.StringFixed(2)))
 
 	// Get positions
-	btn, sb, bb := Positions(s)
+	btn, sb, bb := PositionsFor(params, s)
 
 	// Position info
 	b.WriteString("\nPositions:\n")
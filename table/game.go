@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/pokerdroid/poker/card"
 	"github.com/pokerdroid/poker/chips"
 	"github.com/pokerdroid/poker/encbin"
 )
@@ -21,6 +22,19 @@ type GameParams struct {
 	TerminalStreet     Street      `json:"terminal_street"`
 	MinBet             bool        `json:"min_bet"`
 	Limp               bool        `json:"limp"`
+	// Ante is posted by every player, BBAnte by big blind only.
+	// Both are dead money, they don't count as street commitment.
+	Ante   chips.Chips `json:"ante,omitempty"`
+	BBAnte chips.Chips `json:"bb_ante,omitempty"`
+	// Blinds override default small and big blind posting.
+	Blinds Blinds `json:"blinds,omitempty"`
+	// Structure is no limit by default, RaiseCap applies to fixed limit.
+	Structure BettingStructure `json:"structure,omitempty"`
+	RaiseCap  uint8            `json:"raise_cap,omitempty"`
+	// ActionAbs overrides BetSizes where its rules match.
+	ActionAbs ActionAbs `json:"action_abs,omitempty"`
+	// Variant decides deck and hand rankings, holdem by default.
+	Variant card.Variant `json:"variant,omitempty"`
 	// Disable validation to improve performance
 	DisableV bool `json:"disable_v"`
 }
@@ -45,6 +59,18 @@ func (g GameParams) Size() uint64 {
 
 	size += 1 + uint64(len(g.InitialStacks))*4 // Length prefix + float32 per stack
 
+	size += g.ActionAbs.Size()
+
+	size += 4 // Ante (chips.Chips - float32)
+	size += 4 // BBAnte (chips.Chips - float32)
+
+	size += g.Blinds.Size()
+
+	size += 1 // Structure (uint8)
+	size += 1 // RaiseCap (uint8)
+
+	size += 1 // Variant (uint8)
+
 	return size
 }
 
@@ -83,9 +109,38 @@ func (g GameParams) String() string {
 	sb.WriteString(" SB:")
 	sb.WriteString(g.SbAmount.String())
 	sb.WriteString("\n")
+	if g.Ante.GreaterThan(chips.Zero) || g.BBAnte.GreaterThan(chips.Zero) {
+		sb.WriteString(" Ante:")
+		sb.WriteString(g.Ante.String())
+		sb.WriteString(" BBAnte:")
+		sb.WriteString(g.BBAnte.String())
+		sb.WriteString("\n")
+	}
+	if len(g.Blinds) > 0 {
+		sb.WriteString(" Blinds:")
+		sb.WriteString(g.Blinds.String())
+		sb.WriteString("\n")
+	}
+	if g.Variant != card.Holdem {
+		sb.WriteString(" Variant:")
+		sb.WriteString(g.Variant.String())
+		sb.WriteString("\n")
+	}
+	if g.Structure != NoLimit {
+		sb.WriteString(" Structure:")
+		sb.WriteString(g.Structure.String())
+		sb.WriteString(" RaiseCap:")
+		sb.WriteString(strconv.Itoa(int(g.RaiseCap)))
+		sb.WriteString("\n")
+	}
 	sb.WriteString(" BetSizes:")
 	sb.WriteString(fmt.Sprint(g.BetSizes))
 	sb.WriteString("\n")
+	if len(g.ActionAbs) > 0 {
+		sb.WriteString(" ActionAbs:")
+		sb.WriteString(strings.ReplaceAll(g.ActionAbs.String(), "\n", "; "))
+		sb.WriteString("\n")
+	}
 	sb.WriteString(" MinBet:")
 	sb.WriteString(strconv.FormatBool(g.MinBet))
 	sb.WriteString("\n")
@@ -124,12 +179,42 @@ func NewGameParams(np uint8, stack chips.Chips) GameParams {
 	return g
 }
 
+// MultiwayActionAbs is action abstraction for 3 to 6 handed games.
+// Tree grows with every seat, so there are fewer sizes than heads up
+// and 4-bets are all-in only.
+var MultiwayActionAbs = mustParseActionAbs(`
+* spr<1: allin
+preflop r0: 1.5 2     # open to 2.25bb or 3bb
+preflop r1: 2 allin   # 3-bet
+preflop r2+: allin
+flop r0: 0.33 0.75
+* r0: 0.5 1
+* r1: 1 allin
+* r2+: allin
+`)
+
+// NewMultiwayGameParams returns params for 3 to 6 handed games, bet
+// sizes come from MultiwayActionAbs and there are at most two actions
+// per player on a street.
+func NewMultiwayGameParams(np uint8, stack chips.Chips) GameParams {
+	g := NewGameParams(np, stack)
+	g.MaxActionsPerRound = np * 2
+	g.ActionAbs = MultiwayActionAbs.Clone()
+	return g
+}
+
 func (g GameParams) Clone() GameParams {
 	prsm := GameParams{
 		NumPlayers:         g.NumPlayers,
 		MaxActionsPerRound: g.MaxActionsPerRound,
 		BtnPos:             g.BtnPos,
 		SbAmount:           g.SbAmount,
+		Ante:               g.Ante,
+		BBAnte:             g.BBAnte,
+		Blinds:             g.Blinds.Clone(),
+		Structure:          g.Structure,
+		RaiseCap:           g.RaiseCap,
+		Variant:            g.Variant,
 		TerminalStreet:     g.TerminalStreet,
 		DisableV:           g.DisableV,
 		MinBet:             g.MinBet,
@@ -142,6 +227,8 @@ func (g GameParams) Clone() GameParams {
 
 	prsm.InitialStacks = make(chips.List, len(g.InitialStacks))
 	copy(prsm.InitialStacks, g.InitialStacks)
+
+	prsm.ActionAbs = g.ActionAbs.Clone()
 	return prsm
 }
 
@@ -225,6 +312,46 @@ func (g GameParams) MarshalBinary() ([]byte, error) {
 		return nil, err
 	}
 
+	// Marshal ActionAbs
+	abs, err := g.ActionAbs.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = buf.Write(abs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Marshal antes
+	err = encbin.MarshalValues(buf, g.Ante, g.BBAnte)
+	if err != nil {
+		return nil, err
+	}
+
+	// Marshal Blinds
+	blinds, err := g.Blinds.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = buf.Write(blinds)
+	if err != nil {
+		return nil, err
+	}
+
+	// Marshal betting structure
+	err = encbin.MarshalValues(buf, g.Structure, g.RaiseCap)
+	if err != nil {
+		return nil, err
+	}
+
+	// Marshal variant
+	err = encbin.MarshalValues(buf, g.Variant)
+	if err != nil {
+		return nil, err
+	}
+
 	return buf.Bytes(), nil
 }
 
@@ -267,6 +394,61 @@ func (g *GameParams) UnmarshalBinary(data []byte) error {
 		return err
 	}
 
+	// Params stored before ActionAbs existed end here.
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	// Unmarshal ActionAbs
+	err = g.ActionAbs.unmarshal(buf)
+	if err != nil {
+		return err
+	}
+
+	// Params stored before antes existed end here.
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	// Unmarshal antes
+	err = encbin.UnmarshalValues(buf, &g.Ante, &g.BBAnte)
+	if err != nil {
+		return err
+	}
+
+	// Params stored before Blinds existed end here.
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	// Unmarshal Blinds
+	err = g.Blinds.unmarshal(buf)
+	if err != nil {
+		return err
+	}
+
+	// Params stored before betting structures existed end here.
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	// Unmarshal betting structure
+	err = encbin.UnmarshalValues(buf, &g.Structure, &g.RaiseCap)
+	if err != nil {
+		return err
+	}
+
+	// Params stored before variants existed end here.
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	// Unmarshal variant
+	err = encbin.UnmarshalValues(buf, &g.Variant)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
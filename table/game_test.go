@@ -3,6 +3,7 @@ package table
 import (
 	"testing"
 
+	"github.com/pokerdroid/poker/card"
 	"github.com/pokerdroid/poker/chips"
 	"github.com/stretchr/testify/require"
 )
@@ -143,6 +144,47 @@ func TestGameMarshal(t *testing.T) {
 				InitialStacks: chips.List{100, 100},
 			},
 		},
+		{
+			name: "antes",
+			params: GameParams{
+				NumPlayers:    3,
+				BetSizes:      [][]float32{{1}},
+				InitialStacks: chips.List{100, 100, 100},
+				Ante:          chips.NewFromFloat(0.25),
+				BBAnte:        chips.NewFromInt(2),
+			},
+		},
+		{
+			name: "straddle",
+			params: GameParams{
+				NumPlayers:    6,
+				BetSizes:      [][]float32{{1}},
+				InitialStacks: chips.List{100, 100, 100, 100, 100, 100},
+				SbAmount:      chips.NewFromInt(1),
+				Blinds:        StraddleBlinds(6, chips.NewFromInt(1), chips.NewFromInt(4), false),
+			},
+		},
+		{
+			name: "fixed limit",
+			params: GameParams{
+				NumPlayers:    2,
+				BetSizes:      [][]float32{{1}},
+				InitialStacks: chips.List{100, 100},
+				SbAmount:      chips.NewFromInt(1),
+				Structure:     FixedLimit,
+				RaiseCap:      5,
+			},
+		},
+		{
+			name: "short deck",
+			params: GameParams{
+				NumPlayers:    2,
+				BetSizes:      [][]float32{{1}},
+				InitialStacks: chips.List{100, 100},
+				SbAmount:      chips.NewFromInt(1),
+				Variant:       card.ShortDeck,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -166,6 +208,13 @@ func TestGameMarshal(t *testing.T) {
 			require.Equal(t, tt.params.MinBet, unmarshaled.MinBet)
 			require.Equal(t, tt.params.BetSizes, unmarshaled.BetSizes)
 			require.Equal(t, tt.params.InitialStacks, unmarshaled.InitialStacks)
+			require.Equal(t, tt.params.Ante, unmarshaled.Ante)
+			require.Equal(t, tt.params.BBAnte, unmarshaled.BBAnte)
+			require.Equal(t, tt.params.Blinds, unmarshaled.Blinds)
+			require.Equal(t, tt.params.Structure, unmarshaled.Structure)
+			require.Equal(t, tt.params.RaiseCap, unmarshaled.RaiseCap)
+			require.Equal(t, tt.params.Variant, unmarshaled.Variant)
+			require.Equal(t, tt.params.Size(), uint64(len(data)))
 		})
 	}
 }
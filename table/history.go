@@ -24,7 +24,7 @@ func (h History) String() string {
 
 	var y int
 	for z, action := range h {
-		if action.Action.Action.IsBlind() {
+		if action.Action.Action.IsForced() {
 			y++
 			continue
 		}
@@ -72,8 +72,20 @@ func (s *State) Path(sb chips.Chips) string {
 	h.WriteString("r")
 	var st Street
 
-	for _, x := range s.History() {
-		if x.Action.Action.IsBlind() {
+	hist := s.History()
+
+	antes := chips.Zero
+	for _, x := range hist {
+		if x.Action.Action == Ante {
+			antes = antes.Add(x.Action.Amount)
+		}
+	}
+	if antes.GreaterThan(chips.Zero) {
+		h.WriteString(fmt.Sprintf(":ante%.2f", antes.Div(sb).Float64()))
+	}
+
+	for _, x := range hist {
+		if x.Action.Action.IsForced() {
 			continue
 		}
 		if x.State.Street != st {
@@ -119,6 +131,20 @@ func (s *State) History() History {
 		}
 
 		for i := range cur.PSAC {
+			// Antes are paid without acting.
+			if cur.PSAC[i] == prev.PSAC[i] && cur.Players[i].Paid.GreaterThan(prev.Players[i].Paid) {
+				actions = append(actions, PlayerAction{
+					Pos:    uint8(i),
+					Street: cur.Street,
+					Action: ActionAmount{
+						Action: Ante,
+						Amount: cur.Players[i].Paid.Sub(prev.Players[i].Paid),
+					},
+					State: prev,
+				})
+				continue
+			}
+
 			if cur.PSAC[i] == prev.PSAC[i] {
 				continue
 			}
@@ -2,6 +2,7 @@ package table
 
 import (
 	"bytes"
+	"math"
 	"sort"
 
 	"github.com/pokerdroid/poker/card"
@@ -130,47 +131,60 @@ func (pp Pots) Sum() chips.Chips {
 	return sum
 }
 
-func GetPots(p Players) (pots Pots) {
-	allin := p.Indicies(IsAllIn)
+// GetPots splits paid chips into main pot and side pots. Main pot
+// is first and every player still in the hand is eligible for it,
+// side pots follow from the smallest all-in. Chips nobody called
+// are in the last pot with the only player eligible for them.
+func GetPots(p Players) Pots {
+	// Pots are split at every all-in amount and the biggest one.
+	levels := make([]chips.Chips, 0, len(p)+1)
+	for _, pl := range p {
+		if pl.Status == StatusAllIn {
+			levels = append(levels, pl.Paid)
+		}
+	}
+	levels = append(levels, p.PaidMax())
 
-	// Sort all-in players by their paid amount in ascending order
-	sort.Slice(allin, func(i, j int) bool {
-		return p[allin[i]].Paid.LessThan(p[allin[j]].Paid)
+	sort.Slice(levels, func(i, j int) bool {
+		return levels[i].LessThan(levels[j])
 	})
 
-	side := Pots{}
-	for _, pi := range allin {
-		sidePotSum := side.Sum()
-		pot := p[pi].Paid.Sub(sidePotSum)
+	var pots Pots
+	prev := chips.Zero
+
+	for _, level := range levels {
+		if !level.GreaterThan(prev) {
+			continue
+		}
+
+		amount := chips.Zero
+		for _, pl := range p {
+			amount = amount.Add(chips.Max(chips.Min(pl.Paid, level).Sub(prev), chips.Zero))
+		}
+
+		// Only all-in players are capped, antes make the paid amounts
+		// of players still betting differ.
 		eligible := []uint8{}
-		for i, ep := range p {
-			if ep.Paid.GreaterThanOrEqual(p[pi].Paid) && ep.Status != StatusFolded {
+		for i, pl := range p {
+			if pl.Status == StatusFolded {
+				continue
+			}
+			if pl.Status != StatusAllIn || pl.Paid.GreaterThanOrEqual(level) {
 				eligible = append(eligible, uint8(i))
 			}
 		}
-		side = append(side, Pot{
-			Amount:  pot,
-			Players: eligible,
-		})
-	}
 
-	maxpay := p.PaidMax()
-	eligible := []uint8{}
+		prev = level
 
-	for id, player := range p {
-		if player.Paid.Equal(maxpay) && player.Status != StatusFolded {
-			eligible = append(eligible, uint8(id))
+		// Folded players paid more than anyone left.
+		if len(eligible) == 0 && len(pots) > 0 {
+			pots[len(pots)-1].Amount = pots[len(pots)-1].Amount.Add(amount)
+			continue
 		}
-	}
 
-	mp := Pot{
-		Amount:  p.PaidSum().Sub(side.Sum()),
-		Players: eligible,
+		pots = append(pots, Pot{Amount: amount, Players: eligible})
 	}
 
-	pots = append(pots, mp)
-	pots = append(pots, side...)
-
 	return pots
 }
 
@@ -200,10 +214,15 @@ func GetWinners(ss *State, judge Judger) []uint8 {
 }
 
 func GetWinnings(pp Players, judge Judger) chips.List {
-	winnings := chips.NewListAlloc(len(pp))
+	return GetPotsWinnings(len(pp), GetPots(pp), judge)
+}
+
+// GetPotsWinnings splits given pots between np players. Each pot
+// goes to the best hands among players eligible for it.
+func GetPotsWinnings(np int, pots Pots, judge Judger) chips.List {
+	winnings := chips.NewListAlloc(np)
 
-	// Get pots, main and side pots
-	for _, pot := range GetPots(pp) {
+	for _, pot := range pots {
 		// If someone folds
 		if len(pot.Players) == 1 {
 			winnings[pot.Players[0]] = winnings[pot.Players[0]].Add(pot.Amount)
@@ -220,17 +239,34 @@ func GetWinnings(pp Players, judge Judger) chips.List {
 		}
 
 		// If there is a tie, split the pot
-		prize := pot.Amount.Div(chips.New(len(winners)))
+		share, odd := SplitPot(pot.Amount, len(winners))
 		for _, winner := range winners {
-			winnings[winner] = winnings[winner].Add(prize)
+			winnings[winner] = winnings[winner].Add(share)
 		}
+		winnings[winners[0]] = winnings[winners[0]].Add(odd)
 	}
 	return winnings
 }
 
+// SplitPot splits amount evenly between n winners in cents. Odd
+// cents that can't be split go to the first winner.
+func SplitPot(amount chips.Chips, n int) (share, odd chips.Chips) {
+	if n <= 1 {
+		return amount, chips.Zero
+	}
+
+	cents := int64(math.Round(amount.Float64() * 100))
+	share = chips.NewFromInt(cents / int64(n)).Div(100)
+	odd = amount.Sub(share.Mul(chips.New(n)))
+
+	return share, odd
+}
+
 type Cards struct {
 	Community card.Cards
 	Players   []card.Cards
+	// Variant of hand rankings, holdem by default.
+	Variant card.Variant
 }
 
 func (d *Cards) Judge(pp []uint8) []uint8 {
@@ -240,5 +276,11 @@ func (d *Cards) Judge(pp []uint8) []uint8 {
 		hands = append(hands, d.Players[p].Clone())
 	}
 
-	return eval.MustJudgeBoard(hands, d.Community)
+	// Judge returns indexes of hands, map them to players.
+	winners := eval.MustJudgeBoardVariant(d.Variant, hands, d.Community)
+	for i, w := range winners {
+		winners[i] = pp[w]
+	}
+
+	return winners
 }
@@ -540,3 +540,85 @@ func TestPot_Size(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPotsMultiway(t *testing.T) {
+	pp := Players{
+		{Paid: chips.NewFromInt(10), Status: StatusAllIn},
+		{Paid: chips.NewFromInt(30), Status: StatusAllIn},
+		{Paid: chips.NewFromInt(50), Status: StatusActive},
+		{Paid: chips.NewFromInt(20), Status: StatusFolded},
+	}
+
+	pots := GetPots(pp)
+	require.Equal(t, Pots{
+		{Amount: chips.NewFromInt(40), Players: []uint8{0, 1, 2}},
+		{Amount: chips.NewFromInt(50), Players: []uint8{1, 2}},
+		{Amount: chips.NewFromInt(20), Players: []uint8{2}},
+	}, pots)
+
+	tests := []struct {
+		name     string
+		ranks    map[uint8]uint8
+		expected chips.List
+	}{
+		{
+			name:     "short stack wins main pot",
+			ranks:    map[uint8]uint8{0: 0, 1: 1, 2: 2},
+			expected: chips.NewList(40, 50, 20, 0),
+		},
+		{
+			name:     "tie for main pot",
+			ranks:    map[uint8]uint8{0: 0, 1: 0, 2: 1},
+			expected: chips.NewList(20, 70, 20, 0),
+		},
+		{
+			name:     "three way tie",
+			ranks:    map[uint8]uint8{0: 0, 1: 0, 2: 0},
+			expected: chips.NewList(13.34, 38.33, 58.33, 0),
+		},
+		{
+			name:     "biggest stack wins everything",
+			ranks:    map[uint8]uint8{0: 1, 1: 1, 2: 0},
+			expected: chips.NewList(0, 0, 110, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := GetWinnings(pp, testJudge{winners: tt.ranks})
+			require.InDelta(t, pp.PaidSum().Float64(), w.Sum().Float64(), 1e-4)
+			for i := range w {
+				require.InDelta(t, tt.expected[i].Float64(), w[i].Float64(), 1e-4, i)
+			}
+		})
+	}
+}
+
+func TestSplitPot(t *testing.T) {
+	share, odd := SplitPot(chips.NewFromInt(10), 2)
+	require.Equal(t, chips.NewFromInt(5), share)
+	require.Equal(t, chips.Zero, odd)
+
+	share, odd = SplitPot(chips.NewFromInt(10), 3)
+	require.InDelta(t, 3.33, share.Float64(), 1e-6)
+	require.InDelta(t, 0.01, odd.Float64(), 1e-6)
+
+	share, odd = SplitPot(chips.NewFromFloat(1.01), 2)
+	require.InDelta(t, 0.5, share.Float64(), 1e-6)
+	require.InDelta(t, 0.01, odd.Float64(), 1e-6)
+}
+
+func TestCards_JudgeSubset(t *testing.T) {
+	c := Cards{
+		Community: card.NewCardsFromString("2c 3s 8h 6c 4d"),
+		Players: []card.Cards{
+			card.NewCardsFromString("ac ad"),
+			card.NewCardsFromString("kc kd"),
+			card.NewCardsFromString("qc qd"),
+		},
+	}
+
+	// Winners are players, not indexes of the subset.
+	require.Equal(t, []uint8{1}, c.Judge([]uint8{1, 2}))
+	require.Equal(t, []uint8{0}, c.Judge([]uint8{0, 1, 2}))
+}
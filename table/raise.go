@@ -0,0 +1,74 @@
+package table
+
+import "github.com/pokerdroid/poker/chips"
+
+// FullRaise returns the smallest bet or raise increment allowed on
+// street. It is the last full bet or raise, at least big blind.
+// Incomplete all-in raises don't change it.
+func FullRaise(p GameParams, r *State) chips.Chips {
+	if p.Structure == FixedLimit {
+		return p.LimitBet(r.Street)
+	}
+	return chips.Max(r.FR, p.SbAmount.Mul(2))
+}
+
+// reopenRaise returns how much biggest commitment has to grow to
+// count as full raise. In fixed limit all-in of at least half a bet
+// is a full raise.
+func reopenRaise(p GameParams, r *State) chips.Chips {
+	if p.Structure == FixedLimit {
+		return p.LimitBet(r.Street).Div(2)
+	}
+	return FullRaise(p, r)
+}
+
+// RaiseOpen returns true if player on turn may raise. Player who
+// already acted on street and since then faced only incomplete
+// all-in raises can just call or fold.
+func RaiseOpen(p GameParams, r *State) bool {
+	pos := r.TurnPos
+
+	// State without full raise tracking.
+	if len(r.PSF) == 0 {
+		return true
+	}
+
+	// Player didn't act yet or posted blind only.
+	if r.PSAC[pos] == 0 || r.PSLA[pos].IsBlind() {
+		return true
+	}
+
+	// Player checked, any bet opens betting.
+	if r.PSF[pos].Equal(chips.Zero) {
+		return true
+	}
+
+	return r.PSC.Max().Sub(r.PSF[pos]).GreaterThanOrEqual(reopenRaise(p, r))
+}
+
+// trackRaise updates full raise and faced commitment of player at
+// pos after commitment went from prev to the current one.
+func trackRaise(p GameParams, r *State, pos uint8, action ActionKind, prev chips.Chips) {
+	if len(r.PSF) == 0 {
+		return
+	}
+
+	max := r.PSC.Max()
+
+	switch {
+	case action.IsBlind():
+		// Live blind is a bet of its size.
+		if max.GreaterThan(r.FR) {
+			r.FR = max
+		}
+		return
+
+	case max.GreaterThan(prev):
+		inc := max.Sub(prev)
+		if inc.GreaterThanOrEqual(reopenRaise(p, r)) {
+			r.FR = inc
+		}
+	}
+
+	r.PSF[pos] = max
+}
@@ -0,0 +1,170 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/pokerdroid/poker/chips"
+	"github.com/stretchr/testify/require"
+)
+
+// Ring blinds 1/2. With 3 players P0 is button, P1 small blind, P2 big
+// blind. With 4 players P3 is UTG and acts first. Raise amounts are
+// chips put in by the action, zero raise means player can't raise.
+func TestRaiseReopening(t *testing.T) {
+	raise := func(amount int64) ActionAmount {
+		return ActionAmount{Action: Raise, Amount: chips.NewFromInt(amount)}
+	}
+
+	tests := []struct {
+		name    string
+		stacks  chips.List
+		actions []Actioner
+		turn    uint8
+		call    chips.Chips
+		raise   chips.Chips
+	}{
+		{
+			name:    "open raise",
+			stacks:  chips.NewList(100, 100, 100, 100),
+			actions: []Actioner{raise(6)},
+			turn:    0,
+			call:    6,
+			raise:   10,
+		},
+		{
+			name:    "incomplete all-in keeps min raise for players yet to act",
+			stacks:  chips.NewList(9, 100, 100, 100),
+			actions: []Actioner{raise(6), DAllIn},
+			turn:    1,
+			call:    8,
+			raise:   12,
+		},
+		{
+			name:    "incomplete all-in does not reopen raiser",
+			stacks:  chips.NewList(9, 100, 100, 100),
+			actions: []Actioner{raise(6), DAllIn, DCall, DCall},
+			turn:    3,
+			call:    3,
+		},
+		{
+			name:    "two incomplete all-ins reopen together",
+			stacks:  chips.NewList(9, 12, 100, 100),
+			actions: []Actioner{raise(6), DAllIn, DAllIn, DCall},
+			turn:    3,
+			call:    6,
+			raise:   10,
+		},
+		{
+			name:    "full all-in reopens",
+			stacks:  chips.NewList(10, 100, 100, 100),
+			actions: []Actioner{raise(6), DAllIn, DFold, DCall},
+			turn:    3,
+			call:    4,
+			raise:   8,
+		},
+		{
+			name:    "bigger all-in sets full raise",
+			stacks:  chips.NewList(20, 100, 100, 100),
+			actions: []Actioner{raise(6), DAllIn, DFold},
+			turn:    2,
+			call:    18,
+			raise:   32,
+		},
+		{
+			name:    "raiser faces incomplete re-raise",
+			stacks:  chips.NewList(100, 100, 8),
+			actions: []Actioner{raise(6), DFold, DAllIn},
+			turn:    0,
+			call:    2,
+		},
+		{
+			name:    "re-raise after incomplete all-in uses last full raise",
+			stacks:  chips.NewList(100, 9, 100),
+			actions: []Actioner{raise(6), DAllIn},
+			turn:    2,
+			call:    7,
+			raise:   11,
+		},
+		{
+			name:    "full re-raise after incomplete all-in reopens raiser",
+			stacks:  chips.NewList(100, 9, 100),
+			actions: []Actioner{raise(6), DAllIn, raise(11)},
+			turn:    0,
+			call:    7,
+			raise:   11,
+		},
+		{
+			name:    "big blind keeps option after short all-in",
+			stacks:  chips.NewList(3, 100, 100),
+			actions: []Actioner{DAllIn, DCall},
+			turn:    2,
+			call:    1,
+			raise:   3,
+		},
+		{
+			name:    "short all-in bet reopens player who checked",
+			stacks:  chips.NewList(100, 100, 3),
+			actions: []Actioner{DCall, DCall, DCheck, DCheck, DAllIn, DCall},
+			turn:    1,
+			call:    1,
+			raise:   3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewGameParams(uint8(len(tt.stacks)), chips.NewFromInt(100))
+			p.InitialStacks = tt.stacks
+			p.Blinds = DefaultBlinds(p.NumPlayers, p.SbAmount)
+			p.Limp = true
+
+			game, err := NewGame(p)
+			require.NoError(t, err)
+
+			for _, a := range tt.actions {
+				require.NoError(t, game.Action(a), a)
+			}
+
+			s := game.Latest
+			require.Equal(t, tt.turn, s.TurnPos)
+
+			la := NewLegalActions(p, s)
+			require.Equal(t, tt.call, la[Call])
+
+			if tt.raise.Equal(chips.Zero) {
+				require.False(t, RaiseOpen(p, s))
+				require.NotContains(t, la, Raise)
+				require.NotContains(t, la, AllIn)
+				require.Error(t, la.Validate(p, s, ActionAmount{Action: AllIn, Amount: p.InitialStacks[s.TurnPos].Sub(s.Players[s.TurnPos].Paid)}))
+				return
+			}
+
+			require.True(t, RaiseOpen(p, s))
+			require.Equal(t, tt.raise, la[Raise])
+			require.Contains(t, la, AllIn)
+		})
+	}
+}
+
+func TestStateMarshalLegacy(t *testing.T) {
+	p := NewGameParams(3, chips.NewFromInt(100))
+
+	game, err := NewGame(p)
+	require.NoError(t, err)
+	require.NoError(t, game.Action(ActionAmount{Action: Raise, Amount: chips.NewFromInt(6)}))
+
+	data, err := game.Latest.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, game.Latest.Size(), uint64(len(data)))
+
+	var s State
+	require.NoError(t, s.UnmarshalBinary(data))
+	require.True(t, game.Latest.Equal(&s))
+	require.Equal(t, chips.NewFromInt(4), s.FR)
+
+	// State stored before full raise tracking always reopens betting.
+	var legacy State
+	require.NoError(t, legacy.UnmarshalBinary(data[:len(data)-4-1-3*4]))
+	require.Empty(t, legacy.PSF)
+	require.True(t, RaiseOpen(p, &legacy))
+}
@@ -110,10 +110,10 @@ func HaveToAct(s *State) (hta int) {
 		if s.PSC[pi].LessThan(psc) ||
 			// Need to act
 			s.PSAC[pi] == 0 ||
-			// Need to act on preflop if you are big blind
+			// Need to act on preflop if you are big blind or straddle
 			// If you are small blind you have to call so that is
 			// covered by the previous check
-			(s.Street == Preflop && s.PSAC[pi] == 1 && s.PSLA[pi] == BigBlind) {
+			(s.Street == Preflop && s.PSAC[pi] == 1 && s.PSLA[pi].IsBlind()) {
 			hta++
 			continue
 		}
@@ -129,10 +129,10 @@ func Move(p GameParams, r *State) (state *State, err error) {
 		return state, ShiftTurn(p, state)
 
 	case RuleShiftStreet:
-		return state, ShiftStreet(state)
+		return state, ShiftStreet(p, state)
 
 	case RuleShiftStreetUntilEnd:
-		err := ShiftStreet(state)
+		err := ShiftStreet(p, state)
 		if err != nil {
 			return nil, err
 		}
@@ -168,20 +168,22 @@ func ShiftTurn(p GameParams, r *State) error {
 	return nil
 }
 
-func ShiftStreet(r *State) error {
+func ShiftStreet(p GameParams, r *State) error {
 	r.Street++
 
 	if r.Street == Finished {
 		return nil
 	}
 
-	ShiftTurnStreetStart(r)
+	ShiftTurnStreetStart(p, r)
 	r.StreetAction = 0
 	r.BetAction = 0
 
 	r.PSC = chips.NewListAlloc(len(r.Players))
 	r.PSAC = make([]uint8, len(r.Players))
 	r.PSLA = make([]ActionKind, len(r.Players))
+	r.PSF = chips.NewListAlloc(len(r.Players))
+	r.FR = chips.Zero
 	//
 	r.BSC.Addition = chips.Zero
 	r.BSC.Amount = chips.Zero
@@ -191,24 +193,10 @@ func ShiftStreet(r *State) error {
 	return nil
 }
 
-func ShiftTurnStreetStart(r *State) {
-	btn, sb, bb := Positions(r)
-
-	if len(r.Players) == 2 {
-		if r.Street == Preflop {
-			r.TurnPos = sb
-		} else {
-			r.TurnPos = bb
-		}
-		return
-	}
-
-	nis := bb
-	if r.Street == Preflop {
-		nis = btn
-	}
-
-	np := r.Players.FindWaitingPos(int(nis + 1))
+// ShiftTurnStreetStart moves turn to the small blind or first player
+// after it, heads up to the big blind.
+func ShiftTurnStreetStart(p GameParams, r *State) {
+	np := r.Players.FindWaitingPos(int(streetStart(p, r)))
 	if np == -1 {
 		return
 	}
@@ -216,6 +204,9 @@ func ShiftTurnStreetStart(r *State) {
 	r.TurnPos = uint8(np)
 }
 
+// Positions returns button, small blind and big blind seats of ring
+// positions, heads up the button is small blind. It doesn't know how
+// the game posts blinds, table rules use PositionsFor.
 func Positions(s *State) (uint8, uint8, uint8) {
 	pLen := uint8(len(s.Players))
 	btn := s.BtnPos % pLen
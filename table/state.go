@@ -40,6 +40,13 @@ type State struct {
 	PSAC []uint8 `json:"psac"`
 	// Per Player street last action
 	PSLA []ActionKind `json:"psla"`
+	// Full Raise is size of the last full bet or raise on street,
+	// incomplete all-in raise doesn't change it.
+	FR chips.Chips `json:"fr"`
+	// Per Player Street Faced commitment, biggest commitment on
+	// street after player's last voluntary action. Player can raise
+	// again only if it grew by a full raise since.
+	PSF chips.List `json:"psf"`
 
 	// This is for debug purposes
 	// should not be serialized or accounted for
@@ -71,6 +78,7 @@ func NewState(params GameParams) *State {
 		PSC:          chips.NewListAlloc(params.NumPlayers),
 		PSAC:         make([]uint8, params.NumPlayers),
 		PSLA:         make([]ActionKind, params.NumPlayers),
+		PSF:          chips.NewListAlloc(params.NumPlayers),
 		Previous:     nil,
 	}
 }
@@ -85,6 +93,8 @@ func (r *State) Next() *State {
 		BetAction:    r.BetAction,
 		BSC:          r.BSC,
 		PSC:          r.PSC.Copy(),
+		FR:           r.FR,
+		PSF:          r.PSF.Copy(),
 		StreetAction: r.StreetAction,
 		PSAC:         make([]uint8, len(r.PSAC)),
 		PSLA:         make([]ActionKind, len(r.PSLA)),
@@ -154,6 +164,10 @@ func (s *State) Equal(other *State) bool {
 		return false
 	}
 
+	if !s.FR.Equal(other.FR) {
+		return false
+	}
+
 	// Compare BSC
 	if !s.BSC.Amount.Equal(other.BSC.Amount) ||
 		!s.BSC.Addition.Equal(other.BSC.Addition) ||
@@ -202,6 +216,16 @@ func (s *State) Equal(other *State) bool {
 		}
 	}
 
+	// Compare PSF (Player Street Faced commitment)
+	if len(s.PSF) != len(other.PSF) {
+		return false
+	}
+	for i := range s.PSF {
+		if !s.PSF[i].Equal(other.PSF[i]) {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -249,6 +273,17 @@ func (s State) MarshalBinary() ([]byte, error) {
 		return nil, err
 	}
 
+	// Marshal full raise tracking
+	err = encbin.MarshalValues(buf, s.FR)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encbin.MarshalSliceLen[chips.Chips, uint8](buf, s.PSF)
+	if err != nil {
+		return nil, err
+	}
+
 	return buf.Bytes(), nil
 }
 
@@ -296,6 +331,23 @@ func (s *State) UnmarshalBinary(data []byte) error {
 		return err
 	}
 
+	// States stored before full raise tracking end here,
+	// betting is always reopened for them.
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	// Unmarshal full raise tracking
+	err = encbin.UnmarshalValues(buf, &s.FR)
+	if err != nil {
+		return err
+	}
+
+	s.PSF, err = encbin.UnmarhsalSliceLen[chips.Chips, uint8](buf)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -324,12 +376,15 @@ func (s *State) Size() uint64 {
 	size += uint64(len(s.PSC)) * 4     // PSC (chips.List - []float32)
 	size += uint64(len(s.PSAC))        // PSAC ([]uint8)
 	size += uint64(len(s.PSLA))        // PSLA ([]ActionKind - []uint8)
+	size += 4                          // FR (chips.Chips)
+	size += uint64(len(s.PSF)) * 4     // PSF (chips.List - []float32)
 
 	// Length prefixes for slices
 	size += 1 // Players length
 	size += 1 // PSC length
 	size += 1 // PSAC length
 	size += 1 // PSLA length
+	size += 1 // PSF length
 
 	return size
 }
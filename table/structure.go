@@ -0,0 +1,101 @@
+package table
+
+import (
+	"errors"
+
+	"github.com/pokerdroid/poker/chips"
+)
+
+// BettingStructure limits how much can be bet or raised.
+type BettingStructure uint8
+
+const (
+	NoLimit BettingStructure = iota
+	// PotLimit caps raise to pot after call.
+	PotLimit
+	// FixedLimit bets small bet (big blind) preflop and on flop,
+	// big bet (two big blinds) on turn and river. Number of bets
+	// on street is capped by RaiseCap.
+	FixedLimit
+)
+
+// DefaultRaiseCap is number of bets per street in fixed limit
+// when GameParams.RaiseCap is not set: bet, raise, 3-bet and cap.
+const DefaultRaiseCap = 4
+
+func NewBettingStructureFromString(str string) (BettingStructure, error) {
+	switch str {
+	case "nl", "nolimit", "":
+		return NoLimit, nil
+	case "pl", "potlimit":
+		return PotLimit, nil
+	case "fl", "limit", "fixedlimit":
+		return FixedLimit, nil
+	default:
+		return 0, errors.New("unknown betting structure")
+	}
+}
+
+func (b BettingStructure) String() string {
+	switch b {
+	case NoLimit:
+		return "nolimit"
+	case PotLimit:
+		return "potlimit"
+	case FixedLimit:
+		return "fixedlimit"
+	default:
+		return "unknown"
+	}
+}
+
+// LimitBet returns size of a bet in fixed limit on given street.
+func (g GameParams) LimitBet(s Street) chips.Chips {
+	bb := g.SbAmount.Mul(2)
+	if s >= Turn {
+		return bb.Mul(2)
+	}
+	return bb
+}
+
+// LimitCapped returns true if no more bets are allowed on street.
+// Preflop live blinds count as bets, big blind is the first one.
+func LimitCapped(p GameParams, r *State) bool {
+	if p.Structure != FixedLimit {
+		return false
+	}
+
+	limit := p.RaiseCap
+	if limit == 0 {
+		limit = DefaultRaiseCap
+	}
+
+	bets := r.BetAction
+	if r.Street == Preflop && p.liveBets() > 0 {
+		bets += p.liveBets() - 1
+	}
+
+	return bets >= limit
+}
+
+// MaxRaise returns most chips player on turn can put in with a bet
+// or raise, including the call. In no limit that is the whole stack.
+func MaxRaise(p GameParams, r *State) chips.Chips {
+	stack := p.InitialStacks[r.TurnPos].Sub(r.Players[r.TurnPos].Paid)
+	call := r.CallAmount
+
+	switch p.Structure {
+	case PotLimit:
+		// Call first, then raise by the pot.
+		pot := r.Players.PaidSum().Add(call)
+		return chips.Min(stack, call.Add(pot))
+
+	case FixedLimit:
+		if LimitCapped(p, r) {
+			return chips.Min(stack, call)
+		}
+		return chips.Min(stack, call.Add(p.LimitBet(r.Street)))
+	}
+
+	return stack
+}
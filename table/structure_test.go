@@ -0,0 +1,111 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/pokerdroid/poker/chips"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixedLimit(t *testing.T) {
+	p := NewGameParams(2, chips.NewFromInt(100))
+	p.Structure = FixedLimit
+	p.Limp = true
+
+	s, err := MakeInitialBets(p, NewState(p))
+	require.NoError(t, err)
+
+	// Small blind completes or raises to two small bets.
+	la := NewLegalActions(p, s)
+	require.Equal(t, chips.NewFromInt(3), la[Raise])
+	require.NotContains(t, la, AllIn)
+	require.Equal(t, chips.NewFromInt(3), MaxRaise(p, s))
+
+	err = la.Validate(p, s, ActionAmount{Action: Raise, Amount: chips.NewFromInt(5)})
+	require.Error(t, err)
+
+	dla := NewDiscreteLegalActions(p, s)
+	require.Len(t, dla, 3)
+
+	// Raise until capped, big blind is the first bet.
+	for i := 0; i < 3; i++ {
+		dla = NewDiscreteLegalActions(p, s)
+		var raise DiscreteAction
+		for a := range dla {
+			if a > 0 {
+				raise = a
+			}
+		}
+		require.NotZero(t, raise, i)
+
+		s, err = MakeAction(p, s, raise)
+		require.NoError(t, err)
+		s, err = Move(p, s)
+		require.NoError(t, err)
+	}
+
+	require.True(t, LimitCapped(p, s))
+	require.Equal(t, chips.NewList(8, 6), s.PSC)
+	require.ElementsMatch(t, []DiscreteAction{DFold, DCall}, NewDiscreteLegalActions(p, s).List())
+
+	s, err = MakeAction(p, s, DCall)
+	require.NoError(t, err)
+	s, err = Move(p, s)
+	require.NoError(t, err)
+
+	// Flop bet is a small bet, turn is a big bet.
+	require.Equal(t, Flop, s.Street)
+	require.Equal(t, chips.NewFromInt(2), NewLegalActions(p, s)[Bet])
+	require.Equal(t, chips.NewFromInt(4), p.LimitBet(Turn))
+}
+
+func TestPotLimit(t *testing.T) {
+	p := NewGameParams(2, chips.NewFromInt(100))
+	p.Structure = PotLimit
+	p.Limp = true
+	p.BetSizes = [][]float32{{0.5, 1, 2, 5}}
+
+	s, err := MakeInitialBets(p, NewState(p))
+	require.NoError(t, err)
+
+	// Call 1, pot is 4, small blind can put in 5 at most.
+	require.Equal(t, chips.NewFromInt(5), MaxRaise(p, s))
+
+	la := NewLegalActions(p, s)
+	require.NotContains(t, la, AllIn)
+	require.Equal(t, chips.NewFromInt(3), la[Raise])
+
+	err = la.Validate(p, s, ActionAmount{Action: Raise, Amount: chips.NewFromInt(6)})
+	require.Error(t, err)
+	err = la.Validate(p, s, ActionAmount{Action: Raise, Amount: chips.NewFromInt(5)})
+	require.NoError(t, err)
+
+	// Sizes over the limit collapse into the pot sized raise.
+	dla := NewDiscreteLegalActions(p, s)
+	pot := DiscreteAction(chips.NewFromInt(5).Div(chips.NewFromInt(3)))
+	require.ElementsMatch(t, []DiscreteAction{DFold, DCall, 1, pot}, dla.List())
+	require.Equal(t, chips.NewFromInt(5), dla[pot])
+
+	a, amount := pot.GetAction(p, s)
+	require.Equal(t, Raise, a)
+	require.Equal(t, chips.NewFromInt(5), amount)
+
+	// Oversized discrete action is clamped to the pot.
+	a, amount = DiscreteAction(5).GetAction(p, s)
+	require.Equal(t, Raise, a)
+	require.Equal(t, chips.NewFromInt(5), amount)
+
+	// Short stack can go all in within the pot.
+	p.InitialStacks = chips.NewList(4, 100)
+	require.Contains(t, NewLegalActions(p, s), AllIn)
+}
+
+func TestNoLimitMaxRaise(t *testing.T) {
+	p := NewGameParams(2, chips.NewFromInt(100))
+
+	s, err := MakeInitialBets(p, NewState(p))
+	require.NoError(t, err)
+
+	require.Equal(t, chips.NewFromInt(99), MaxRaise(p, s))
+	require.Contains(t, NewLegalActions(p, s), AllIn)
+}
@@ -0,0 +1,196 @@
+// Package export dumps strategies stored in a tree into formats
+// other tools can consume: JSON, CSV and range text ("AKs:0.5,QQ:1").
+package export
+
+import (
+	"errors"
+
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/table"
+	"github.com/pokerdroid/poker/tree"
+)
+
+// Hand is the average strategy of a single combo ("AhKh")
+// or of a hand class ("AKs").
+type Hand struct {
+	Hand     string    `json:"hand"`
+	Strategy []float64 `json:"strategy"`
+	// Weight is the frequency the hand reaches the node, mean of its
+	// combos for hand classes. Set only with Params.Reach.
+	Weight float64 `json:"weight,omitempty"`
+}
+
+// Node is exported strategy of a single player node.
+type Node struct {
+	Path    string                 `json:"path"`
+	Street  string                 `json:"street"`
+	Player  uint8                  `json:"player"`
+	Actions []table.DiscreteAction `json:"actions"`
+	Hands   []Hand                 `json:"hands"`
+	// Ranges holds range text for every action, it is
+	// the frequency each hand takes the action.
+	Ranges []string `json:"ranges"`
+	// Reach is the frequency the node is reached, see
+	// tree.NodeRanges. Set only with Params.Reach.
+	Reach float64 `json:"reach,omitempty"`
+}
+
+type Params struct {
+	Tree  tree.Node
+	Abs   abs.Mapper
+	Depth int
+	Board card.Cards
+	// Classes will aggregate 1326 combos into 169 hand classes.
+	Classes bool
+	// Reach adds reach of every node and weights of its hands from
+	// tree.Ranges, Tree must be the root.
+	Reach bool
+}
+
+// New walks the tree up to Depth and exports strategy of every
+// player node. Nodes on streets the board doesn't cover are skipped.
+func New(p Params) (nodes []Node, err error) {
+	if p.Tree == nil {
+		return nil, errors.New("tree is nil")
+	}
+
+	if p.Abs == nil {
+		return nil, errors.New("abstraction is nil")
+	}
+
+	if len(p.Board) > 5 || len(p.Board) == 1 || len(p.Board) == 2 {
+		return nil, errors.New("board must have 0, 3, 4 or 5 cards")
+	}
+
+	var ranges *tree.Ranges
+	if p.Reach {
+		root, ok := p.Tree.(*tree.Root)
+		if !ok {
+			return nil, errors.New("reach needs root of the tree")
+		}
+		// Only exported nodes and their ancestors are annotated.
+		ranges, err = tree.NewRanges(root, p.Abs, p.Board)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tree.Visit(p.Tree, p.Depth, func(n tree.Node, _ []tree.Node, _ int) bool {
+		player, ok := n.(*tree.Player)
+		if !ok {
+			return true
+		}
+
+		board, ok := tree.StreetBoard(player.State.Street, p.Board)
+		if !ok {
+			return false
+		}
+
+		if player.Actions == nil || len(player.Actions.Actions) == 0 {
+			return true
+		}
+
+		node := Node{
+			Path:    tree.GetPath(player).String(),
+			Street:  player.State.Street.String(),
+			Player:  player.TurnPos,
+			Actions: player.Actions.Actions,
+		}
+
+		var weights *card.RangeDist
+		if ranges != nil {
+			if nr, ok := ranges.Get(player); ok {
+				node.Reach = nr.Reach
+				weights = &nr.Weights[player.TurnPos]
+			}
+		}
+
+		if p.Classes {
+			node.Hands = classes(player, board, p.Abs, weights)
+		} else {
+			node.Hands = combos(player, board, p.Abs, weights)
+		}
+
+		node.Ranges = make([]string, len(node.Actions))
+		for i := range node.Actions {
+			node.Ranges[i] = RangeText(node.Hands, i)
+		}
+
+		nodes = append(nodes, node)
+		return true
+	})
+
+	return nodes, err
+}
+
+func strategy(player *tree.Player, hand, board card.Cards, m abs.Mapper) ([]float64, bool) {
+	cl := m.Map(append(hand.Clone(), board...))
+	pol, ok := player.Actions.Policies.Get(cl)
+	if !ok {
+		return nil, false
+	}
+	return pol.GetAverageStrategy(), true
+}
+
+func combos(player *tree.Player, board card.Cards, m abs.Mapper, weights *card.RangeDist) (hands []Hand) {
+	for i := 0; i < 1326; i++ {
+		hand := card.RangeCards(i)
+		if card.IsAnyMatch(hand, board) {
+			continue
+		}
+
+		st, ok := strategy(player, hand, board, m)
+		if !ok {
+			continue
+		}
+
+		h := Hand{Hand: card.ComboName(hand), Strategy: st}
+		if weights != nil {
+			h.Weight = weights[card.RangeIndex(hand)]
+		}
+
+		hands = append(hands, h)
+	}
+	return hands
+}
+
+func classes(player *tree.Player, board card.Cards, m abs.Mapper, weights *card.RangeDist) (hands []Hand) {
+	card.ForCoords(func(x, y int, _ []card.Cards) {
+		var sum []float64
+		var weight float64
+		var count int
+
+		for _, hand := range card.CardsInCoordsWithBlockersAt(x, y, board) {
+			st, ok := strategy(player, hand, board, m)
+			if !ok {
+				continue
+			}
+			if sum == nil {
+				sum = make([]float64, len(st))
+			}
+			for i, v := range st {
+				sum[i] += v
+			}
+			if weights != nil {
+				weight += weights[card.RangeIndex(hand)]
+			}
+			count++
+		}
+
+		if count == 0 {
+			return
+		}
+
+		for i := range sum {
+			sum[i] /= float64(count)
+		}
+
+		hands = append(hands, Hand{
+			Hand:     card.CoordsName(x, y),
+			Strategy: sum,
+			Weight:   weight / float64(count),
+		})
+	})
+	return hands
+}
@@ -0,0 +1,151 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	absp "github.com/pokerdroid/poker/abs/pack"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/chips"
+	"github.com/pokerdroid/poker/table"
+	"github.com/pokerdroid/poker/tree"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTree(t *testing.T) (*tree.Root, *tree.Player) {
+	p := table.NewGameParams(2, chips.NewFromInt(20))
+	p.BetSizes = [][]float32{{1}}
+
+	root, err := tree.NewRoot(p)
+	require.NoError(t, err)
+
+	require.NoError(t, tree.Expand(root, root))
+	chance := root.Next.(*tree.Chance)
+	require.NoError(t, tree.Expand(root, chance))
+	player := chance.Next.(*tree.Player)
+	require.NoError(t, tree.Expand(root, player))
+
+	abs := absp.NewIso()
+
+	// AA always takes the last action, AKs splits evenly
+	// between first and last.
+	aa := player.Acquire(root, abs.Map(card.Cards{card.CardAC, card.CardAD}))
+	aa.StrategySum[len(aa.StrategySum)-1] = 1
+
+	aks := player.Acquire(root, abs.Map(card.Cards{card.CardAC, card.CardKC}))
+	aks.StrategySum[0] = 1
+	aks.StrategySum[len(aks.StrategySum)-1] = 1
+
+	return root, player
+}
+
+func TestExportClasses(t *testing.T) {
+	root, player := newTestTree(t)
+	last := len(player.Actions.Actions) - 1
+
+	nodes, err := New(Params{
+		Tree:    root,
+		Abs:     absp.NewIso(),
+		Depth:   2,
+		Classes: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+
+	n := nodes[0]
+	require.Equal(t, "preflop", n.Street)
+	require.Equal(t, player.Actions.Actions, n.Actions)
+	require.Len(t, n.Hands, 2)
+
+	require.Equal(t, "AA", n.Hands[0].Hand)
+	require.Equal(t, 1., n.Hands[0].Strategy[last])
+	require.Equal(t, "AKs", n.Hands[1].Hand)
+
+	require.Equal(t, "AKs:0.5", n.Ranges[0])
+	require.Equal(t, "AA:1,AKs:0.5", n.Ranges[last])
+}
+
+func TestExportCombos(t *testing.T) {
+	root, _ := newTestTree(t)
+
+	nodes, err := New(Params{
+		Tree:  root,
+		Abs:   absp.NewIso(),
+		Depth: 2,
+	})
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+
+	// 6 combos of AA and 4 of AKs
+	require.Len(t, nodes[0].Hands, 10)
+}
+
+func TestExportSkipsUncoveredStreets(t *testing.T) {
+	root, _ := newTestTree(t)
+
+	_, err := New(Params{Tree: root, Abs: absp.NewIso(), Board: card.Cards{card.CardAC}})
+	require.Error(t, err)
+
+	// Flop nodes are skipped without board, preflop is exported.
+	nodes, err := New(Params{Tree: root, Abs: absp.NewIso(), Depth: -1, Classes: true})
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+}
+
+func TestWriteFormats(t *testing.T) {
+	root, player := newTestTree(t)
+
+	nodes, err := New(Params{Tree: root, Abs: absp.NewIso(), Depth: 2, Classes: true})
+	require.NoError(t, err)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, WriteJSON(buf, nodes))
+
+	var decoded []Node
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, nodes, decoded)
+
+	buf.Reset()
+	require.NoError(t, WriteCSV(buf, nodes))
+
+	rows, err := csv.NewReader(buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 1+2*len(player.Actions.Actions))
+	require.Equal(t, "AA", rows[1][3])
+
+	buf.Reset()
+	require.NoError(t, WriteRanges(buf, nodes))
+	require.Contains(t, buf.String(), "AA:1,AKs:0.5")
+}
+
+func TestExportReach(t *testing.T) {
+	root, player := newTestTree(t)
+	require.NoError(t, tree.ExpandFull(root))
+
+	_, err := New(Params{Tree: player, Abs: absp.NewIso(), Reach: true})
+	require.Error(t, err)
+
+	nodes, err := New(Params{Tree: root, Abs: absp.NewIso(), Depth: 3, Classes: true, Reach: true})
+	require.NoError(t, err)
+	require.Greater(t, len(nodes), 1)
+
+	require.Equal(t, 1., nodes[0].Reach)
+	require.Equal(t, 1., nodes[0].Hands[0].Weight)
+
+	// AA never takes the first action and AKs half of the time, range
+	// of the other player acting next is untouched.
+	first := tree.GetPath(player.Actions.Nodes[0]).String()
+	for _, n := range nodes {
+		if n.Path != first {
+			continue
+		}
+		require.Less(t, n.Reach, 1.)
+		for _, h := range n.Hands {
+			require.Equal(t, 1., h.Weight)
+		}
+		return
+	}
+	t.Fatalf("node %s not exported", first)
+}
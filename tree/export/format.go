@@ -0,0 +1,86 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RangeText formats frequency of given action as range text
+// understood by other solvers, e.g. "AKs:0.5,QQ:1".
+// Hands that never take the action are left out.
+func RangeText(hands []Hand, action int) string {
+	parts := make([]string, 0, len(hands))
+	for _, h := range hands {
+		if action >= len(h.Strategy) {
+			continue
+		}
+		f := formatFreq(h.Strategy[action])
+		if f == "0" {
+			continue
+		}
+		parts = append(parts, h.Hand+":"+f)
+	}
+	return strings.Join(parts, ",")
+}
+
+func formatFreq(f float64) string {
+	return strconv.FormatFloat(math.Round(f*10000)/10000, 'f', -1, 64)
+}
+
+// WriteJSON writes nodes as JSON array.
+func WriteJSON(w io.Writer, nodes []Node) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(nodes)
+}
+
+// WriteCSV writes nodes in long format, single row per
+// node, hand and action.
+func WriteCSV(w io.Writer, nodes []Node) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write([]string{"path", "street", "player", "hand", "action", "frequency"})
+	if err != nil {
+		return err
+	}
+
+	for _, n := range nodes {
+		for _, h := range n.Hands {
+			for i, a := range n.Actions {
+				err = cw.Write([]string{
+					n.Path,
+					n.Street,
+					strconv.Itoa(int(n.Player)),
+					h.Hand,
+					a.Short(),
+					formatFreq(h.Strategy[i]),
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteRanges writes range text for every node and action,
+// one line per action.
+func WriteRanges(w io.Writer, nodes []Node) error {
+	for _, n := range nodes {
+		for i, a := range n.Actions {
+			_, err := fmt.Fprintf(w, "%s %s: %s\n", n.Path, a.Short(), n.Ranges[i])
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
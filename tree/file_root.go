@@ -5,6 +5,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pokerdroid/poker/abs"
+	absp "github.com/pokerdroid/poker/abs/pack"
 )
 
 type FileRoot struct {
@@ -71,11 +75,70 @@ func (f FileRoots) Roots() []*Root {
 	return roots
 }
 
+// AbsID returns ID of abstraction the solutions were trained with, it
+// fails when solutions were trained with different abstractions.
+func (f FileRoots) AbsID() (uuid.UUID, error) {
+	if len(f) == 0 {
+		return uuid.Nil, fmt.Errorf("no solutions")
+	}
+
+	id := f[0].Root.AbsID
+	for _, r := range f[1:] {
+		if r.Root.AbsID != id {
+			return uuid.Nil, fmt.Errorf("%w: %s and %s were trained with different abstractions",
+				ErrAbsMismatch, f[0].File.Name(), r.File.Name())
+		}
+	}
+
+	return id, nil
+}
+
+// FindAbs returns path to packed abstraction the solutions were trained
+// with, dirs are searched recursively.
+func (f FileRoots) FindAbs(dirs ...string) (string, error) {
+	id, err := f.AbsID()
+	if err != nil {
+		return "", err
+	}
+	return absp.FindFile(id, dirs...)
+}
+
+// Abs returns abstraction the solutions were trained with. Solutions
+// trained lossless get iso of their variant, packed abstraction is
+// searched in dirs otherwise.
+func (f FileRoots) Abs(dirs ...string) (abs.Mapper, error) {
+	id, err := f.AbsID()
+	if err != nil {
+		return nil, err
+	}
+	if id == uuid.Nil {
+		return absp.NewIsoVariant(f[0].Root.Params.Variant), nil
+	}
+
+	path, err := absp.FindFile(id, dirs...)
+	if err != nil {
+		return nil, err
+	}
+	return absp.NewFromFile(path)
+}
+
+// CheckAbs returns error naming the first solution trained with other
+// abstraction than m.
+func (f FileRoots) CheckAbs(m abs.Mapper) error {
+	for _, r := range f {
+		if err := r.Root.CheckAbs(m); err != nil {
+			return fmt.Errorf("%s: %w", r.File.Name(), err)
+		}
+	}
+	return nil
+}
+
 func (f FileRoots) String() string {
 	var s strings.Builder
 	ws := s.WriteString
 	for _, r := range f {
 		ws("Solution:\n")
+		ws(fmt.Sprintf("\tAbsID: %s\n", r.Root.AbsID))
 		ws(fmt.Sprintf("\tInitialStacks: %v\n", r.Root.Params.InitialStacks))
 		ws(fmt.Sprintf("\tBetSizes: %v\n", r.Root.Params.BetSizes))
 		ws(fmt.Sprintf("\tMaxActionsPerRound: %d\n", r.Root.Params.MaxActionsPerRound))
@@ -15,10 +15,14 @@ type NewFullTreeParams struct {
 	Terminal   table.Street `json:"terminal"` // Changed to table2.Street
 	MinBet     bool         `json:"min_bet"`
 	Limp       bool         `json:"limp"`
+	Ante       chips.Chips  `json:"ante"`
+	BBAnte     chips.Chips  `json:"bb_ante"`
+	// Structure is betting structure, no limit by default.
+	Structure table.BettingStructure `json:"structure"`
 }
 
 func (t NewFullTreeParams) Name() string {
-	return fmt.Sprintf(
+	name := fmt.Sprintf(
 		"tree_p%d_b%d_ma%d_bb%.0f_t%s",
 		t.NumPlayers,
 		len(t.Betting),
@@ -26,6 +30,13 @@ func (t NewFullTreeParams) Name() string {
 		t.BigBlind,
 		t.Terminal,
 	)
+	if t.Ante > 0 || t.BBAnte > 0 {
+		name += fmt.Sprintf("_a%.2f_bba%.2f", t.Ante, t.BBAnte)
+	}
+	if t.Structure != table.NoLimit {
+		name += "_" + t.Structure.String()
+	}
+	return name
 }
 
 func NewFullTree(params NewFullTreeParams) (*Root, error) {
@@ -40,6 +51,9 @@ func NewFullTree(params NewFullTreeParams) (*Root, error) {
 		TerminalStreet:     params.Terminal,
 		MinBet:             params.MinBet,
 		Limp:               params.Limp,
+		Ante:               params.Ante,
+		BBAnte:             params.BBAnte,
+		Structure:          params.Structure,
 	}
 
 	// Set initial stacks for all players (in big blinds)
@@ -50,7 +64,7 @@ func NewFullTree(params NewFullTreeParams) (*Root, error) {
 	// Create initial state
 	state := table.NewState(gameParams)
 
-	// Make initial bets (SB and BB)
+	// Make initial bets (SB, BB and antes)
 	state, err := table.MakeInitialBets(gameParams, state)
 	if err != nil {
 		return nil, err
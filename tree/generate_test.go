@@ -84,6 +84,59 @@ func TestNewFullTree(t *testing.T) {
 	}
 }
 
+func TestNewFullTreeAntes(t *testing.T) {
+	params := NewFullTreeParams{
+		BigBlind:   chips.NewFromInt(100),
+		NumPlayers: 2,
+		Betting:    [][]float32{},
+		MaxActions: 2,
+		Terminal:   table.Flop,
+		Limp:       true,
+		Ante:       chips.NewFromFloat(0.5),
+		BBAnte:     chips.NewFromInt(1),
+	}
+
+	root, err := NewFullTree(params)
+	require.NoError(t, err)
+	require.Equal(t, params.Ante, root.Params.Ante)
+	require.Contains(t, params.Name(), "_a0.50_bba1.00")
+
+	leafs := FindLeafNodes(root)
+
+	fold := findLeafByPath(leafs, "r:n:f:t")
+	require.NotNil(t, fold)
+	require.Equal(t, chips.NewFromFloat(1.5), fold.Players[0].Paid)
+	require.Equal(t, chips.NewFromFloat(3.5), fold.Players[1].Paid)
+	require.Equal(t, float32(5), fold.Pots.Sum().Float32())
+
+	// Antes are dead money, all-in is for what is left of the stack.
+	call := findLeafByPath(leafs, "r:n:a:c:t")
+	require.NotNil(t, call)
+	require.Equal(t, float32(200), call.Pots.Sum().Float32())
+}
+
+func TestNewFullTreeFixedLimit(t *testing.T) {
+	params := NewFullTreeParams{
+		BigBlind:   chips.NewFromInt(100),
+		NumPlayers: 2,
+		MaxActions: 8,
+		Terminal:   table.Flop,
+		Limp:       true,
+		Structure:  table.FixedLimit,
+	}
+
+	root, err := NewFullTree(params)
+	require.NoError(t, err)
+	require.Contains(t, params.Name(), "_fixedlimit")
+
+	for _, r := range FindLeafNodes(root) {
+		x := r.(*Terminal)
+		require.NotContains(t, GetPath(x).String(), ":a:")
+		// Preflop and flop are capped at four small bets each.
+		require.LessOrEqual(t, x.Players.PaidSum().Float32(), float32(32))
+	}
+}
+
 // Helper function to find leaf node by path
 func findLeafByPath(leafs []Node, path string) *Terminal {
 	for _, r := range leafs {
@@ -56,7 +56,7 @@ func MapGameStateToTree(p table.GameParams, s *table.State, rx *tree.Root) (*tre
 			return nil, errors.New("current node is not a player node")
 		}
 
-		if pa.Action.Action.IsBlind() {
+		if pa.Action.Action.IsForced() {
 			continue
 		}
 
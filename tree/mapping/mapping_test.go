@@ -43,6 +43,32 @@ func TestMapGameStateToTree_Basic(t *testing.T) {
 	t.Logf("Found player node with TurnPos=%d", playerNode.TurnPos)
 }
 
+func TestMapGameStateToTree_Antes(t *testing.T) {
+	// Tree is in chips with SB 1, game in chips with SB 5.
+	prms := table.NewGameParams(2, chips.NewFromInt(20))
+	prms.BetSizes = [][]float32{{1, 2}}
+	prms.BBAnte = chips.NewFromInt(2)
+
+	root, err := tree.NewRoot(prms)
+	require.NoError(t, err)
+	require.NoError(t, tree.ExpandFull(root))
+
+	game := table.NewGameParams(2, chips.NewFromInt(100))
+	game.SbAmount = chips.NewFromInt(5)
+	game.BBAnte = chips.NewFromInt(10)
+	game.BetSizes = prms.BetSizes
+
+	s, err := table.NewGame(game)
+	require.NoError(t, err)
+
+	// Pot is 25 with ante, so pot sized raise is 25 more on top of the call.
+	require.NoError(t, s.Action(table.ActionAmount{Action: table.Raise, Amount: chips.NewFromInt(30)}))
+
+	p, err := MapGameStateToTree(game, s.Latest, root)
+	require.NoError(t, err)
+	require.Equal(t, "r:ante2.00:n:b6.00", p.State.Path(1))
+}
+
 // TestMapGameStateToTree_NoRoot tests when we provide a nil root to the
 func TestMapGameStateToTree_NoRoot(t *testing.T) {
 	prms := table.NewGameParams(2, chips.NewFromInt(10))
@@ -40,6 +40,15 @@ func (ch *Player) GetTurnPos() uint8 {
 	return ch.TurnPos
 }
 
+// GetStreet returns street of the decision, NoStreet if state
+// is not known.
+func (ch *Player) GetStreet() table.Street {
+	if ch.State == nil {
+		return table.NoStreet
+	}
+	return ch.State.Street
+}
+
 func (ch *Player) Acquire(r *Root, c abs.Cluster) *policy.Policy {
 	return ch.Actions.Acquire(r, c)
 }
@@ -219,7 +228,7 @@ func MakeNode(e *Root, n *Player, a table.DiscreteAction) (Node, error) {
 		}
 
 	case table.RuleShiftStreet:
-		err = table.ShiftStreet(s)
+		err = table.ShiftStreet(e.Params, s)
 		if err != nil {
 			return nil, err
 		}
@@ -63,6 +63,20 @@ func (p *Policies) Delete(cl abs.Cluster) {
 	p.mux.Unlock()
 }
 
+// Max returns the biggest cluster with policy.
+func (p *Policies) Max() (abs.Cluster, bool) {
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+
+	var max abs.Cluster
+	for c := range p.Map {
+		if c > max {
+			max = c
+		}
+	}
+	return max, len(p.Map) > 0
+}
+
 func (p *Policies) Len() uint32 {
 	return uint32(len(p.Map))
 }
@@ -0,0 +1,323 @@
+package tree
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/table"
+)
+
+// NodeRanges are hand ranges of every player at a player node.
+type NodeRanges struct {
+	// Weights are per player reach weights of each of 1326 combos.
+	// They are not normalized, combos blocked by board are zero.
+	Weights []card.RangeDist
+	// Reach is the frequency the node is reached, as product of
+	// each player's remaining range mass. It ignores card removal
+	// between players.
+	Reach float64
+}
+
+// Range returns normalized range of given player.
+func (n *NodeRanges) Range(p uint8) card.RangeDist {
+	return n.Weights[p].Normalize()
+}
+
+// Ranges annotates player nodes of a tree with ranges
+// for a single board. Safe for concurrent use.
+type Ranges struct {
+	Board card.Cards
+	abs   abs.Mapper
+	init  []card.RangeDist
+	total float64
+	mu    sync.Mutex
+	nodes map[*Player]*NodeRanges
+}
+
+// NewRanges creates ranges of the tree for the board without
+// annotating any node, Get computes ranges of a node and its
+// ancestors on first use.
+func NewRanges(root *Root, m abs.Mapper, board card.Cards) (*Ranges, error) {
+	if root == nil {
+		return nil, errors.New("root is nil")
+	}
+
+	if m == nil {
+		return nil, errors.New("abstraction is nil")
+	}
+
+	r := &Ranges{
+		Board: board.Clone(),
+		abs:   m,
+		init:  make([]card.RangeDist, root.Params.NumPlayers),
+		nodes: make(map[*Player]*NodeRanges),
+	}
+
+	for i := 0; i < 1326; i++ {
+		if card.IsAnyMatch(card.RangeCards(i), board) {
+			continue
+		}
+		for p := range r.init {
+			r.init[p][i] = 1
+		}
+	}
+
+	if len(r.init) > 0 {
+		r.total = r.init[0].Sum()
+	}
+
+	return r, nil
+}
+
+// Get returns ranges at given node. Reference nodes are resolved
+// to node they point to.
+func (r *Ranges) Get(n Node) (*NodeRanges, bool) {
+	if ref, ok := n.(*Reference); ok {
+		n = ref.Node
+	}
+	p, ok := n.(*Player)
+	if !ok {
+		return nil, false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.get(p)
+}
+
+// get returns ranges of p, computing them from the nearest player
+// above when p isn't annotated yet.
+func (r *Ranges) get(p *Player) (*NodeRanges, bool) {
+	if nr, ok := r.nodes[p]; ok {
+		return nr, true
+	}
+
+	if _, ok := StreetBoard(p.State.Street, r.Board); !ok {
+		return nil, false
+	}
+
+	var cur Node = p
+	for {
+		switch parent := cur.GetParent().(type) {
+		case nil, *Root:
+			return r.annotate(p, r.init), true
+
+		case *Player:
+			if parent.Actions == nil {
+				return nil, false
+			}
+			a, ok := parent.GetActionIdx(cur)
+			if !ok {
+				return nil, false
+			}
+			pr, ok := r.get(parent)
+			if !ok {
+				return nil, false
+			}
+			board, _ := StreetBoard(parent.State.Street, r.Board)
+			strats := r.strategies(parent, r.abs, board, pr.Weights[parent.TurnPos])
+			return r.annotate(p, next(pr.Weights, parent.TurnPos, strats, a)), true
+
+		default:
+			cur = parent
+		}
+	}
+}
+
+// annotate stores ranges of p given weights of players.
+func (r *Ranges) annotate(p *Player, weights []card.RangeDist) *NodeRanges {
+	reach := 1.
+	for _, w := range weights {
+		reach *= w.Sum() / r.total
+	}
+
+	nr := &NodeRanges{Weights: weights, Reach: reach}
+	r.nodes[p] = nr
+	return nr
+}
+
+// Len returns number of annotated nodes.
+func (r *Ranges) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.nodes)
+}
+
+// PropagateRanges walks the tree once and computes ranges of every
+// reachable player node given the board. Player nodes on streets
+// the board doesn't cover are not annotated. References are expanded,
+// use NewRanges to annotate only nodes asked for.
+//
+// Same as cfr.ComputeRange, combos without policy keep their weight.
+func PropagateRanges(root *Root, m abs.Mapper, board card.Cards) (*Ranges, error) {
+	r, err := NewRanges(root, m, board)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, r.propagate(root.Next, m, r.init)
+}
+
+func (r *Ranges) propagate(n Node, m abs.Mapper, weights []card.RangeDist) error {
+	switch x := n.(type) {
+	case nil:
+		return nil
+
+	case *Chance:
+		return r.propagate(x.Next, m, weights)
+
+	case *Reference:
+		nx, err := x.Expand()
+		if err != nil {
+			return err
+		}
+		return r.propagate(nx, m, weights)
+
+	case *Player:
+		board, ok := StreetBoard(x.State.Street, r.Board)
+		if !ok {
+			return nil
+		}
+
+		r.annotate(x, weights)
+
+		if x.Actions == nil || len(x.Actions.Actions) == 0 {
+			return nil
+		}
+
+		strats := r.strategies(x, m, board, weights[x.TurnPos])
+
+		for a, child := range x.Actions.Nodes {
+			if err := r.propagate(child, m, next(weights, x.TurnPos, strats, a)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// next returns weights after player at turn took action a.
+func next(weights []card.RangeDist, turn uint8, strats [][]float64, a int) []card.RangeDist {
+	nx := make([]card.RangeDist, len(weights))
+	copy(nx, weights)
+
+	for i, st := range strats {
+		if st == nil {
+			continue
+		}
+		nx[turn][i] *= st[a]
+	}
+	return nx
+}
+
+// strategies returns average strategy for every combo in range,
+// nil for combos out of range or without policy.
+func (r *Ranges) strategies(p *Player, m abs.Mapper, board card.Cards, w card.RangeDist) [][]float64 {
+	strats := make([][]float64, len(w))
+	cache := make(map[abs.Cluster][]float64)
+
+	for i := range w {
+		if w[i] == 0 {
+			continue
+		}
+
+		cl := m.Map(append(card.RangeCards(i).Clone(), board...))
+
+		st, ok := cache[cl]
+		if !ok {
+			if pol, found := p.Actions.Policies.Get(cl); found {
+				st = pol.GetAverageStrategy()
+			}
+			cache[cl] = st
+		}
+
+		strats[i] = st
+	}
+
+	return strats
+}
+
+// StreetBoard returns cards of board dealt by street s, false when
+// board doesn't cover the street.
+func StreetBoard(s table.Street, board card.Cards) (card.Cards, bool) {
+	var n int
+	switch s {
+	case table.Preflop:
+		n = 0
+	case table.Flop:
+		n = 3
+	case table.Turn:
+		n = 4
+	case table.River:
+		n = 5
+	default:
+		return nil, false
+	}
+	if len(board) < n {
+		return nil, false
+	}
+	return board[:n], true
+}
+
+// RangeCache keeps ranges of the most recently used boards, see
+// NewRanges. Safe for concurrent use.
+type RangeCache struct {
+	root   *Root
+	abs    abs.Mapper
+	size   int
+	mu     sync.Mutex
+	boards map[string]*list.Element
+	lru    *list.List
+}
+
+// NewRangeCache creates cache keeping ranges of at most size boards.
+func NewRangeCache(root *Root, m abs.Mapper, size int) *RangeCache {
+	return &RangeCache{
+		root:   root,
+		abs:    m,
+		size:   max(size, 1),
+		boards: make(map[string]*list.Element),
+		lru:    list.New(),
+	}
+}
+
+// NewRangeCaches creates range cache of every root.
+func NewRangeCaches(roots []*Root, m abs.Mapper, size int) map[*Root]*RangeCache {
+	caches := make(map[*Root]*RangeCache, len(roots))
+	for _, r := range roots {
+		caches[r] = NewRangeCache(r, m, size)
+	}
+	return caches
+}
+
+// Get returns ranges for the board, nodes are annotated as they
+// are asked for.
+func (c *RangeCache) Get(board card.Cards) (*Ranges, error) {
+	key := string(board.Bytes())
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.boards[key]; ok {
+		c.lru.MoveToFront(e)
+		return e.Value.(*Ranges), nil
+	}
+
+	r, err := NewRanges(c.root, c.abs, board)
+	if err != nil {
+		return nil, err
+	}
+
+	c.boards[key] = c.lru.PushFront(r)
+
+	for c.lru.Len() > c.size {
+		old := c.lru.Remove(c.lru.Back()).(*Ranges)
+		delete(c.boards, string(old.Board.Bytes()))
+	}
+
+	return r, nil
+}
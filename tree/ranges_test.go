@@ -0,0 +1,138 @@
+package tree
+
+import (
+	"sync"
+	"testing"
+
+	absp "github.com/pokerdroid/poker/abs/pack"
+	"github.com/pokerdroid/poker/card"
+	"github.com/pokerdroid/poker/chips"
+	"github.com/pokerdroid/poker/table"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropagateRanges(t *testing.T) {
+	p := table.NewGameParams(2, chips.NewFromInt(20))
+	p.BetSizes = [][]float32{{1}}
+
+	root, err := NewRoot(p)
+	require.NoError(t, err)
+	require.NoError(t, ExpandFull(root))
+
+	player := root.Next.(*Chance).Next.(*Player)
+	require.Equal(t, table.DAllIn, player.Actions.Actions[0])
+	last := len(player.Actions.Actions) - 1
+
+	abs := absp.NewIso()
+
+	// AA never shoves, AKs shoves half of the time.
+	aa := player.Acquire(root, abs.Map(card.Cards{card.CardAC, card.CardAD}))
+	aa.StrategySum[last] = 1
+
+	aks := player.Acquire(root, abs.Map(card.Cards{card.CardAC, card.CardKC}))
+	aks.StrategySum[0] = 1
+	aks.StrategySum[last] = 1
+
+	rr, err := PropagateRanges(root, abs, card.Cards{})
+	require.NoError(t, err)
+
+	nr, ok := rr.Get(player)
+	require.True(t, ok)
+	require.Equal(t, 1., nr.Reach)
+	require.Equal(t, 1326., nr.Weights[0].Sum())
+
+	shove, ok := rr.Get(player.Actions.Nodes[0])
+	require.True(t, ok)
+
+	aaIdx := card.RangeIndex(card.Cards{card.CardAC, card.CardAD})
+	aksIdx := card.RangeIndex(card.Cards{card.CardKC, card.CardAC})
+
+	require.Equal(t, 0., shove.Weights[0][aaIdx])
+	require.Equal(t, 0.5, shove.Weights[0][aksIdx])
+	require.Equal(t, 1., shove.Weights[1][aaIdx])
+	require.InDelta(t, (1326.-6-2)/1326., shove.Reach, 1e-9)
+	require.InDelta(t, 1., shove.Range(0).Sum(), 1e-9)
+
+	// Without board postflop nodes are not annotated.
+	_, ok = rr.Get(findStreet(root, table.Flop))
+	require.False(t, ok)
+
+	// Lazy ranges annotate only the path to the node.
+	lazy, err := NewRanges(root, abs, card.Cards{})
+	require.NoError(t, err)
+
+	got, ok := lazy.Get(player.Actions.Nodes[0])
+	require.True(t, ok)
+	require.Equal(t, shove, got)
+	require.Equal(t, 2, lazy.Len())
+	require.Greater(t, rr.Len(), lazy.Len())
+}
+
+func TestPropagateRangesBoard(t *testing.T) {
+	p := table.NewGameParams(2, chips.NewFromInt(20))
+	p.BetSizes = [][]float32{{1}}
+
+	root, err := NewRoot(p)
+	require.NoError(t, err)
+	require.NoError(t, ExpandFull(root))
+
+	board := card.Cards{card.CardAC, card.CardKD, card.Card2H}
+
+	cache := NewRangeCache(root, absp.NewIso(), 1)
+
+	rr, err := cache.Get(board)
+	require.NoError(t, err)
+
+	flop := findStreet(root, table.Flop)
+	nr, ok := rr.Get(flop)
+	require.True(t, ok)
+
+	// Combos blocked by board are removed.
+	require.Equal(t, float64(card.CombinationsLen(49, 2)), nr.Weights[0].Sum())
+
+	// Lazy ranges pass through chance nodes as propagation does.
+	eager, err := PropagateRanges(root, absp.NewIso(), board)
+	require.NoError(t, err)
+	en, ok := eager.Get(flop)
+	require.True(t, ok)
+	require.Equal(t, en, nr)
+
+	again, err := cache.Get(board)
+	require.NoError(t, err)
+	require.Same(t, rr, again)
+
+	// Least recently used board is evicted.
+	_, err = cache.Get(card.Cards{card.CardAC, card.CardKD, card.Card3H})
+	require.NoError(t, err)
+
+	again, err = cache.Get(board)
+	require.NoError(t, err)
+	require.NotSame(t, rr, again)
+
+	// Concurrent callers share ranges of a board.
+	var wg sync.WaitGroup
+	got := make([]*Ranges, 4)
+	for i := range got {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got[i], _ = cache.Get(card.Cards{card.CardAC, card.CardKD, card.Card4H})
+		}()
+	}
+	wg.Wait()
+
+	for _, r := range got {
+		require.NotNil(t, r)
+		require.Same(t, got[0], r)
+	}
+}
+
+func findStreet(root *Root, s table.Street) (found *Player) {
+	MustVisit(root, -1, func(n Node, _ []Node, _ int) bool {
+		if p, ok := n.(*Player); ok && found == nil && p.State.Street == s {
+			found = p
+		}
+		return found == nil
+	})
+	return found
+}
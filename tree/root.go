@@ -1,11 +1,14 @@
 package tree
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/pokerdroid/poker/abs"
 	"github.com/pokerdroid/poker/chips"
 	"github.com/pokerdroid/poker/table"
 )
@@ -35,6 +38,93 @@ func NewRoot(prms table.GameParams) (r *Root, err error) {
 	return r, nil
 }
 
+// ErrAbsMismatch is returned when solution is paired with abstraction
+// it wasn't trained with.
+var ErrAbsMismatch = errors.New("abstraction mismatch")
+
+// CheckAbs returns ErrAbsMismatch when root was trained with other
+// abstraction than m. Mappers without ID aren't checked. Bucket counts
+// of mappers which know them must cover clusters in the tree, which
+// walks the whole tree, so it's meant for loading.
+func (r *Root) CheckAbs(m abs.Mapper) error {
+	if _, ok := m.(abs.Identified); !ok {
+		return nil
+	}
+
+	if err := r.CheckAbsID(m); err != nil {
+		return err
+	}
+
+	if c, ok := m.(abs.Counted); ok {
+		return checkBuckets(r.Next, c.Buckets())
+	}
+
+	return nil
+}
+
+// CheckAbsID is CheckAbs comparing only abstraction IDs, cheap enough
+// for every decision of root already checked on load.
+func (r *Root) CheckAbsID(m abs.Mapper) error {
+	x, ok := m.(abs.Identified)
+	if !ok || x.ID() == r.AbsID {
+		return nil
+	}
+
+	got := absName(x.ID())
+	if s, ok := m.(fmt.Stringer); ok && x.ID() != uuid.Nil {
+		got = s.String()
+	}
+
+	return fmt.Errorf("%w: solution was trained with %s, loaded %s",
+		ErrAbsMismatch, absName(r.AbsID), got)
+}
+
+// checkBuckets returns ErrAbsMismatch when node holds policy of cluster
+// the abstraction doesn't have. References aren't expanded.
+func checkBuckets(n Node, buckets [4]uint32) error {
+	switch x := n.(type) {
+	case *Chance:
+		if x != nil {
+			return checkBuckets(x.Next, buckets)
+		}
+
+	case *Reference:
+		if x != nil {
+			return checkBuckets(x.Node, buckets)
+		}
+
+	case *Player:
+		if x == nil || x.Actions == nil {
+			return nil
+		}
+
+		st := x.State.Street
+		if st >= table.Preflop && st <= table.River && x.Actions.Policies != nil {
+			n := buckets[st-table.Preflop]
+			c, ok := x.Actions.Policies.Max()
+			if n > 0 && ok && uint32(c) >= n {
+				return fmt.Errorf("%w: solution has %s cluster %d, abstraction has %d clusters",
+					ErrAbsMismatch, st, c, n)
+			}
+		}
+
+		for _, c := range x.Actions.Nodes {
+			if err := checkBuckets(c, buckets); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func absName(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return "lossless abstraction"
+	}
+	return "abstraction " + id.String()
+}
+
 func NewRootFromReadSeeker(rs io.ReadSeeker) (*Root, error) {
 	root := &Root{}
 	return root, root.ReadBinary(rs)
@@ -75,6 +165,46 @@ func FindClosestRoot(roots []*Root, p table.GameParams, turn uint8) *Root {
 	return closest
 }
 
+// FindBracketingRoots finds roots with the closest effective stack
+// below (lo) and above (hi) the given game, both in big blinds.
+// Weight is how much hi should count, 0 means only lo.
+//
+// If game is out of range of the roots, both are the closest root.
+func FindBracketingRoots(roots []*Root, p table.GameParams, turn uint8) (lo, hi *Root, weight float64) {
+	bbs := func(g table.GameParams) float64 {
+		return g.EffectiveStack(turn).Div(g.SbAmount.Mul(2)).Float64()
+	}
+
+	effs := bbs(p)
+
+	var lod, hid float64
+	for _, r := range roots {
+		if r.Params.NumPlayers != p.NumPlayers {
+			continue
+		}
+
+		d := bbs(r.Params)
+
+		if d <= effs && (lo == nil || d > lod) {
+			lo, lod = r, d
+		}
+		if d >= effs && (hi == nil || d < hid) {
+			hi, hid = r, d
+		}
+	}
+
+	switch {
+	case lo == nil:
+		return hi, hi, 0
+	case hi == nil:
+		return lo, lo, 0
+	case hid == lod:
+		return lo, hi, 0
+	}
+
+	return lo, hi, (effs - lod) / (hid - lod)
+}
+
 func NewFromFile(path string) (*Root, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -5,6 +5,9 @@ import (
 	"encoding/binary"
 	"testing"
 
+	"github.com/google/uuid"
+	"github.com/pokerdroid/poker/abs"
+	"github.com/pokerdroid/poker/card"
 	"github.com/pokerdroid/poker/chips"
 	"github.com/pokerdroid/poker/table"
 	"github.com/stretchr/testify/require"
@@ -221,6 +224,37 @@ func TestFindClosestRoot(t *testing.T) {
 	require.Equal(t, rootC, closest)
 }
 
+func TestFindBracketingRoots(t *testing.T) {
+	var roots []*Root
+	for _, bb := range []int64{50, 80, 20} {
+		roots = append(roots, &Root{Params: table.NewGameParams(2, chips.NewFromInt(bb*2))})
+	}
+
+	// 63bb with blinds 5/10.
+	p := table.NewGameParams(2, chips.NewFromInt(630))
+	p.SbAmount = chips.NewFromInt(5)
+
+	lo, hi, w := FindBracketingRoots(roots, p, 0)
+	require.Equal(t, roots[0], lo)
+	require.Equal(t, roots[1], hi)
+	require.InDelta(t, 13./30., w, 1e-6)
+
+	// Exact match.
+	lo, hi, w = FindBracketingRoots(roots, table.NewGameParams(2, chips.NewFromInt(100)), 0)
+	require.Equal(t, roots[0], lo)
+	require.Equal(t, roots[0], hi)
+	require.Equal(t, 0., w)
+
+	// Out of range.
+	lo, hi, w = FindBracketingRoots(roots, table.NewGameParams(2, chips.NewFromInt(400)), 0)
+	require.Equal(t, roots[1], lo)
+	require.Equal(t, roots[1], hi)
+	require.Equal(t, 0., w)
+
+	lo, _, _ = FindBracketingRoots(roots, table.NewGameParams(3, chips.NewFromInt(100)), 0)
+	require.Nil(t, lo)
+}
+
 func TestRoot_Size(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -345,3 +379,70 @@ func TestRoot_Size(t *testing.T) {
 		})
 	}
 }
+
+type idMapper uuid.UUID
+
+func (m idMapper) Map(cds card.Cards) abs.Cluster {
+	return 0
+}
+
+func (m idMapper) ID() uuid.UUID {
+	return uuid.UUID(m)
+}
+
+func TestRoot_CheckAbs(t *testing.T) {
+	id := uuid.New()
+	root := &Root{AbsID: id}
+
+	require.NoError(t, root.CheckAbs(idMapper(id)))
+
+	err := root.CheckAbs(idMapper(uuid.New()))
+	require.ErrorIs(t, err, ErrAbsMismatch)
+	require.Contains(t, err.Error(), id.String())
+
+	// Tree trained with lossless abstraction.
+	err = (&Root{}).CheckAbs(idMapper(id))
+	require.ErrorIs(t, err, ErrAbsMismatch)
+	require.Contains(t, err.Error(), "lossless")
+
+	// Mappers without ID aren't checked.
+	require.NoError(t, root.CheckAbs(struct{ abs.Mapper }{}))
+}
+
+type countedMapper struct {
+	idMapper
+	buckets [4]uint32
+}
+
+func (m countedMapper) Buckets() [4]uint32 {
+	return m.buckets
+}
+
+func TestRoot_CheckAbsBuckets(t *testing.T) {
+	prms := table.NewGameParams(2, chips.NewFromInt(10))
+	prms.TerminalStreet = table.Preflop
+
+	root, err := NewRoot(prms)
+	require.NoError(t, err)
+	require.NoError(t, ExpandFull(root))
+
+	id := uuid.New()
+	root.AbsID = id
+
+	p := root.Next.(*Chance).Next.(*Player)
+	p.Acquire(root, 168).Unlock()
+
+	require.NoError(t, root.CheckAbs(countedMapper{idMapper(id), [4]uint32{169, 10, 10, 10}}))
+
+	// Unknown counts aren't checked.
+	p.Acquire(root, 200).Unlock()
+	require.NoError(t, root.CheckAbs(countedMapper{idMapper(id), [4]uint32{}}))
+
+	err = root.CheckAbs(countedMapper{idMapper(id), [4]uint32{169, 10, 10, 10}})
+	require.ErrorIs(t, err, ErrAbsMismatch)
+	require.Contains(t, err.Error(), "preflop cluster 200")
+
+	// IDs only.
+	require.NoError(t, root.CheckAbsID(countedMapper{idMapper(id), [4]uint32{169, 10, 10, 10}}))
+	require.ErrorIs(t, root.CheckAbsID(idMapper(uuid.New())), ErrAbsMismatch)
+}